@@ -40,6 +40,13 @@ import (
 
 var log = logging.GetLogger("main")
 
+const (
+	enableWebhooksEnv = "ENABLE_WEBHOOKS"
+	webhookPort       = 9443
+	webhookCertDir    = "/etc/webhook/certs"
+	metricsAddress    = ":8080"
+)
+
 func printVersion() {
 	log.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
 	log.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
@@ -77,7 +84,13 @@ func main() {
 	}()
 
 	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{Namespace: namespace})
+	enableWebhooks := os.Getenv(enableWebhooksEnv) == "true"
+	options := manager.Options{Namespace: namespace, MetricsBindAddress: metricsAddress}
+	if enableWebhooks {
+		options.Port = webhookPort
+		options.CertDir = webhookCertDir
+	}
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -115,6 +128,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Add the storage/v2beta1 admission webhooks
+	if enableWebhooks {
+		storagev2beta1.AddWebhooks(mgr)
+	}
+
 	log.Info("Starting the Cmd.")
 
 	// Start the Cmd
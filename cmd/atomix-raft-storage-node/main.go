@@ -49,14 +49,16 @@ func main() {
 	nodeID := os.Args[1]
 	protocolConfig := parseProtocolConfig()
 	raftConfig := parseRaftConfig()
+	membershipConfig := parseMembershipConfig()
 
 	// Configure the Raft protocol
-	protocol := raft.NewProtocol(raftConfig)
+	protocol := raft.NewProtocol(raftConfig, membershipConfig)
 
 	ctrlCluster := cluster.NewCluster(cluster.NewNetwork(), protocolapi.ProtocolConfig{}, cluster.WithMemberID(nodeID), cluster.WithPort(monitoringPort))
 	member, _ := ctrlCluster.Member()
 	err := member.Serve(cluster.WithService(func(server *grpc.Server) {
 		raft.RegisterRaftEventsServer(server, raft.NewEventServer(protocol))
+		raft.RegisterRaftAdminServer(server, raft.NewAdminServer(protocol))
 	}))
 	if err != nil {
 		fmt.Println(err)
@@ -129,3 +131,24 @@ func parseRaftConfig() config.ProtocolConfig {
 	}
 	return protocolConfig
 }
+
+func parseMembershipConfig() *config.MembershipConfig {
+	if len(os.Args) < 5 {
+		return nil
+	}
+	membershipConfigFile := os.Args[4]
+	membershipConfig := &config.MembershipConfig{}
+	membershipBytes, err := ioutil.ReadFile(membershipConfigFile)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(membershipBytes) == 0 {
+		return nil
+	}
+	if err := jsonpb.Unmarshal(bytes.NewReader(membershipBytes), membershipConfig); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return membershipConfig
+}
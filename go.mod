@@ -11,6 +11,7 @@ require (
 	github.com/gogo/protobuf v1.3.1
 	github.com/hashicorp/golang-lru v0.5.3 // indirect
 	github.com/lni/dragonboat/v3 v3.1.1-0.20201211124920-79d5e54396f7
+	github.com/prometheus/client_golang v1.0.0
 	github.com/stretchr/testify v1.6.1
 	google.golang.org/grpc v1.33.2
 	k8s.io/api v0.17.2
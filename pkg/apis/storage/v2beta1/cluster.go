@@ -15,6 +15,7 @@
 package v2beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,6 +24,8 @@ type RaftClusterState string
 const (
 	RaftClusterNotReady RaftClusterState = "NotReady"
 	RaftClusterReady    RaftClusterState = "Ready"
+	// RaftClusterDegraded indicates all partitions are available but some are not fully replicated
+	RaftClusterDegraded RaftClusterState = "Degraded"
 )
 
 // RaftClusterSpec specifies a RaftClusterSpec configuration
@@ -30,9 +33,156 @@ type RaftClusterSpec struct {
 	ClusterID int32 `json:"clusterId,omitempty"`
 }
 
+type RaftClusterScalePhase string
+
+const (
+	RaftClusterAddingMember    RaftClusterScalePhase = "AddingMember"
+	RaftClusterCatchingUp      RaftClusterScalePhase = "CatchingUp"
+	RaftClusterRemovingMember  RaftClusterScalePhase = "RemovingMember"
+	RaftClusterRemovingReplica RaftClusterScalePhase = "RemovingReplica"
+)
+
+type RaftClusterUpgradePhase string
+
+const (
+	RaftClusterTransferringLeadership RaftClusterUpgradePhase = "TransferringLeadership"
+	RaftClusterRestarting             RaftClusterUpgradePhase = "Restarting"
+	RaftClusterRejoining              RaftClusterUpgradePhase = "Rejoining"
+)
+
+type RaftVolumeExpansionPhase string
+
+const (
+	// RaftVolumeExpansionResizing indicates the cluster's volume claims are being expanded
+	RaftVolumeExpansionResizing RaftVolumeExpansionPhase = "Resizing"
+	// RaftVolumeExpansionFailed indicates a volume claim could not be expanded
+	RaftVolumeExpansionFailed RaftVolumeExpansionPhase = "Failed"
+)
+
+type RaftPodMonitorState string
+
+const (
+	// RaftPodMonitorConnecting indicates the controller is connecting to the pod's event stream
+	RaftPodMonitorConnecting RaftPodMonitorState = "Connecting"
+	// RaftPodMonitorConnected indicates the controller is receiving events from the pod
+	RaftPodMonitorConnected RaftPodMonitorState = "Connected"
+	// RaftPodMonitorDisconnected indicates the controller lost the pod's event stream and is waiting to reconnect
+	RaftPodMonitorDisconnected RaftPodMonitorState = "Disconnected"
+)
+
+const (
+	// RaftClusterUpgradePaused indicates a rolling upgrade has been paused because a partition lost quorum
+	RaftClusterUpgradePaused ConditionType = "UpgradePaused"
+	// RaftClusterMajorityInFailureDomain indicates a majority of the cluster's replicas run in a single failure
+	// domain, so the failure of that domain would cause the cluster's partitions to lose quorum
+	RaftClusterMajorityInFailureDomain ConditionType = "MajorityInFailureDomain"
+)
+
 // RaftClusterStatus defines the status of a RaftCluster
 type RaftClusterStatus struct {
 	State RaftClusterState `json:"state,omitempty"`
+
+	// Replicas is the number of replicas that are voting members of the cluster's partitions
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// LastNodeID is the last Raft node ID allocated to a member of the cluster
+	LastNodeID int32 `json:"lastNodeId,omitempty"`
+
+	// Scale is the status of an in-progress scaling operation
+	Scale *RaftClusterScaleStatus `json:"scale,omitempty"`
+
+	// Upgrade is the status of an in-progress rolling upgrade
+	Upgrade *RaftClusterUpgradeStatus `json:"upgrade,omitempty"`
+
+	// VolumeExpansion is the status of an in-progress expansion of the cluster's data volumes
+	VolumeExpansion *RaftVolumeExpansionStatus `json:"volumeExpansion,omitempty"`
+
+	// LastLeaderTransferTime is the last time leadership of a partition was transferred to balance leaders
+	LastLeaderTransferTime *metav1.Time `json:"lastLeaderTransferTime,omitempty"`
+
+	// Monitors is the state of the controller's event streams from the cluster's pods
+	Monitors []RaftPodMonitorStatus `json:"monitors,omitempty"`
+
+	// Conditions is the current conditions of the cluster
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// RaftPodMonitorStatus is the state of the controller's event stream from a Raft pod
+type RaftPodMonitorStatus struct {
+	// Pod is the name of the pod
+	Pod string `json:"pod"`
+
+	// State is the state of the stream
+	State RaftPodMonitorState `json:"state,omitempty"`
+
+	// LastConnectTime is the last time the stream was connected
+	LastConnectTime *metav1.Time `json:"lastConnectTime,omitempty"`
+
+	// LastError is the error with which the stream last failed
+	LastError string `json:"lastError,omitempty"`
+}
+
+// RaftClusterScaleStatus defines the status of a RaftCluster scaling operation
+type RaftClusterScaleStatus struct {
+	// Replicas is the number of replicas to which the cluster is being scaled
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Replica is the replica being added to or removed from the cluster
+	Replica string `json:"replica,omitempty"`
+
+	// Phase is the phase of the operation for the replica
+	Phase RaftClusterScalePhase `json:"phase,omitempty"`
+
+	// StartTime is the time at which the operation for the replica was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// RaftClusterUpgradeStatus defines the status of a RaftCluster rolling upgrade
+type RaftClusterUpgradeStatus struct {
+	// Revision is the StatefulSet revision to which the cluster is being upgraded
+	Revision string `json:"revision,omitempty"`
+
+	// Replica is the replica being upgraded
+	Replica string `json:"replica,omitempty"`
+
+	// Phase is the phase of the upgrade for the replica
+	Phase RaftClusterUpgradePhase `json:"phase,omitempty"`
+
+	// StartTime is the time at which the upgrade of the replica was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// RestartTime is the time at which the replica's pod was deleted to restart it
+	RestartTime *metav1.Time `json:"restartTime,omitempty"`
+}
+
+// RaftVolumeExpansionStatus defines the status of the expansion of a RaftCluster's data volumes
+type RaftVolumeExpansionStatus struct {
+	// Size is the storage request to which the cluster's volume claims are being expanded
+	Size resource.Quantity `json:"size"`
+
+	// Phase is the phase of the expansion
+	Phase RaftVolumeExpansionPhase `json:"phase,omitempty"`
+
+	// Message is a human-readable message describing why the expansion failed
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time at which the expansion was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Claims is the progress of the expansion for each of the cluster's volume claims
+	Claims []RaftVolumeClaimExpansionStatus `json:"claims,omitempty"`
+}
+
+// RaftVolumeClaimExpansionStatus is the progress of the expansion of a single volume claim
+type RaftVolumeClaimExpansionStatus struct {
+	// Name is the name of the claim
+	Name string `json:"name"`
+
+	// Capacity is the storage capacity of the claim's volume
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+
+	// Message is a human-readable message describing the claim's pending resize
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
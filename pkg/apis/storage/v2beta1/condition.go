@@ -0,0 +1,57 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is the type of a resource condition
+type ConditionType string
+
+const (
+	// ConditionReady indicates all of a resource's members are ready
+	ConditionReady ConditionType = "Ready"
+	// ConditionAvailable indicates a resource is able to serve requests
+	ConditionAvailable ConditionType = "Available"
+	// ConditionDegraded indicates a resource is available with reduced redundancy
+	ConditionDegraded ConditionType = "Degraded"
+	// ConditionProgressing indicates a change to a resource is in progress
+	ConditionProgressing ConditionType = "Progressing"
+	// ConditionQuorumLost indicates a majority of a partition's voting members are unavailable
+	ConditionQuorumLost ConditionType = "QuorumLost"
+)
+
+// Condition describes an aspect of the state of a resource
+type Condition struct {
+	// Type is the type of the condition
+	Type ConditionType `json:"type"`
+
+	// Status is the status of the condition
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Reason is a machine-readable reason for the condition's last transition
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable message describing the condition's last transition
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the resource generation for which the condition was computed
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastTransitionTime is the last time the condition's status changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
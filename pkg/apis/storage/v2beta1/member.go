@@ -33,23 +33,86 @@ const (
 	RaftFollower  RaftMemberRole = "Follower"
 )
 
+type RaftMemberType string
+
+const (
+	RaftVoter   RaftMemberType = "Voter"
+	RaftLearner RaftMemberType = "Learner"
+)
+
+const (
+	// RaftMemberLagging indicates a member trails the leader by more than the lag threshold
+	RaftMemberLagging ConditionType = "Lagging"
+	// RaftMemberDiskPressure indicates the usage of the member's data volume exceeds the disk pressure threshold
+	RaftMemberDiskPressure ConditionType = "DiskPressure"
+)
+
 // RaftMemberSpec specifies a RaftMemberSpec configuration
 type RaftMemberSpec struct {
 	ClusterID   int32  `json:"clusterId,omitempty"`
 	PartitionID int32  `json:"partitionId,omitempty"`
 	MemberID    int32  `json:"memberId,omitempty"`
 	Pod         string `json:"pod,omitempty"`
+
+	// NodeID is the Raft node identifier of the member
+	NodeID int32 `json:"nodeId,omitempty"`
+
+	// Join indicates whether the member joins the running partition rather than bootstrapping it
+	Join bool `json:"join,omitempty"`
 }
 
 // RaftMemberStatus defines the status of a RaftMember
 type RaftMemberStatus struct {
 	State             *RaftMemberState `json:"state,omitempty"`
+	Type              *RaftMemberType  `json:"type,omitempty"`
 	Role              *RaftMemberRole  `json:"role,omitempty"`
 	Leader            *string          `json:"leader,omitempty"`
 	Term              *uint64          `json:"term,omitempty"`
 	LastUpdated       *metav1.Time     `json:"lastUpdated,omitempty"`
 	LastSnapshotIndex *uint64          `json:"lastSnapshotIndex,omitempty"`
 	LastSnapshotTime  *metav1.Time     `json:"lastSnapshotTime,omitempty"`
+
+	// EstimatedAppliedIndex estimates the index of the last entry applied to the member's state machine.
+	// Entries are counted from the member's last recovered snapshot, and entries not applied to the state
+	// machine, e.g. no-ops and configuration changes, are not counted, so it is not an exact Raft index.
+	EstimatedAppliedIndex *uint64 `json:"estimatedAppliedIndex,omitempty"`
+
+	// SnapshotSize is the size of the member's last snapshot in bytes
+	SnapshotSize *uint64 `json:"snapshotSize,omitempty"`
+
+	// LogSize is the estimated number of entries retained in the member's log
+	LogSize *uint64 `json:"logSize,omitempty"`
+
+	// Lag estimates the number of entries the member has yet to apply, computed as the difference between the
+	// estimated applied indexes of the leader and the member
+	Lag *uint64 `json:"lag,omitempty"`
+
+	// DiskUsage is the usage of the data volume of the member's pod
+	DiskUsage *RaftDiskUsage `json:"diskUsage,omitempty"`
+
+	// Conditions is the current conditions of the member
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// RaftDiskUsage is the usage of a Raft pod's data volume
+type RaftDiskUsage struct {
+	// WALSize is the size of the write-ahead log in bytes
+	WALSize uint64 `json:"walSize"`
+
+	// SnapshotSize is the size of all snapshots stored by the pod in bytes
+	SnapshotSize uint64 `json:"snapshotSize"`
+
+	// Capacity is the capacity of the data volume in bytes
+	Capacity uint64 `json:"capacity"`
+
+	// Available is the number of bytes available on the data volume
+	Available uint64 `json:"available"`
+
+	// Pressure indicates the usage of the data volume exceeds the protocol's disk pressure threshold
+	Pressure bool `json:"pressure,omitempty"`
+
+	// WritesRejected indicates the pod is rejecting writes because the usage of the data volume exceeds the write limit
+	WritesRejected bool `json:"writesRejected,omitempty"`
 }
 
 // +genclient
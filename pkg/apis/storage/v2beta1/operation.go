@@ -0,0 +1,135 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RaftOperationType string
+
+const (
+	// RaftSnapshotOperation takes a snapshot of the targeted members
+	RaftSnapshotOperation RaftOperationType = "Snapshot"
+	// RaftCompactLogOperation compacts the logs of the targeted members up to their last snapshots
+	RaftCompactLogOperation RaftOperationType = "CompactLog"
+	// RaftTransferLeadershipOperation transfers leadership of the targeted partitions. Leadership is
+	// transferred to the targeted member if one is specified, otherwise to any other voting member.
+	RaftTransferLeadershipOperation RaftOperationType = "TransferLeadership"
+	// RaftRestartMemberOperation restarts the targeted members from their persisted state. Members are restarted
+	// one at a time, and each member is only restarted once its partition is ready and the previously restarted
+	// member has caught up with its leader.
+	RaftRestartMemberOperation RaftOperationType = "RestartMember"
+)
+
+type RaftOperationPhase string
+
+const (
+	RaftOperationPending   RaftOperationPhase = "Pending"
+	RaftOperationRunning   RaftOperationPhase = "Running"
+	RaftOperationSucceeded RaftOperationPhase = "Succeeded"
+	RaftOperationFailed    RaftOperationPhase = "Failed"
+)
+
+// RaftOperationSpec specifies an operation to run against the members of a MultiRaftProtocol.
+// Unset targets select all clusters, partitions or members of the protocol.
+type RaftOperationSpec struct {
+	// Type is the type of the operation
+	Type RaftOperationType `json:"type"`
+
+	// Protocol is the name of the MultiRaftProtocol in the operation's namespace
+	Protocol string `json:"protocol"`
+
+	// Cluster is the ID of the targeted cluster
+	Cluster *int32 `json:"cluster,omitempty"`
+
+	// Partition is the ID of the targeted partition
+	Partition *int32 `json:"partition,omitempty"`
+
+	// Member is the ID of the targeted member within the targeted cluster
+	Member *int32 `json:"member,omitempty"`
+
+	// Snapshot is the parameters of a Snapshot operation
+	Snapshot *RaftSnapshotParameters `json:"snapshot,omitempty"`
+}
+
+// RaftSnapshotParameters are the parameters of a Snapshot operation
+type RaftSnapshotParameters struct {
+	// CompactionOverhead is the number of entries to retain in the log after the snapshot
+	CompactionOverhead int64 `json:"compactionOverhead,omitempty"`
+}
+
+// RaftOperationStatus defines the status of a RaftOperation
+type RaftOperationStatus struct {
+	// Phase is the phase of the operation
+	Phase RaftOperationPhase `json:"phase,omitempty"`
+
+	// Message is a human-readable message describing the phase of the operation
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time at which the operation was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time at which the operation succeeded or failed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Results is the result of the operation for each target that has been run
+	Results []RaftOperationResult `json:"results,omitempty"`
+}
+
+// RaftOperationResult is the result of an operation for a single partition or member
+type RaftOperationResult struct {
+	// Partition is the partition against which the operation was run
+	Partition int32 `json:"partition"`
+
+	// Pod is the pod against which the operation was run
+	Pod string `json:"pod,omitempty"`
+
+	// Succeeded indicates whether the operation succeeded
+	Succeeded bool `json:"succeeded"`
+
+	// Message is a human-readable message describing the result
+	Message string `json:"message,omitempty"`
+
+	// CompletionTime is the time at which the operation completed against the target
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RaftOperation is the Schema for the RaftOperation API
+// +k8s:openapi-gen=true
+type RaftOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              RaftOperationSpec   `json:"spec,omitempty"`
+	Status            RaftOperationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RaftOperationList contains a list of RaftOperation
+type RaftOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the RaftOperation of items in the list
+	Items []RaftOperation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RaftOperation{}, &RaftOperationList{})
+}
@@ -23,6 +23,8 @@ type RaftPartitionState string
 const (
 	RaftPartitionNotReady RaftPartitionState = "NotReady"
 	RaftPartitionReady    RaftPartitionState = "Ready"
+	// RaftPartitionDegraded indicates the partition has a quorum and a leader but not all members are ready
+	RaftPartitionDegraded RaftPartitionState = "Degraded"
 )
 
 // RaftPartitionSpec specifies a RaftPartitionSpec configuration
@@ -36,6 +38,9 @@ type RaftPartitionStatus struct {
 	State  RaftPartitionState `json:"state,omitempty"`
 	Leader *string             `json:"leader,omitempty"`
 	Term   *uint64             `json:"term,omitempty"`
+
+	// Conditions is the current conditions of the partition
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 // +genclient
@@ -17,6 +17,8 @@ package v2beta1
 import (
 	"github.com/atomix/atomix-controller/pkg/apis/core/v2beta1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,6 +27,37 @@ type MultiRaftProtocolState string
 const (
 	MultiRaftProtocolNotReady MultiRaftProtocolState = "NotReady"
 	MultiRaftProtocolReady    MultiRaftProtocolState = "Ready"
+	// MultiRaftProtocolDegraded indicates all partitions are available but some are not fully replicated
+	MultiRaftProtocolDegraded MultiRaftProtocolState = "Degraded"
+)
+
+type PartitionPlacementStrategy string
+
+const (
+	// RoundRobinPlacement assigns partitions to clusters in round-robin order
+	RoundRobinPlacement PartitionPlacementStrategy = "RoundRobin"
+	// ContiguousPlacement assigns contiguous ranges of partitions to each cluster
+	ContiguousPlacement PartitionPlacementStrategy = "Contiguous"
+	// LeastLoadedPlacement assigns partitions to the cluster with the fewest partitions
+	LeastLoadedPlacement PartitionPlacementStrategy = "LeastLoaded"
+)
+
+type PersistentVolumeClaimRetentionPolicy string
+
+const (
+	// RetainPersistentVolumeClaims retains data volume claims when a protocol is deleted
+	RetainPersistentVolumeClaims PersistentVolumeClaimRetentionPolicy = "Retain"
+	// DeletePersistentVolumeClaims deletes data volume claims when a protocol is deleted
+	DeletePersistentVolumeClaims PersistentVolumeClaimRetentionPolicy = "Delete"
+)
+
+type PartitionMigrationPhase string
+
+const (
+	PartitionMigrationAddingMembers          PartitionMigrationPhase = "AddingMembers"
+	PartitionMigrationCatchingUp             PartitionMigrationPhase = "CatchingUp"
+	PartitionMigrationTransferringLeadership PartitionMigrationPhase = "TransferringLeadership"
+	PartitionMigrationRemovingMembers        PartitionMigrationPhase = "RemovingMembers"
 )
 
 // MultiRaftProtocolSpec specifies a MultiRaftProtocol configuration
@@ -38,6 +71,24 @@ type MultiRaftProtocolSpec struct {
 	// Replicas is the number of raft replicas
 	Replicas int32 `json:"replicas,omitempty"`
 
+	// Placement is the strategy with which new partitions are assigned to clusters
+	Placement PartitionPlacementStrategy `json:"placement,omitempty"`
+
+	// ElectionTimeout is the Raft election timeout
+	ElectionTimeout *metav1.Duration `json:"electionTimeout,omitempty"`
+
+	// HeartbeatInterval is the interval at which Raft leaders send heartbeats
+	HeartbeatInterval *metav1.Duration `json:"heartbeatInterval,omitempty"`
+
+	// SnapshotInterval is the interval at which Raft partitions are snapshotted
+	SnapshotInterval *metav1.Duration `json:"snapshotInterval,omitempty"`
+
+	// SnapshotThreshold is the number of log entries after which a snapshot is taken
+	SnapshotThreshold int64 `json:"snapshotThreshold,omitempty"`
+
+	// Compaction configures the compaction of Raft logs and the retention of snapshots
+	Compaction *RaftCompaction `json:"compaction,omitempty"`
+
 	// Image is the image to run
 	Image string `json:"image,omitempty"`
 
@@ -50,14 +101,198 @@ type MultiRaftProtocolSpec struct {
 	// SecurityContext is a pod security context
 	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 
-	// VolumeClaimTemplate is the volume claim template for Raft logs
+	// VolumeClaimTemplate is the volume claim template for Raft logs. The template is immutable except for the
+	// storage request, which may be raised to expand the data volumes of existing pods but not lowered.
 	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+
+	// PodTemplate is a set of overrides merged into the pod template generated for Raft pods
+	PodTemplate *RaftPodTemplate `json:"podTemplate,omitempty"`
+
+	// TopologySpread spreads the replicas of each cluster across failure domains
+	TopologySpread *RaftTopologySpread `json:"topologySpread,omitempty"`
+
+	// PersistentVolumeClaimRetentionPolicy is the policy for data volume claims when the protocol is deleted
+	PersistentVolumeClaimRetentionPolicy PersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+
+	// LeaderBalancing balances the leaders of each cluster's partitions across the cluster's replicas
+	LeaderBalancing *RaftLeaderBalancing `json:"leaderBalancing,omitempty"`
+
+	// NetworkPolicy restricts the traffic accepted by Raft pods with a NetworkPolicy for each cluster
+	NetworkPolicy *RaftNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// DiskPressure configures the handling of Raft data volumes that are running out of space
+	DiskPressure *RaftDiskPressure `json:"diskPressure,omitempty"`
+
+	// MaxMemberLag is the estimated number of entries a member may be behind its leader before it's reported
+	// as Lagging. Defaults to 1000.
+	MaxMemberLag int64 `json:"maxMemberLag,omitempty"`
+
+	// Encryption configures the encryption of Raft log entries and snapshots at rest
+	Encryption *RaftEncryption `json:"encryption,omitempty"`
+}
+
+// RaftEncryption configures the encryption of Raft log entries and snapshots with AES-GCM. Keys are read from
+// a Secret in which each key is the ID of a 16, 24 or 32 byte AES key. To rotate keys, add the new key to the
+// Secret and then change the KeyID. Previous keys must be retained in the Secret until the entries and snapshots
+// encrypted with them have been compacted. Encryption can't be removed once enabled and the SecretName is immutable.
+type RaftEncryption struct {
+	// SecretName is the name of the Secret containing the encryption keys
+	SecretName string `json:"secretName"`
+
+	// KeyID is the ID of the key with which new entries and snapshots are encrypted. When empty, entries and
+	// snapshots are written unencrypted, and previously encrypted entries are decrypted with the keys in the Secret.
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// RaftDiskPressure configures the thresholds at which the usage of Raft data volumes is reported and limited
+type RaftDiskPressure struct {
+	// Threshold is the percentage of a data volume's capacity above which members report DiskPressure. Defaults to 80.
+	Threshold int32 `json:"threshold,omitempty"`
+
+	// CompactOnPressure snapshots and compacts the logs of a pod's members when the pod comes under disk pressure
+	CompactOnPressure bool `json:"compactOnPressure,omitempty"`
+
+	// WriteLimit is the percentage of a data volume's capacity above which nodes reject writes.
+	// Writes are not rejected when unset.
+	WriteLimit int32 `json:"writeLimit,omitempty"`
+}
+
+// RaftCompaction configures the compaction of Raft logs and the retention of snapshots
+type RaftCompaction struct {
+	// Overhead is the number of entries retained in the log when it's compacted, allowing slow followers
+	// to catch up from the log rather than a snapshot. Defaults to a tenth of the snapshot threshold.
+	Overhead int64 `json:"overhead,omitempty"`
+
+	// SnapshotThresholdSize is the size of the entries applied since the last snapshot after which a
+	// snapshot is taken, in addition to the snapshot threshold
+	SnapshotThresholdSize *resource.Quantity `json:"snapshotThresholdSize,omitempty"`
+
+	// DisableAutoCompaction disables the compaction of logs when snapshots are taken. Logs are then compacted
+	// at the compaction interval or by CompactLog operations.
+	DisableAutoCompaction bool `json:"disableAutoCompaction,omitempty"`
+
+	// Interval is the interval at which logs are compacted when automatic compaction is disabled
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// RaftNetworkPolicy configures the NetworkPolicies generated for Raft clusters. Raft traffic is accepted only
+// from the pods of the same cluster and monitoring traffic only from the controller.
+type RaftNetworkPolicy struct {
+	// Clients are the peers allowed to access the API port. The API port accepts no traffic when empty.
+	Clients []networkingv1.NetworkPolicyPeer `json:"clients,omitempty"`
+}
+
+// RaftLeaderBalancing configures the balancing of partition leaders across the replicas of a cluster
+type RaftLeaderBalancing struct {
+	// MaxImbalance is the maximum difference in the number of leaders between replicas. Defaults to 1.
+	MaxImbalance int32 `json:"maxImbalance,omitempty"`
+
+	// Interval is the minimum interval between leader transfers in a cluster. Defaults to 30s.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// RaftTopologySpread configures the spreading of Raft replicas across failure domains
+type RaftTopologySpread struct {
+	// TopologyKey is the node label identifying failure domains. Defaults to topology.kubernetes.io/zone.
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// MaxSkew is the maximum difference in the number of replicas between failure domains. Defaults to 1.
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+
+	// WhenUnsatisfiable is the scheduling behavior when the constraint can't be satisfied. Defaults to DoNotSchedule.
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
+
+// RaftPodTemplate is a set of overrides merged into the pod template generated for Raft pods.
+// Fields generated by the controller take precedence over overrides with the same name or key.
+type RaftPodTemplate struct {
+	// Labels are added to the pod labels
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the pod annotations
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Resources are the compute resources of the Raft container
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector is the node selector for Raft pods
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are the tolerations of Raft pods
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity replaces the default anti-affinity of Raft pods
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName is the priority class of Raft pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Env is a list of environment variables added to the Raft container
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// InitContainers is a list of init containers added to Raft pods
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Containers is a list of sidecar containers added to Raft pods
+	Containers []corev1.Container `json:"containers,omitempty"`
+
+	// Volumes is a list of volumes added to Raft pods
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
 }
 
 // MultiRaftProtocolStatus defines the status of a MultiRaftProtocol
 type MultiRaftProtocolStatus struct {
 	*v2beta1.ProtocolStatus `json:",inline"`
 	State                   MultiRaftProtocolState `json:"state,omitempty"`
+
+	// Placement is the cluster to which each partition is assigned. Partitions are
+	// assigned when they're created and remain in the same cluster thereafter.
+	Placement []PartitionPlacement `json:"placement,omitempty"`
+
+	// Migration is the status of an in-progress partition migration
+	Migration *PartitionMigrationStatus `json:"migration,omitempty"`
+
+	// RetainedVolumeClaims is the data volume claims retained when the protocol was deleted
+	RetainedVolumeClaims []RetainedVolumeClaim `json:"retainedVolumeClaims,omitempty"`
+
+	// Conditions is the current conditions of the protocol
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// RetainedVolumeClaim is a data volume claim retained after the protocol was deleted
+type RetainedVolumeClaim struct {
+	// Name is the name of the claim
+	Name string `json:"name"`
+
+	// Reason is the reason the claim was retained
+	Reason string `json:"reason,omitempty"`
+}
+
+// PartitionPlacement is the assignment of a partition to a cluster
+type PartitionPlacement struct {
+	PartitionID int32 `json:"partitionId"`
+	ClusterID   int32 `json:"clusterId"`
+}
+
+// PartitionMigrationStatus is the status of the migration of a partition between clusters
+type PartitionMigrationStatus struct {
+	// PartitionID is the partition being migrated
+	PartitionID int32 `json:"partitionId"`
+
+	// SourceClusterID is the cluster from which the partition is being migrated
+	SourceClusterID int32 `json:"sourceClusterId"`
+
+	// TargetClusterID is the cluster to which the partition is being migrated
+	TargetClusterID int32 `json:"targetClusterId"`
+
+	// BaseNodeID is the Raft node ID after which IDs are allocated to members in the target cluster
+	BaseNodeID int32 `json:"baseNodeId,omitempty"`
+
+	// Phase is the phase of the migration
+	Phase PartitionMigrationPhase `json:"phase,omitempty"`
+
+	// StartTime is the time at which the migration was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
 }
 
 // +genclient
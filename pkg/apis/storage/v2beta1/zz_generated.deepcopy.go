@@ -22,10 +22,29 @@ package v2beta1
 
 import (
 	corev2beta1 "github.com/atomix/atomix-controller/pkg/apis/core/v2beta1"
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiRaftProtocol) DeepCopyInto(out *MultiRaftProtocol) {
 	*out = *in
@@ -90,21 +109,71 @@ func (in *MultiRaftProtocolList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiRaftProtocolSpec) DeepCopyInto(out *MultiRaftProtocolSpec) {
 	*out = *in
+	if in.ElectionTimeout != nil {
+		in, out := &in.ElectionTimeout, &out.ElectionTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HeartbeatInterval != nil {
+		in, out := &in.HeartbeatInterval, &out.HeartbeatInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SnapshotInterval != nil {
+		in, out := &in.SnapshotInterval, &out.SnapshotInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(RaftCompaction)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.SecurityContext)
+		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.VolumeClaimTemplate != nil {
 		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
-		*out = new(v1.PersistentVolumeClaim)
+		*out = new(corev1.PersistentVolumeClaim)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(RaftPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpread != nil {
+		in, out := &in.TopologySpread, &out.TopologySpread
+		*out = new(RaftTopologySpread)
+		**out = **in
+	}
+	if in.LeaderBalancing != nil {
+		in, out := &in.LeaderBalancing, &out.LeaderBalancing
+		*out = new(RaftLeaderBalancing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(RaftNetworkPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DiskPressure != nil {
+		in, out := &in.DiskPressure, &out.DiskPressure
+		*out = new(RaftDiskPressure)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(RaftEncryption)
+		**out = **in
+	}
 	return
 }
 
@@ -126,6 +195,28 @@ func (in *MultiRaftProtocolStatus) DeepCopyInto(out *MultiRaftProtocolStatus) {
 		*out = new(corev2beta1.ProtocolStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = make([]PartitionPlacement, len(*in))
+		copy(*out, *in)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(PartitionMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetainedVolumeClaims != nil {
+		in, out := &in.RetainedVolumeClaims, &out.RetainedVolumeClaims
+		*out = make([]RetainedVolumeClaim, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -139,13 +230,49 @@ func (in *MultiRaftProtocolStatus) DeepCopy() *MultiRaftProtocolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionMigrationStatus) DeepCopyInto(out *PartitionMigrationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionMigrationStatus.
+func (in *PartitionMigrationStatus) DeepCopy() *PartitionMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionPlacement) DeepCopyInto(out *PartitionPlacement) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionPlacement.
+func (in *PartitionPlacement) DeepCopy() *PartitionPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RaftCluster) DeepCopyInto(out *RaftCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -200,6 +327,26 @@ func (in *RaftClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftClusterScaleStatus) DeepCopyInto(out *RaftClusterScaleStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftClusterScaleStatus.
+func (in *RaftClusterScaleStatus) DeepCopy() *RaftClusterScaleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftClusterScaleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RaftClusterSpec) DeepCopyInto(out *RaftClusterSpec) {
 	*out = *in
@@ -219,6 +366,39 @@ func (in *RaftClusterSpec) DeepCopy() *RaftClusterSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RaftClusterStatus) DeepCopyInto(out *RaftClusterStatus) {
 	*out = *in
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		*out = new(RaftClusterScaleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(RaftClusterUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeExpansion != nil {
+		in, out := &in.VolumeExpansion, &out.VolumeExpansion
+		*out = new(RaftVolumeExpansionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastLeaderTransferTime != nil {
+		in, out := &in.LastLeaderTransferTime, &out.LastLeaderTransferTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Monitors != nil {
+		in, out := &in.Monitors, &out.Monitors
+		*out = make([]RaftPodMonitorStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -232,6 +412,125 @@ func (in *RaftClusterStatus) DeepCopy() *RaftClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftClusterUpgradeStatus) DeepCopyInto(out *RaftClusterUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestartTime != nil {
+		in, out := &in.RestartTime, &out.RestartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftClusterUpgradeStatus.
+func (in *RaftClusterUpgradeStatus) DeepCopy() *RaftClusterUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftClusterUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftCompaction) DeepCopyInto(out *RaftCompaction) {
+	*out = *in
+	if in.SnapshotThresholdSize != nil {
+		in, out := &in.SnapshotThresholdSize, &out.SnapshotThresholdSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftCompaction.
+func (in *RaftCompaction) DeepCopy() *RaftCompaction {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftCompaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftDiskPressure) DeepCopyInto(out *RaftDiskPressure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftDiskPressure.
+func (in *RaftDiskPressure) DeepCopy() *RaftDiskPressure {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftDiskPressure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftDiskUsage) DeepCopyInto(out *RaftDiskUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftDiskUsage.
+func (in *RaftDiskUsage) DeepCopy() *RaftDiskUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftDiskUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftEncryption) DeepCopyInto(out *RaftEncryption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftEncryption.
+func (in *RaftEncryption) DeepCopy() *RaftEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftLeaderBalancing) DeepCopyInto(out *RaftLeaderBalancing) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftLeaderBalancing.
+func (in *RaftLeaderBalancing) DeepCopy() *RaftLeaderBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftLeaderBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RaftMember) DeepCopyInto(out *RaftMember) {
 	*out = *in
@@ -317,6 +616,11 @@ func (in *RaftMemberStatus) DeepCopyInto(out *RaftMemberStatus) {
 		*out = new(RaftMemberState)
 		**out = **in
 	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(RaftMemberType)
+		**out = **in
+	}
 	if in.Role != nil {
 		in, out := &in.Role, &out.Role
 		*out = new(RaftMemberRole)
@@ -332,6 +636,10 @@ func (in *RaftMemberStatus) DeepCopyInto(out *RaftMemberStatus) {
 		*out = new(uint64)
 		**out = **in
 	}
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
 	if in.LastSnapshotIndex != nil {
 		in, out := &in.LastSnapshotIndex, &out.LastSnapshotIndex
 		*out = new(uint64)
@@ -341,6 +649,38 @@ func (in *RaftMemberStatus) DeepCopyInto(out *RaftMemberStatus) {
 		in, out := &in.LastSnapshotTime, &out.LastSnapshotTime
 		*out = (*in).DeepCopy()
 	}
+	if in.EstimatedAppliedIndex != nil {
+		in, out := &in.EstimatedAppliedIndex, &out.EstimatedAppliedIndex
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.SnapshotSize != nil {
+		in, out := &in.SnapshotSize, &out.SnapshotSize
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.LogSize != nil {
+		in, out := &in.LogSize, &out.LogSize
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.DiskUsage != nil {
+		in, out := &in.DiskUsage, &out.DiskUsage
+		*out = new(RaftDiskUsage)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -354,6 +694,177 @@ func (in *RaftMemberStatus) DeepCopy() *RaftMemberStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftNetworkPolicy) DeepCopyInto(out *RaftNetworkPolicy) {
+	*out = *in
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftNetworkPolicy.
+func (in *RaftNetworkPolicy) DeepCopy() *RaftNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftOperation) DeepCopyInto(out *RaftOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftOperation.
+func (in *RaftOperation) DeepCopy() *RaftOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RaftOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftOperationList) DeepCopyInto(out *RaftOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RaftOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftOperationList.
+func (in *RaftOperationList) DeepCopy() *RaftOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RaftOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftOperationResult) DeepCopyInto(out *RaftOperationResult) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftOperationResult.
+func (in *RaftOperationResult) DeepCopy() *RaftOperationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftOperationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftOperationSpec) DeepCopyInto(out *RaftOperationSpec) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Member != nil {
+		in, out := &in.Member, &out.Member
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(RaftSnapshotParameters)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftOperationSpec.
+func (in *RaftOperationSpec) DeepCopy() *RaftOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftOperationStatus) DeepCopyInto(out *RaftOperationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]RaftOperationResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftOperationStatus.
+func (in *RaftOperationStatus) DeepCopy() *RaftOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RaftPartition) DeepCopyInto(out *RaftPartition) {
 	*out = *in
@@ -444,6 +955,13 @@ func (in *RaftPartitionStatus) DeepCopyInto(out *RaftPartitionStatus) {
 		*out = new(uint64)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -457,6 +975,104 @@ func (in *RaftPartitionStatus) DeepCopy() *RaftPartitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftPodMonitorStatus) DeepCopyInto(out *RaftPodMonitorStatus) {
+	*out = *in
+	if in.LastConnectTime != nil {
+		in, out := &in.LastConnectTime, &out.LastConnectTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftPodMonitorStatus.
+func (in *RaftPodMonitorStatus) DeepCopy() *RaftPodMonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftPodMonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftPodTemplate) DeepCopyInto(out *RaftPodTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftPodTemplate.
+func (in *RaftPodTemplate) DeepCopy() *RaftPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RaftSessionConfig) DeepCopyInto(out *RaftSessionConfig) {
 	*out = *in
@@ -515,3 +1131,100 @@ func (in *RaftSessionConfigList) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftSnapshotParameters) DeepCopyInto(out *RaftSnapshotParameters) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftSnapshotParameters.
+func (in *RaftSnapshotParameters) DeepCopy() *RaftSnapshotParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftSnapshotParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftTopologySpread) DeepCopyInto(out *RaftTopologySpread) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftTopologySpread.
+func (in *RaftTopologySpread) DeepCopy() *RaftTopologySpread {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftTopologySpread)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftVolumeClaimExpansionStatus) DeepCopyInto(out *RaftVolumeClaimExpansionStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftVolumeClaimExpansionStatus.
+func (in *RaftVolumeClaimExpansionStatus) DeepCopy() *RaftVolumeClaimExpansionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftVolumeClaimExpansionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftVolumeExpansionStatus) DeepCopyInto(out *RaftVolumeExpansionStatus) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]RaftVolumeClaimExpansionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftVolumeExpansionStatus.
+func (in *RaftVolumeExpansionStatus) DeepCopy() *RaftVolumeExpansionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftVolumeExpansionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedVolumeClaim) DeepCopyInto(out *RetainedVolumeClaim) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedVolumeClaim.
+func (in *RetainedVolumeClaim) DeepCopy() *RetainedVolumeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedVolumeClaim)
+	in.DeepCopyInto(out)
+	return out
+}
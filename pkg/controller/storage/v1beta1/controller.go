@@ -34,6 +34,7 @@ func AddControllers(mgr manager.Manager) error {
 	r := &Reconciler{
 		client: mgr.GetClient(),
 		scheme: mgr.GetScheme(),
+		events: mgr.GetEventRecorderFor("atomix-raft-storage"),
 	}
 
 	// Create a new controller
@@ -0,0 +1,270 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	"github.com/atomix/atomix-controller/pkg/apis/cloud/v1beta3"
+	"github.com/atomix/atomix-raft-storage/pkg/apis/storage/v1beta1"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// migrateAnnotation requests that a Database be migrated to a MultiRaftProtocol with the given version
+	migrateAnnotation = "storage.atomix.io/migrate"
+	// migrationPhaseAnnotation records the phase of a Database's migration
+	migrationPhaseAnnotation = "storage.atomix.io/migration-phase"
+	// migratedFromAnnotation records the Database from which a MultiRaftProtocol was migrated
+	migratedFromAnnotation = "storage.atomix.io/migrated-from"
+	// revisionLabel is the label with which the StatefulSet controller records a pod's revision
+	revisionLabel = "controller-revision-hash"
+)
+
+const migrationVersion = "v2beta1"
+
+const migrationRequeueInterval = 5 * time.Second
+
+type migrationPhase string
+
+const (
+	// migrationAdopting indicates the MultiRaftProtocol is being created and is adopting the Database's resources
+	migrationAdopting migrationPhase = "Adopting"
+	// migrationRestarting indicates pods are being restarted with the v2beta1 configuration
+	migrationRestarting migrationPhase = "Restarting"
+	// migrationComplete indicates the MultiRaftProtocol is ready and the Database is no longer reconciled
+	migrationComplete migrationPhase = "Complete"
+)
+
+// getMigrationPhase returns the phase of the given database's migration, or an empty phase if it's not being migrated
+func getMigrationPhase(database *v1beta3.Database) migrationPhase {
+	if phase, ok := database.Annotations[migrationPhaseAnnotation]; ok {
+		return migrationPhase(phase)
+	}
+	if database.Annotations[migrateAnnotation] == migrationVersion {
+		return migrationAdopting
+	}
+	return ""
+}
+
+// reconcileMigration migrates a Database to a MultiRaftProtocol with the same name. The protocol uses the same
+// names for its StatefulSets, services and volume claims as the Database, so it adopts the Database's resources
+// in place and the v2beta1 controller rewrites their configuration. Pods are then restarted with the new
+// configuration on their existing volumes, and the Database is switched off once the protocol is ready.
+func (r *Reconciler) reconcileMigration(database *v1beta3.Database, storage *v1beta1.RaftStorageClass) (reconcile.Result, error) {
+	switch getMigrationPhase(database) {
+	case migrationAdopting:
+		return r.reconcileAdopting(database, storage)
+	case migrationRestarting:
+		return r.reconcileRestarting(database, storage)
+	}
+	return reconcile.Result{}, nil
+}
+
+// reconcileAdopting creates the MultiRaftProtocol and transfers control of the Database's resources to it
+func (r *Reconciler) reconcileAdopting(database *v1beta3.Database, storage *v1beta1.RaftStorageClass) (reconcile.Result, error) {
+	protocol := &storagev2beta1.MultiRaftProtocol{}
+	name := types.NamespacedName{
+		Namespace: database.Namespace,
+		Name:      database.Name,
+	}
+	err := r.client.Get(context.TODO(), name, protocol)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		log.Info("Creating MultiRaftProtocol", "Name", database.Name, "Namespace", database.Namespace)
+		protocol = newMigratedProtocol(database, storage)
+		if err := r.client.Create(context.TODO(), protocol); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.events.Eventf(database, "Normal", "MigrationStarted", "Created MultiRaftProtocol %s", protocol.Name)
+	} else if protocol.Annotations[migratedFromAnnotation] != database.Name {
+		// Never adopt resources into a protocol that wasn't created for the migration
+		r.events.Eventf(database, "Warning", "MigrationFailed", "MultiRaftProtocol %s already exists", protocol.Name)
+		return reconcile.Result{}, nil
+	}
+
+	for cluster := 1; cluster <= getClusters(storage); cluster++ {
+		if err := r.adoptResource(database, protocol, getClusterName(database, cluster), &corev1.ConfigMap{}); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := r.adoptResource(database, protocol, getClusterName(database, cluster), &appsv1.StatefulSet{}); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := r.adoptResource(database, protocol, getClusterHeadlessServiceName(database, cluster), &corev1.Service{}); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err := r.setMigrationPhase(database, migrationRestarting); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.events.Eventf(database, "Normal", "ResourcesAdopted", "MultiRaftProtocol %s adopted the database's resources", protocol.Name)
+	return reconcile.Result{RequeueAfter: migrationRequeueInterval}, nil
+}
+
+// reconcileRestarting restarts pods created from the v1beta1 template once the v2beta1 controller has updated
+// their StatefulSets, and completes the migration once the protocol is ready
+func (r *Reconciler) reconcileRestarting(database *v1beta3.Database, storage *v1beta1.RaftStorageClass) (reconcile.Result, error) {
+	protocol := &storagev2beta1.MultiRaftProtocol{}
+	name := types.NamespacedName{
+		Namespace: database.Namespace,
+		Name:      database.Name,
+	}
+	if err := r.client.Get(context.TODO(), name, protocol); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for cluster := 1; cluster <= getClusters(storage); cluster++ {
+		statefulSet := &appsv1.StatefulSet{}
+		name := types.NamespacedName{
+			Namespace: database.Namespace,
+			Name:      getClusterName(database, cluster),
+		}
+		if err := r.client.Get(context.TODO(), name, statefulSet); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return reconcile.Result{RequeueAfter: migrationRequeueInterval}, nil
+			}
+			return reconcile.Result{}, err
+		}
+
+		// The v2beta1 controller switches StatefulSets to the OnDelete strategy when it updates their template
+		if statefulSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType ||
+			statefulSet.Status.ObservedGeneration < statefulSet.Generation ||
+			statefulSet.Status.UpdateRevision == "" {
+			return reconcile.Result{RequeueAfter: migrationRequeueInterval}, nil
+		}
+
+		// v1beta1 nodes can't form a cluster with v2beta1 nodes, so all outdated pods are restarted together
+		pods := &corev1.PodList{}
+		options := &client.ListOptions{
+			Namespace:     database.Namespace,
+			LabelSelector: labels.SelectorFromSet(newClusterLabels(database, cluster)),
+		}
+		if err := r.client.List(context.TODO(), pods, options); err != nil {
+			return reconcile.Result{}, err
+		}
+		for _, pod := range pods.Items {
+			if pod.Labels[revisionLabel] == statefulSet.Status.UpdateRevision || pod.DeletionTimestamp != nil {
+				continue
+			}
+			log.Info("Restarting pod", "Name", pod.Name, "Namespace", pod.Namespace)
+			if err := r.client.Delete(context.TODO(), &pod); err != nil && !k8serrors.IsNotFound(err) {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	// The Database is only switched off once the protocol serves all partitions
+	if protocol.Status.State != storagev2beta1.MultiRaftProtocolReady {
+		return reconcile.Result{RequeueAfter: migrationRequeueInterval}, nil
+	}
+	if err := r.setMigrationPhase(database, migrationComplete); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.events.Eventf(database, "Normal", "MigrationComplete", "Migrated to MultiRaftProtocol %s", protocol.Name)
+	return reconcile.Result{}, nil
+}
+
+// newMigratedProtocol returns a MultiRaftProtocol equivalent to the given database and storage class
+func newMigratedProtocol(database *v1beta3.Database, storage *v1beta1.RaftStorageClass) *storagev2beta1.MultiRaftProtocol {
+	protocolLabels := make(map[string]string)
+	for key, value := range database.Labels {
+		protocolLabels[key] = value
+	}
+
+	// The v1beta1 default volume name is set explicitly so the protocol's claims match the existing claims
+	var volumeClaimTemplate *corev1.PersistentVolumeClaim
+	if storage.Spec.VolumeClaimTemplate != nil {
+		volumeClaimTemplate = storage.Spec.VolumeClaimTemplate.DeepCopy()
+		if volumeClaimTemplate.Name == "" {
+			volumeClaimTemplate.Name = dataVolume
+		}
+	}
+
+	// The v1beta1 image isn't carried over since it runs the v1beta1 node
+	return &storagev2beta1.MultiRaftProtocol{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      database.Name,
+			Namespace: database.Namespace,
+			Labels:    protocolLabels,
+			Annotations: map[string]string{
+				migratedFromAnnotation: database.Name,
+			},
+		},
+		Spec: storagev2beta1.MultiRaftProtocolSpec{
+			Clusters:            int32(getClusters(storage)),
+			Partitions:          database.Spec.Partitions,
+			Replicas:            storage.Spec.Replicas,
+			Placement:           storagev2beta1.RoundRobinPlacement,
+			ImagePullPolicy:     storage.Spec.ImagePullPolicy,
+			VolumeClaimTemplate: volumeClaimTemplate,
+		},
+	}
+}
+
+// adoptResource transfers control of the named resource from the database to the protocol
+func (r *Reconciler) adoptResource(database *v1beta3.Database, protocol *storagev2beta1.MultiRaftProtocol, name string, object runtime.Object) error {
+	key := types.NamespacedName{
+		Namespace: database.Namespace,
+		Name:      name,
+	}
+	if err := r.client.Get(context.TODO(), key, object); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	meta := object.(metav1.Object)
+	owner := metav1.GetControllerOf(meta)
+	if owner != nil && owner.UID == protocol.UID {
+		return nil
+	}
+
+	references := make([]metav1.OwnerReference, 0, len(meta.GetOwnerReferences()))
+	for _, reference := range meta.GetOwnerReferences() {
+		if reference.UID != database.UID {
+			references = append(references, reference)
+		}
+	}
+	meta.SetOwnerReferences(references)
+	if err := controllerutil.SetControllerReference(protocol, meta, r.scheme); err != nil {
+		return err
+	}
+	log.Info("Adopting resource", "Name", name, "Namespace", database.Namespace, "Protocol", protocol.Name)
+	return r.client.Update(context.TODO(), object)
+}
+
+// setMigrationPhase records the phase of the given database's migration
+func (r *Reconciler) setMigrationPhase(database *v1beta3.Database, phase migrationPhase) error {
+	if database.Annotations == nil {
+		database.Annotations = make(map[string]string)
+	}
+	database.Annotations[migrationPhaseAnnotation] = string(phase)
+	return r.client.Update(context.TODO(), database)
+}
@@ -0,0 +1,256 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"context"
+	"github.com/atomix/atomix-controller/pkg/apis/cloud/v1beta3"
+	"github.com/atomix/atomix-raft-storage/pkg/apis"
+	"github.com/atomix/atomix-raft-storage/pkg/apis/storage/v1beta1"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+// newTestReconciler returns a Reconciler backed by a fake client populated with the given objects
+func newTestReconciler(objects ...runtime.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	return &Reconciler{
+		client: fake.NewFakeClientWithScheme(scheme, objects...),
+		scheme: scheme,
+		events: record.NewFakeRecorder(100),
+	}
+}
+
+// newTestDatabase returns a database with the given number of partitions stored in the given storage class
+func newTestDatabase(storage *v1beta1.RaftStorageClass, partitions int32) *v1beta3.Database {
+	return &v1beta3.Database{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raft",
+			Namespace: "test",
+			UID:       "database",
+			Labels: map[string]string{
+				"tier": "storage",
+			},
+		},
+		Spec: v1beta3.DatabaseSpec{
+			Partitions: partitions,
+			StorageClass: v1beta3.StorageClassReference{
+				Name: storage.Name,
+			},
+		},
+	}
+}
+
+// newTestStorageClass returns a storage class with the given number of clusters and replicas
+func newTestStorageClass(clusters, replicas int32) *v1beta1.RaftStorageClass {
+	return &v1beta1.RaftStorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raft",
+			Namespace: "test",
+		},
+		Spec: v1beta1.RaftStorageClassSpec{
+			Clusters:        clusters,
+			Replicas:        replicas,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+		},
+	}
+}
+
+// reconcileTestDatabase reconciles the given database and refreshes it with its current state
+func reconcileTestDatabase(t *testing.T, reconciler *Reconciler, database *v1beta3.Database) reconcile.Result {
+	result, err := reconciler.Reconcile(reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: database.Namespace,
+			Name:      database.Name,
+		},
+	})
+	assert.NoError(t, err)
+	getTestObject(t, reconciler, database.Name, database)
+	return result
+}
+
+// getTestObject gets the object with the given name in the test namespace
+func getTestObject(t *testing.T, reconciler *Reconciler, name string, object runtime.Object) {
+	assert.NoError(t, reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: name}, object))
+}
+
+// getTestEvents returns the events recorded by the given reconciler
+func getTestEvents(reconciler *Reconciler) []string {
+	recorder := reconciler.events.(*record.FakeRecorder)
+	events := make([]string, 0)
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// isTestObjectFound returns whether the object with the given name exists in the test namespace
+func isTestObjectFound(t *testing.T, reconciler *Reconciler, name string, object runtime.Object) bool {
+	err := reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: name}, object)
+	if k8serrors.IsNotFound(err) {
+		return false
+	}
+	assert.NoError(t, err)
+	return true
+}
+
+// assertTestAdopted asserts the object with the given name is controlled only by the given protocol
+func assertTestAdopted(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, name string, object runtime.Object) {
+	getTestObject(t, reconciler, name, object)
+	meta := object.(metav1.Object)
+	owner := metav1.GetControllerOf(meta)
+	assert.NotNil(t, owner)
+	assert.Equal(t, "MultiRaftProtocol", owner.Kind)
+	assert.Equal(t, protocol.Name, owner.Name)
+	assert.Len(t, meta.GetOwnerReferences(), 1)
+}
+
+// newTestPod returns a pod of the given cluster created from the given StatefulSet revision
+func newTestPod(database *v1beta3.Database, cluster int, podID int, revision string) *corev1.Pod {
+	podLabels := newClusterLabels(database, cluster)
+	podLabels[revisionLabel] = revision
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getPodName(database, cluster, podID),
+			Namespace: database.Namespace,
+			Labels:    podLabels,
+		},
+	}
+}
+
+func TestReconcileMigration(t *testing.T) {
+	storage := newTestStorageClass(2, 3)
+	database := newTestDatabase(storage, 4)
+	reconciler := newTestReconciler(storage, database)
+	reconcileTestDatabase(t, reconciler, database)
+	for cluster := 1; cluster <= 2; cluster++ {
+		statefulSet := &appsv1.StatefulSet{}
+		getTestObject(t, reconciler, getClusterName(database, cluster), statefulSet)
+		assert.Equal(t, database.Name, metav1.GetControllerOf(statefulSet).Name)
+	}
+
+	// Requesting the migration creates an equivalent protocol that adopts the database's resources
+	database.Annotations = map[string]string{migrateAnnotation: migrationVersion}
+	assert.NoError(t, reconciler.client.Update(context.TODO(), database))
+	result := reconcileTestDatabase(t, reconciler, database)
+	assert.Equal(t, migrationRequeueInterval, result.RequeueAfter)
+	assert.Equal(t, string(migrationRestarting), database.Annotations[migrationPhaseAnnotation])
+
+	protocol := &storagev2beta1.MultiRaftProtocol{}
+	getTestObject(t, reconciler, database.Name, protocol)
+	assert.Equal(t, database.Name, protocol.Annotations[migratedFromAnnotation])
+	assert.Equal(t, "storage", protocol.Labels["tier"])
+	assert.Equal(t, int32(2), protocol.Spec.Clusters)
+	assert.Equal(t, int32(4), protocol.Spec.Partitions)
+	assert.Equal(t, int32(3), protocol.Spec.Replicas)
+	assert.Equal(t, storagev2beta1.RoundRobinPlacement, protocol.Spec.Placement)
+	assert.Equal(t, corev1.PullIfNotPresent, protocol.Spec.ImagePullPolicy)
+	for cluster := 1; cluster <= 2; cluster++ {
+		assertTestAdopted(t, reconciler, protocol, getClusterName(database, cluster), &corev1.ConfigMap{})
+		assertTestAdopted(t, reconciler, protocol, getClusterName(database, cluster), &appsv1.StatefulSet{})
+		assertTestAdopted(t, reconciler, protocol, getClusterHeadlessServiceName(database, cluster), &corev1.Service{})
+	}
+	events := getTestEvents(reconciler)
+	assert.Contains(t, events, "Normal MigrationStarted Created MultiRaftProtocol raft")
+	assert.Contains(t, events, "Normal ResourcesAdopted MultiRaftProtocol raft adopted the database's resources")
+
+	// Pods are not restarted until the v2beta1 controller has updated the StatefulSets
+	for cluster := 1; cluster <= 2; cluster++ {
+		for podID := 0; podID < 3; podID++ {
+			assert.NoError(t, reconciler.client.Create(context.TODO(), newTestPod(database, cluster, podID, "v1")))
+		}
+	}
+	reconcileTestDatabase(t, reconciler, database)
+	assert.True(t, isTestObjectFound(t, reconciler, getPodName(database, 1, 0), &corev1.Pod{}))
+
+	// Pods created from the v1beta1 template are restarted once their StatefulSet has been updated
+	for cluster := 1; cluster <= 2; cluster++ {
+		statefulSet := &appsv1.StatefulSet{}
+		getTestObject(t, reconciler, getClusterName(database, cluster), statefulSet)
+		statefulSet.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+		statefulSet.Status.UpdateRevision = "v2"
+		assert.NoError(t, reconciler.client.Update(context.TODO(), statefulSet))
+	}
+	assert.NoError(t, reconciler.client.Delete(context.TODO(), newTestPod(database, 2, 1, "v1")))
+	assert.NoError(t, reconciler.client.Create(context.TODO(), newTestPod(database, 2, 1, "v2")))
+	reconcileTestDatabase(t, reconciler, database)
+	for cluster := 1; cluster <= 2; cluster++ {
+		for podID := 0; podID < 3; podID++ {
+			restarted := cluster != 2 || podID != 1
+			assert.Equal(t, !restarted, isTestObjectFound(t, reconciler, getPodName(database, cluster, podID), &corev1.Pod{}))
+		}
+	}
+
+	// The database is only switched off once the protocol is ready
+	assert.Equal(t, string(migrationRestarting), database.Annotations[migrationPhaseAnnotation])
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	protocol.Status.State = storagev2beta1.MultiRaftProtocolReady
+	assert.NoError(t, reconciler.client.Status().Update(context.TODO(), protocol))
+	reconcileTestDatabase(t, reconciler, database)
+	assert.Equal(t, string(migrationComplete), database.Annotations[migrationPhaseAnnotation])
+	assert.Contains(t, getTestEvents(reconciler), "Normal MigrationComplete Migrated to MultiRaftProtocol raft")
+
+	// Migrated databases no longer manage their resources
+	statefulSet := &appsv1.StatefulSet{}
+	getTestObject(t, reconciler, getClusterName(database, 1), statefulSet)
+	assert.NoError(t, reconciler.client.Delete(context.TODO(), statefulSet))
+	reconcileTestDatabase(t, reconciler, database)
+	assert.False(t, isTestObjectFound(t, reconciler, getClusterName(database, 1), &appsv1.StatefulSet{}))
+}
+
+func TestReconcileMigrationToExistingProtocol(t *testing.T) {
+	storage := newTestStorageClass(1, 3)
+	database := newTestDatabase(storage, 3)
+	protocol := &storagev2beta1.MultiRaftProtocol{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      database.Name,
+			Namespace: database.Namespace,
+			UID:       "protocol",
+		},
+	}
+	reconciler := newTestReconciler(storage, database, protocol)
+	reconcileTestDatabase(t, reconciler, database)
+
+	// Resources are never adopted by a protocol that wasn't created for the migration
+	database.Annotations = map[string]string{migrateAnnotation: migrationVersion}
+	assert.NoError(t, reconciler.client.Update(context.TODO(), database))
+	reconcileTestDatabase(t, reconciler, database)
+	assert.Empty(t, database.Annotations[migrationPhaseAnnotation])
+	assert.Contains(t, getTestEvents(reconciler), "Warning MigrationFailed MultiRaftProtocol raft already exists")
+	statefulSet := &appsv1.StatefulSet{}
+	getTestObject(t, reconciler, getClusterName(database, 1), statefulSet)
+	assert.Equal(t, types.UID("database"), metav1.GetControllerOf(statefulSet).UID)
+}
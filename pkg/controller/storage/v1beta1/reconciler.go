@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -74,6 +75,7 @@ var _ reconcile.Reconciler = &Reconciler{}
 type Reconciler struct {
 	client client.Client
 	scheme *runtime.Scheme
+	events record.EventRecorder
 }
 
 // Reconcile reads that state of the cluster for a Cluster object and makes changes based on the state read
@@ -90,6 +92,11 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{Requeue: true}, err
 	}
 
+	// Migrated databases are managed by the MultiRaftProtocol that adopted their resources
+	if getMigrationPhase(database) == migrationComplete {
+		return reconcile.Result{}, nil
+	}
+
 	log.Info("Reconcile RaftStorageClass")
 	storage := &v1beta1.RaftStorageClass{}
 	namespace := database.Spec.StorageClass.Namespace
@@ -109,6 +116,15 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{Requeue: true}, err
 	}
 
+	if getMigrationPhase(database) != "" {
+		log.Info("Reconcile Migration")
+		result, err := r.reconcileMigration(database, storage)
+		if err != nil {
+			log.Error(err, "Reconcile Migration")
+		}
+		return result, err
+	}
+
 	log.Info("Reconcile Clusters")
 	err = r.reconcileClusters(database, storage)
 	if err != nil {
@@ -0,0 +1,166 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// statusFlushInterval is the interval at which accumulated member statuses are written
+	statusFlushInterval = time.Second
+	// maxStatusFlushes is the maximum number of member statuses written per interval
+	maxStatusFlushes = 50
+)
+
+// newMemberStatusAccumulator returns a new member status accumulator for the given reconciler
+func newMemberStatusAccumulator(reconciler *Reconciler) *memberStatusAccumulator {
+	return &memberStatusAccumulator{
+		reconciler: reconciler,
+		pending:    make(map[types.NamespacedName]*pendingMemberStatus),
+	}
+}
+
+// pendingMemberStatus is a member status waiting to be written along with the maximum lag of the member's protocol
+type pendingMemberStatus struct {
+	protocolUID types.UID
+	status      storagev2beta1.RaftMemberStatus
+	maxLag      uint64
+}
+
+// memberStatusAccumulator merges the member statuses reported by Raft events and writes them at a bounded rate,
+// so a burst of events results in at most one status write per member per interval. It is the only writer of the
+// member status fields reported by Raft events.
+type memberStatusAccumulator struct {
+	reconciler *Reconciler
+	pending    map[types.NamespacedName]*pendingMemberStatus
+	mu         sync.Mutex
+}
+
+// update merges the given status into the pending status of the given member
+func (a *memberStatusAccumulator) update(protocol *storagev2beta1.MultiRaftProtocol, member *storagev2beta1.RaftMember, status storagev2beta1.RaftMemberStatus) {
+	name := types.NamespacedName{
+		Namespace: member.Namespace,
+		Name:      member.Name,
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if pending, ok := a.pending[name]; ok {
+		mergeMemberStatus(&pending.status, status)
+		pending.maxLag = getMaxMemberLag(protocol)
+	} else {
+		a.pending[name] = &pendingMemberStatus{
+			protocolUID: protocol.UID,
+			status:      status,
+			maxLag:      getMaxMemberLag(protocol),
+		}
+	}
+	memberStatusesPending.Set(float64(len(a.pending)))
+}
+
+// dropProtocol discards the pending statuses of all members of the given protocol
+func (a *memberStatusAccumulator) dropProtocol(protocol *storagev2beta1.MultiRaftProtocol) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for name, status := range a.pending {
+		if status.protocolUID == protocol.UID {
+			delete(a.pending, name)
+		}
+	}
+	memberStatusesPending.Set(float64(len(a.pending)))
+}
+
+// requeue returns a status that failed to be written to the pending statuses, preserving any status
+// accumulated for the member since
+func (a *memberStatusAccumulator) requeue(name types.NamespacedName, status *pendingMemberStatus) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if pending, ok := a.pending[name]; ok {
+		mergeMemberStatus(&status.status, pending.status)
+		status.maxLag = pending.maxLag
+	}
+	a.pending[name] = status
+	memberStatusesPending.Set(float64(len(a.pending)))
+}
+
+// Start writes accumulated statuses until the given channel is closed
+func (a *memberStatusAccumulator) Start(stop <-chan struct{}) error {
+	ticker := time.NewTicker(statusFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.flush()
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// flush writes up to maxStatusFlushes accumulated statuses
+func (a *memberStatusAccumulator) flush() {
+	a.mu.Lock()
+	statuses := make(map[types.NamespacedName]*pendingMemberStatus)
+	for name, status := range a.pending {
+		if len(statuses) == maxStatusFlushes {
+			break
+		}
+		statuses[name] = status
+		delete(a.pending, name)
+	}
+	memberStatusesPending.Set(float64(len(a.pending)))
+	a.mu.Unlock()
+
+	for name, status := range statuses {
+		if err := a.reconciler.writeMemberStatus(name, status.status, status.maxLag); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			log.Warnf("Failed to write status of RaftMember %s: %s", name, err)
+			a.requeue(name, status)
+		}
+	}
+}
+
+// writeMemberStatus merges the given status into the status of the given member
+func (r *Reconciler) writeMemberStatus(name types.NamespacedName, status storagev2beta1.RaftMemberStatus, maxLag uint64) error {
+	member := &storagev2beta1.RaftMember{}
+	if err := r.client.Get(context.TODO(), name, member); err != nil {
+		return err
+	}
+
+	lagging := isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberLagging)
+	pressure := isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberDiskPressure)
+	if err := r.updateMemberStatus(member, status, maxLag); err != nil {
+		return err
+	}
+	if !lagging && isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberLagging) {
+		r.events.Eventf(member, "Warning", "Lagging", "Member is an estimated %d entries behind the leader", *member.Status.Lag)
+	} else if lagging && !isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberLagging) {
+		r.events.Eventf(member, "Normal", "CaughtUp", "Member caught up with the leader")
+	}
+	if !pressure && isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberDiskPressure) {
+		r.events.Eventf(member, "Warning", "DiskPressure", "Data volume is %d%% full", getDiskUsedPercent(member.Status.DiskUsage))
+	} else if pressure && !isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberDiskPressure) {
+		r.events.Eventf(member, "Normal", "DiskPressureRelieved", "Data volume is %d%% full", getDiskUsedPercent(member.Status.DiskUsage))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPatchMemberStatusPreservesEventStatus(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newTestReconciler(protocol)
+	setTestPods(t, reconciler, protocol, 1, []bool{true, true, true})
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	// The reconciler reads the member before a Raft event reports the leader
+	stale := &storagev2beta1.RaftMember{}
+	getTestObject(t, reconciler, getMemberName(protocol, 1, 1, 0), stale)
+	assert.Nil(t, stale.Status.Leader)
+	setTestLeader(t, reconciler, protocol, 1, 1, 0)
+
+	state := storagev2beta1.RaftMemberNotReady
+	assert.NoError(t, reconciler.patchMemberStatus(stale, storagev2beta1.RaftMemberStatus{State: &state}, getMaxMemberLag(protocol)))
+
+	member := &storagev2beta1.RaftMember{}
+	getTestObject(t, reconciler, getMemberName(protocol, 1, 1, 0), member)
+	assert.Equal(t, storagev2beta1.RaftMemberNotReady, *member.Status.State)
+	assert.False(t, isConditionTrue(member.Status.Conditions, storagev2beta1.ConditionReady))
+	assert.NotNil(t, member.Status.Leader)
+	assert.Equal(t, getPodName(protocol, 1, 0), *member.Status.Leader)
+	assert.Equal(t, uint64(1), *member.Status.Term)
+}
+
+func TestMemberStatusesPending(t *testing.T) {
+	protocol := newTestProtocol(1, 2, 3)
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	lag := uint64(1)
+	for partitionID := 1; partitionID <= 2; partitionID++ {
+		member := &storagev2beta1.RaftMember{}
+		getTestObject(t, reconciler, getMemberName(protocol, 1, partitionID, 0), member)
+		reconciler.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{Lag: &lag})
+		reconciler.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{Lag: &lag})
+	}
+	assert.Equal(t, float64(2), testutil.ToFloat64(memberStatusesPending))
+
+	reconciler.statuses.flush()
+	assert.Equal(t, float64(0), testutil.ToFloat64(memberStatusesPending))
+	member := &storagev2beta1.RaftMember{}
+	getTestObject(t, reconciler, getMemberName(protocol, 1, 2, 0), member)
+	assert.Equal(t, lag, *member.Status.Lag)
+}
@@ -0,0 +1,178 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"strings"
+	"sync"
+)
+
+// testAdminCall is a request received by the admin service of a pod
+type testAdminCall struct {
+	pod     string
+	method  string
+	request proto.Message
+}
+
+// testAdmin fakes the admin services of Raft pods, recording the requests they receive
+type testAdmin struct {
+	listeners map[string]*bufconn.Listener
+	servers   []*grpc.Server
+	calls     []testAdminCall
+	failures  map[string]bool
+	options   []grpc.DialOption
+	mu        sync.Mutex
+}
+
+// newTestAdmin returns a fake of the admin services of all pods, which is dialed by the controller until it's closed
+func newTestAdmin() *testAdmin {
+	admin := &testAdmin{
+		listeners: make(map[string]*bufconn.Listener),
+		failures:  make(map[string]bool),
+		options:   adminDialOptions,
+	}
+	adminDialOptions = []grpc.DialOption{grpc.WithInsecure(), grpc.WithContextDialer(admin.dial)}
+	return admin
+}
+
+// dial connects to the fake admin service of the pod with the given address
+func (a *testAdmin) dial(ctx context.Context, address string) (net.Conn, error) {
+	pod := strings.Split(address, ".")[0]
+	a.mu.Lock()
+	listener, ok := a.listeners[pod]
+	if !ok {
+		listener = bufconn.Listen(1024 * 1024)
+		server := grpc.NewServer()
+		storage.RegisterRaftAdminServer(server, &testAdminServer{admin: a, pod: pod})
+		go server.Serve(listener)
+		a.listeners[pod] = listener
+		a.servers = append(a.servers, server)
+	}
+	a.mu.Unlock()
+	return listener.Dial()
+}
+
+// fail causes requests for the given method to the given pod to fail until they're allowed
+func (a *testAdmin) fail(pod string, method string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.failures[pod+"/"+method] = true
+}
+
+// allow allows requests for the given method to the given pod to succeed
+func (a *testAdmin) allow(pod string, method string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.failures, pod+"/"+method)
+}
+
+// call records a request, returning an error if requests for the method are failing
+func (a *testAdmin) call(pod string, method string, request proto.Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, testAdminCall{
+		pod:     pod,
+		method:  method,
+		request: request,
+	})
+	if a.failures[pod+"/"+method] {
+		return status.Errorf(codes.Unavailable, "%s failed", method)
+	}
+	return nil
+}
+
+// getCalls returns the requests received for the given method, clearing the recorded requests
+func (a *testAdmin) getCalls(method string) []testAdminCall {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	calls := make([]testAdminCall, 0)
+	for _, call := range a.calls {
+		if call.method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// reset clears the recorded requests
+func (a *testAdmin) reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = nil
+}
+
+// close stops the fake admin services and restores the options with which admin services are dialed
+func (a *testAdmin) close() {
+	adminDialOptions = a.options
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, server := range a.servers {
+		server.Stop()
+	}
+}
+
+// testAdminServer is the fake admin service of a pod
+type testAdminServer struct {
+	admin *testAdmin
+	pod   string
+}
+
+func (s *testAdminServer) AddMember(ctx context.Context, request *storage.AddMemberRequest) (*storage.AddMemberResponse, error) {
+	return &storage.AddMemberResponse{}, s.admin.call(s.pod, "AddMember", request)
+}
+
+func (s *testAdminServer) RemoveMember(ctx context.Context, request *storage.RemoveMemberRequest) (*storage.RemoveMemberResponse, error) {
+	return &storage.RemoveMemberResponse{}, s.admin.call(s.pod, "RemoveMember", request)
+}
+
+func (s *testAdminServer) SyncMember(ctx context.Context, request *storage.SyncMemberRequest) (*storage.SyncMemberResponse, error) {
+	return &storage.SyncMemberResponse{}, s.admin.call(s.pod, "SyncMember", request)
+}
+
+func (s *testAdminServer) TransferLeadership(ctx context.Context, request *storage.TransferLeadershipRequest) (*storage.TransferLeadershipResponse, error) {
+	return &storage.TransferLeadershipResponse{}, s.admin.call(s.pod, "TransferLeadership", request)
+}
+
+func (s *testAdminServer) AcquireLeadership(ctx context.Context, request *storage.AcquireLeadershipRequest) (*storage.AcquireLeadershipResponse, error) {
+	return &storage.AcquireLeadershipResponse{}, s.admin.call(s.pod, "AcquireLeadership", request)
+}
+
+func (s *testAdminServer) SnapshotMember(ctx context.Context, request *storage.SnapshotMemberRequest) (*storage.SnapshotMemberResponse, error) {
+	return &storage.SnapshotMemberResponse{}, s.admin.call(s.pod, "SnapshotMember", request)
+}
+
+func (s *testAdminServer) CompactMember(ctx context.Context, request *storage.CompactMemberRequest) (*storage.CompactMemberResponse, error) {
+	return &storage.CompactMemberResponse{}, s.admin.call(s.pod, "CompactMember", request)
+}
+
+func (s *testAdminServer) RestartMember(ctx context.Context, request *storage.RestartMemberRequest) (*storage.RestartMemberResponse, error) {
+	return &storage.RestartMemberResponse{}, s.admin.call(s.pod, "RestartMember", request)
+}
+
+func (s *testAdminServer) StartMember(ctx context.Context, request *storage.StartMemberRequest) (*storage.StartMemberResponse, error) {
+	return &storage.StartMemberResponse{}, s.admin.call(s.pod, "StartMember", request)
+}
+
+func (s *testAdminServer) StopMember(ctx context.Context, request *storage.StopMemberRequest) (*storage.StopMemberResponse, error) {
+	return &storage.StopMemberResponse{}, s.admin.call(s.pod, "StopMember", request)
+}
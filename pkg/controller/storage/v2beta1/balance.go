@@ -0,0 +1,170 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultMaxLeaderImbalance    = 1
+	defaultLeaderBalanceInterval = 30 * time.Second
+)
+
+// getMaxLeaderImbalance returns the maximum difference in the number of leaders between replicas
+func getMaxLeaderImbalance(protocol *storagev2beta1.MultiRaftProtocol) int {
+	if protocol.Spec.LeaderBalancing == nil || protocol.Spec.LeaderBalancing.MaxImbalance == 0 {
+		return defaultMaxLeaderImbalance
+	}
+	return int(protocol.Spec.LeaderBalancing.MaxImbalance)
+}
+
+// getLeaderBalanceInterval returns the minimum interval between leader transfers in a cluster
+func getLeaderBalanceInterval(protocol *storagev2beta1.MultiRaftProtocol) time.Duration {
+	if protocol.Spec.LeaderBalancing == nil || protocol.Spec.LeaderBalancing.Interval == nil {
+		return defaultLeaderBalanceInterval
+	}
+	return protocol.Spec.LeaderBalancing.Interval.Duration
+}
+
+// reconcileLeaderBalance transfers leadership of a partition from the replica leading the most partitions to
+// the replica leading the fewest when the difference exceeds the maximum imbalance. At most one partition is
+// transferred per interval, and only partitions whose members are all ready and caught up are transferred.
+func (r *Reconciler) reconcileLeaderBalance(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	if protocol.Spec.LeaderBalancing == nil || cluster.Status.Replicas < 2 {
+		return nil
+	}
+
+	// Membership changes and restarts move leaders on their own, so wait for them to complete
+	if cluster.Status.Scale != nil || cluster.Status.Upgrade != nil || isMigrating(protocol, cluster) {
+		return nil
+	}
+
+	lastTransfer := cluster.Status.LastLeaderTransferTime
+	if lastTransfer != nil && time.Since(lastTransfer.Time) < getLeaderBalanceInterval(protocol) {
+		return nil
+	}
+
+	clusterID := int(cluster.Spec.ClusterID)
+	replicaIDs := make(map[string]int)
+	leaders := make([][]*storagev2beta1.RaftPartition, cluster.Status.Replicas)
+	for replicaID := 0; replicaID < int(cluster.Status.Replicas); replicaID++ {
+		replicaIDs[getPodName(protocol, clusterID, replicaID)] = replicaID
+	}
+	for _, partitionID := range getPartitions(protocol, clusterID) {
+		partition, err := r.getPartition(protocol, clusterID, partitionID)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		// Leaders are only balanced once every partition has elected a leader among the voting replicas
+		if partition.Status.Leader == nil {
+			return nil
+		}
+		replicaID, ok := replicaIDs[*partition.Status.Leader]
+		if !ok {
+			return nil
+		}
+		leaders[replicaID] = append(leaders[replicaID], partition)
+	}
+
+	sourceID, targetID, ok := getLeaderTransfer(leaders, getMaxLeaderImbalance(protocol))
+	if !ok {
+		return nil
+	}
+
+	for _, partition := range leaders[sourceID] {
+		partitionID := int(partition.Spec.PartitionID)
+		healthy, err := r.isLeaderTransferable(protocol, partition, targetID)
+		if err != nil {
+			return err
+		} else if !healthy {
+			continue
+		}
+
+		source := getPodName(protocol, clusterID, sourceID)
+		target := getPodName(protocol, clusterID, targetID)
+		log.Infof("Transferring leadership of partition %d from %s to %s", partitionID, source, target)
+		err = invokeReplicaAdmin(protocol, clusterID, targetID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			_, err := client.AcquireLeadership(ctx, &storage.AcquireLeadershipRequest{
+				Partition: uint64(partitionID),
+			})
+			return err
+		})
+		if err != nil {
+			log.Warnf("Failed to transfer leadership of partition %d to %s: %s", partitionID, target, err)
+			r.events.Eventf(cluster, "Warning", "LeaderTransferFailed", "Failed to transfer leadership of partition %d from %s to %s: %s", partitionID, source, target, err)
+		} else {
+			leaderTransfers.WithLabelValues(protocol.Namespace, protocol.Name, fmt.Sprint(clusterID)).Inc()
+			r.events.Eventf(cluster, "Normal", "LeaderTransferred", "Transferred leadership of partition %d from %s to %s", partitionID, source, target)
+		}
+
+		// Failed transfers are rate limited along with successful ones
+		original := cluster.DeepCopy()
+		now := metav1.Now()
+		cluster.Status.LastLeaderTransferTime = &now
+		return r.client.Status().Patch(context.TODO(), cluster, client.MergeFrom(original))
+	}
+	return nil
+}
+
+// getLeaderTransfer returns the replica leading the most partitions and the replica leading the fewest, and
+// whether the difference in the number of partitions they lead exceeds the maximum imbalance
+func getLeaderTransfer(leaders [][]*storagev2beta1.RaftPartition, maxImbalance int) (int, int, bool) {
+	if len(leaders) == 0 {
+		return 0, 0, false
+	}
+	sourceID, targetID := 0, 0
+	for replicaID := range leaders {
+		if len(leaders[replicaID]) > len(leaders[sourceID]) {
+			sourceID = replicaID
+		}
+		if len(leaders[replicaID]) < len(leaders[targetID]) {
+			targetID = replicaID
+		}
+	}
+	return sourceID, targetID, len(leaders[sourceID])-len(leaders[targetID]) > maxImbalance
+}
+
+// isLeaderTransferable returns whether leadership of the given partition can be transferred to the given replica.
+// The partition must be stable with all members ready, and the target must be a voting member that is caught up.
+func (r *Reconciler) isLeaderTransferable(protocol *storagev2beta1.MultiRaftProtocol, partition *storagev2beta1.RaftPartition, replicaID int) (bool, error) {
+	if !isConditionTrue(partition.Status.Conditions, storagev2beta1.ConditionReady) ||
+		isConditionTrue(partition.Status.Conditions, storagev2beta1.ConditionProgressing) {
+		return false, nil
+	}
+
+	member, err := r.getMember(protocol, int(partition.Spec.ClusterID), int(partition.Spec.PartitionID), replicaID)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return isConditionTrue(member.Status.Conditions, storagev2beta1.ConditionReady) &&
+		!isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberLagging) &&
+		!isLearner(member), nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+// newBalanceTestReconciler returns a reconciler for the given protocol with the given pod readiness, and with the
+// leader of each partition of the first cluster elected on the given replica
+func newBalanceTestReconciler(t *testing.T, protocol *storagev2beta1.MultiRaftProtocol, ready []bool, leaders []int) *Reconciler {
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	setTestPods(t, reconciler, protocol, 1, ready)
+	for i, podID := range leaders {
+		setTestLeader(t, reconciler, protocol, 1, i+1, podID)
+	}
+	// Partition leaders and conditions are recorded in the status before leaders are balanced
+	reconcileTestProtocol(t, reconciler, protocol)
+	getTestEvents(reconciler)
+	return reconciler
+}
+
+func TestReconcileLeaderBalance(t *testing.T) {
+	tests := []struct {
+		name        string
+		balancing   *storagev2beta1.RaftLeaderBalancing
+		ready       []bool
+		leaders     []int
+		fail        bool
+		partitionID int
+		source      int
+		target      int
+	}{
+		{
+			name:        "imbalance exceeds limit",
+			balancing:   &storagev2beta1.RaftLeaderBalancing{},
+			ready:       []bool{true, true, true},
+			leaders:     []int{0, 0, 0},
+			partitionID: 1,
+			source:      0,
+			target:      1,
+		},
+		{
+			name:        "most leaders on last replica",
+			balancing:   &storagev2beta1.RaftLeaderBalancing{},
+			ready:       []bool{true, true, true},
+			leaders:     []int{2, 1, 2, 2},
+			partitionID: 1,
+			source:      2,
+			target:      0,
+		},
+		{
+			name:        "failed transfer",
+			balancing:   &storagev2beta1.RaftLeaderBalancing{},
+			ready:       []bool{true, true, true},
+			leaders:     []int{0, 0, 0},
+			fail:        true,
+			partitionID: 1,
+			source:      0,
+			target:      1,
+		},
+		{
+			name:      "imbalance within limit",
+			balancing: &storagev2beta1.RaftLeaderBalancing{},
+			ready:     []bool{true, true, true},
+			leaders:   []int{0, 1, 2, 0},
+		},
+		{
+			name:      "imbalance within higher limit",
+			balancing: &storagev2beta1.RaftLeaderBalancing{MaxImbalance: 3},
+			ready:     []bool{true, true, true},
+			leaders:   []int{0, 0, 0},
+		},
+		{
+			name:    "balancing disabled",
+			ready:   []bool{true, true, true},
+			leaders: []int{0, 0, 0},
+		},
+		{
+			name:      "partitions not ready",
+			balancing: &storagev2beta1.RaftLeaderBalancing{},
+			ready:     []bool{true, false, true},
+			leaders:   []int{0, 0, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			admin := newTestAdmin()
+			defer admin.close()
+
+			protocol := newTestProtocol(1, int32(len(test.leaders)), int32(len(test.ready)))
+			protocol.Spec.LeaderBalancing = test.balancing
+			reconciler := newBalanceTestReconciler(t, protocol, test.ready, test.leaders)
+			source := getPodName(protocol, 1, test.source)
+			target := getPodName(protocol, 1, test.target)
+			if test.fail {
+				admin.fail(target, "AcquireLeadership")
+			}
+			transfers := testutil.ToFloat64(leaderTransfers.WithLabelValues(protocol.Namespace, protocol.Name, "1"))
+
+			reconcileTestProtocol(t, reconciler, protocol)
+			calls := admin.getCalls("AcquireLeadership")
+			cluster := &storagev2beta1.RaftCluster{}
+			getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+			if test.partitionID == 0 {
+				assert.Empty(t, calls)
+				assert.Nil(t, cluster.Status.LastLeaderTransferTime)
+				return
+			}
+
+			// Failed transfers are rate limited along with successful ones
+			assert.Len(t, calls, 1)
+			assert.Equal(t, target, calls[0].pod)
+			assert.Equal(t, uint64(test.partitionID), calls[0].request.(*storage.AcquireLeadershipRequest).Partition)
+			assert.NotNil(t, cluster.Status.LastLeaderTransferTime)
+			events := getTestEvents(reconciler)
+			if test.fail {
+				assert.Equal(t, transfers, testutil.ToFloat64(leaderTransfers.WithLabelValues(protocol.Namespace, protocol.Name, "1")))
+				assert.Len(t, events, 1)
+				assert.Contains(t, events[0], "Warning LeaderTransferFailed Failed to transfer leadership of partition 1 from "+source+" to "+target)
+			} else {
+				assert.Equal(t, transfers+1, testutil.ToFloat64(leaderTransfers.WithLabelValues(protocol.Namespace, protocol.Name, "1")))
+				assert.Contains(t, events, "Normal LeaderTransferred Transferred leadership of partition 1 from "+source+" to "+target)
+			}
+		})
+	}
+}
+
+func TestReconcileLeaderBalanceInterval(t *testing.T) {
+	admin := newTestAdmin()
+	defer admin.close()
+
+	protocol := newTestProtocol(1, 3, 3)
+	protocol.Spec.LeaderBalancing = &storagev2beta1.RaftLeaderBalancing{
+		Interval: &metav1.Duration{Duration: time.Minute},
+	}
+	reconciler := newBalanceTestReconciler(t, protocol, []bool{true, true, true}, []int{0, 0, 0})
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Len(t, admin.getCalls("AcquireLeadership"), 1)
+	admin.reset()
+
+	// No further leaders are transferred until the interval has elapsed
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Empty(t, admin.getCalls("AcquireLeadership"))
+
+	cluster := &storagev2beta1.RaftCluster{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+	lastTransfer := metav1.NewTime(time.Now().Add(-time.Minute))
+	cluster.Status.LastLeaderTransferTime = &lastTransfer
+	assert.NoError(t, reconciler.client.Status().Update(context.TODO(), cluster))
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Len(t, admin.getCalls("AcquireLeadership"), 1)
+}
+
+func TestReconcileLeaderBalanceWaitsForLeaders(t *testing.T) {
+	admin := newTestAdmin()
+	defer admin.close()
+
+	// Leaders are not balanced until every partition has elected a leader
+	protocol := newTestProtocol(1, 3, 3)
+	protocol.Spec.LeaderBalancing = &storagev2beta1.RaftLeaderBalancing{}
+	reconciler := newBalanceTestReconciler(t, protocol, []bool{true, true, true}, []int{0, 0})
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Empty(t, admin.getCalls("AcquireLeadership"))
+
+	setTestLeader(t, reconciler, protocol, 1, 3, 0)
+	reconcileTestProtocol(t, reconciler, protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Len(t, admin.getCalls("AcquireLeadership"), 1)
+}
@@ -0,0 +1,252 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"fmt"
+	"strings"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultMaxMemberLag is the default estimated number of entries a member may be behind its leader before
+// it's considered lagging
+const defaultMaxMemberLag = 1000
+
+// getMaxMemberLag returns the estimated number of entries a member may be behind its leader before it's
+// considered lagging
+func getMaxMemberLag(protocol *storagev2beta1.MultiRaftProtocol) uint64 {
+	if protocol.Spec.MaxMemberLag == 0 {
+		return defaultMaxMemberLag
+	}
+	return uint64(protocol.Spec.MaxMemberLag)
+}
+
+// getCondition returns the condition of the given type
+func getCondition(conditions []storagev2beta1.Condition, conditionType storagev2beta1.ConditionType) *storagev2beta1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// isConditionTrue returns whether the condition of the given type is true
+func isConditionTrue(conditions []storagev2beta1.Condition, conditionType storagev2beta1.ConditionType) bool {
+	condition := getCondition(conditions, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// setCondition adds or updates the given condition, returning the updated conditions and whether they changed
+func setCondition(conditions []storagev2beta1.Condition, condition storagev2beta1.Condition) ([]storagev2beta1.Condition, bool) {
+	existing := getCondition(conditions, condition.Type)
+	if existing == nil {
+		condition.LastTransitionTime = metav1.Now()
+		return append(conditions, condition), true
+	}
+	if existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message &&
+		existing.ObservedGeneration == condition.ObservedGeneration {
+		return conditions, false
+	}
+	if existing.Status != condition.Status {
+		existing.LastTransitionTime = metav1.Now()
+	}
+	existing.Status = condition.Status
+	existing.Reason = condition.Reason
+	existing.Message = condition.Message
+	existing.ObservedGeneration = condition.ObservedGeneration
+	return conditions, true
+}
+
+// setConditions adds or updates each of the given conditions, returning the updated conditions and whether they changed
+func setConditions(conditions []storagev2beta1.Condition, updates []storagev2beta1.Condition) ([]storagev2beta1.Condition, bool) {
+	changed := false
+	for _, condition := range updates {
+		var updated bool
+		conditions, updated = setCondition(conditions, condition)
+		changed = changed || updated
+	}
+	return conditions, changed
+}
+
+// newCondition returns a condition of the given type that is true if the given value is true
+func newCondition(conditionType storagev2beta1.ConditionType, value bool, reason, message string, generation int64) storagev2beta1.Condition {
+	status := corev1.ConditionFalse
+	if value {
+		status = corev1.ConditionTrue
+	}
+	return storagev2beta1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+}
+
+// newMemberConditions returns the conditions of the given member. A member is Lagging when its lag exceeds maxLag.
+func newMemberConditions(member *storagev2beta1.RaftMember, maxLag uint64) []storagev2beta1.Condition {
+	ready := member.Status.State != nil && *member.Status.State == storagev2beta1.RaftMemberReady
+	learner := isLearner(member)
+
+	conditions := make([]storagev2beta1.Condition, 0, 4)
+	if ready {
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionReady, true, "MemberReady", "Member is ready", member.Generation))
+	} else {
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionReady, false, "MemberNotReady", "Member is not ready", member.Generation))
+	}
+	switch {
+	case ready && !learner:
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionAvailable, true, "Voting", "Member is a ready voting member", member.Generation))
+	case ready && learner:
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionAvailable, false, "Learner", "Member is a non-voting learner", member.Generation))
+	default:
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionAvailable, false, "MemberNotReady", "Member is not ready", member.Generation))
+	}
+	if learner {
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionProgressing, true, "CatchingUp", "Member is catching up with the leader", member.Generation))
+	} else {
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionProgressing, false, "Stable", "Member is a voting member", member.Generation))
+	}
+	switch {
+	case member.Status.Lag == nil:
+		conditions = append(conditions, newCondition(storagev2beta1.RaftMemberLagging, false, "LagUnknown", "Member has not reported its applied index", member.Generation))
+	case *member.Status.Lag > maxLag:
+		conditions = append(conditions, newCondition(storagev2beta1.RaftMemberLagging, true, "Lagging", fmt.Sprintf("Member is estimated to be more than %d entries behind the leader", maxLag), member.Generation))
+	default:
+		conditions = append(conditions, newCondition(storagev2beta1.RaftMemberLagging, false, "CaughtUp", "Member is caught up with the leader", member.Generation))
+	}
+	switch usage := member.Status.DiskUsage; {
+	case usage == nil:
+		conditions = append(conditions, newCondition(storagev2beta1.RaftMemberDiskPressure, false, "DiskUsageUnknown", "Pod has not reported its disk usage", member.Generation))
+	case usage.WritesRejected:
+		conditions = append(conditions, newCondition(storagev2beta1.RaftMemberDiskPressure, true, "WritesRejected", "Data volume exceeds the write limit and writes are rejected", member.Generation))
+	case usage.Pressure:
+		conditions = append(conditions, newCondition(storagev2beta1.RaftMemberDiskPressure, true, "DiskPressure", "Data volume exceeds the disk pressure threshold", member.Generation))
+	default:
+		conditions = append(conditions, newCondition(storagev2beta1.RaftMemberDiskPressure, false, "SufficientDisk", "Data volume has sufficient free space", member.Generation))
+	}
+	return conditions
+}
+
+// isLearner returns whether the given member has not yet become a voting member of its partition
+func isLearner(member *storagev2beta1.RaftMember) bool {
+	if member.Status.Type != nil {
+		return *member.Status.Type == storagev2beta1.RaftLearner
+	}
+	return member.Spec.Join
+}
+
+// newPartitionConditions returns the conditions of a partition with the given members and leader. A partition
+// is available when a majority of its voting members are ready and a ready leader is known.
+func newPartitionConditions(partition *storagev2beta1.RaftPartition, members []*storagev2beta1.RaftMember, leader *string, progressing bool) []storagev2beta1.Condition {
+	generation := partition.Generation
+	voters, readyVoters, readyMembers := 0, 0, 0
+	leaderReady := false
+	for _, member := range members {
+		ready := isConditionTrue(member.Status.Conditions, storagev2beta1.ConditionReady)
+		if ready {
+			readyMembers++
+			if leader != nil && member.Spec.Pod == *leader {
+				leaderReady = true
+			}
+		}
+		if !isLearner(member) {
+			voters++
+			if ready {
+				readyVoters++
+			}
+		}
+		if isConditionTrue(member.Status.Conditions, storagev2beta1.ConditionProgressing) {
+			progressing = true
+		}
+	}
+
+	quorum := readyVoters > voters/2
+	available := quorum && leaderReady
+	ready := available && readyMembers == len(members)
+	message := fmt.Sprintf("%d of %d voting members are ready", readyVoters, voters)
+	availableReason, availableMessage := "QuorumAvailable", message
+	if !quorum {
+		availableReason = "QuorumUnavailable"
+	} else if !leaderReady {
+		availableReason, availableMessage = "NoLeader", "No ready leader is known"
+	}
+	conditions := []storagev2beta1.Condition{
+		newCondition(storagev2beta1.ConditionReady, ready, conditionReason(ready, "MembersReady", "MembersNotReady"), fmt.Sprintf("%d of %d members are ready", readyMembers, len(members)), generation),
+		newCondition(storagev2beta1.ConditionAvailable, available, availableReason, availableMessage, generation),
+		newCondition(storagev2beta1.ConditionDegraded, available && !ready, conditionReason(available && !ready, "MembersNotReady", "MembersReady"), message, generation),
+		newCondition(storagev2beta1.ConditionQuorumLost, !quorum, conditionReason(!quorum, "QuorumUnavailable", "QuorumAvailable"), message, generation),
+	}
+	if progressing {
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionProgressing, true, "MembershipChanging", "Partition membership is changing", generation))
+	} else {
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionProgressing, false, "Stable", "Partition membership is stable", generation))
+	}
+	return conditions
+}
+
+// newAggregateConditions returns the conditions of a resource composed of children with the given conditions.
+// The kind is the plural name of the children, e.g. "Partitions".
+func newAggregateConditions(kind string, children [][]storagev2beta1.Condition, progressing bool, progressingReason string, generation int64) []storagev2beta1.Condition {
+	readyChildren, availableChildren, degradedChildren, quorumLostChildren := 0, 0, 0, 0
+	for _, conditions := range children {
+		if isConditionTrue(conditions, storagev2beta1.ConditionReady) {
+			readyChildren++
+		}
+		if isConditionTrue(conditions, storagev2beta1.ConditionAvailable) {
+			availableChildren++
+		}
+		if isConditionTrue(conditions, storagev2beta1.ConditionDegraded) {
+			degradedChildren++
+		}
+		if isConditionTrue(conditions, storagev2beta1.ConditionQuorumLost) {
+			quorumLostChildren++
+		}
+	}
+
+	ready := readyChildren == len(children)
+	available := availableChildren == len(children)
+	degraded := available && (!ready || degradedChildren > 0)
+	quorumLost := quorumLostChildren > 0
+	conditions := []storagev2beta1.Condition{
+		newCondition(storagev2beta1.ConditionReady, ready, conditionReason(ready, kind+"Ready", kind+"NotReady"), fmt.Sprintf("%d of %d %s are ready", readyChildren, len(children), strings.ToLower(kind)), generation),
+		newCondition(storagev2beta1.ConditionAvailable, available, conditionReason(available, kind+"Available", kind+"Unavailable"), fmt.Sprintf("%d of %d %s are available", availableChildren, len(children), strings.ToLower(kind)), generation),
+		newCondition(storagev2beta1.ConditionDegraded, degraded, conditionReason(degraded, kind+"Degraded", kind+"Healthy"), fmt.Sprintf("%d of %d %s are degraded", degradedChildren, len(children), strings.ToLower(kind)), generation),
+		newCondition(storagev2beta1.ConditionQuorumLost, quorumLost, conditionReason(quorumLost, "QuorumUnavailable", "QuorumAvailable"), fmt.Sprintf("%d of %d %s lost quorum", quorumLostChildren, len(children), strings.ToLower(kind)), generation),
+	}
+	if progressing {
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionProgressing, true, progressingReason, fmt.Sprintf("%s in progress", progressingReason), generation))
+	} else {
+		conditions = append(conditions, newCondition(storagev2beta1.ConditionProgressing, false, "Stable", "No changes in progress", generation))
+	}
+	return conditions
+}
+
+// conditionReason returns the reason for a condition with the given value
+func conditionReason(value bool, trueReason, falseReason string) string {
+	if value {
+		return trueReason
+	}
+	return falseReason
+}
+
+// isAvailable returns whether the given conditions indicate a resource is available
+func isAvailable(conditions []storagev2beta1.Condition) bool {
+	return isConditionTrue(conditions, storagev2beta1.ConditionAvailable)
+}
@@ -0,0 +1,287 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+// assertTestConditions asserts the status of each of the given conditions
+func assertTestConditions(t *testing.T, conditions []storagev2beta1.Condition, expected map[storagev2beta1.ConditionType]corev1.ConditionStatus) {
+	for conditionType, status := range expected {
+		condition := getCondition(conditions, conditionType)
+		if assert.NotNil(t, condition, string(conditionType)) {
+			assert.Equal(t, status, condition.Status, string(conditionType))
+			assert.False(t, condition.LastTransitionTime.IsZero(), string(conditionType))
+		}
+	}
+}
+
+func TestReconcileConditions(t *testing.T) {
+	protocol := newTestProtocol(1, 2, 3)
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	setTestPods(t, reconciler, protocol, 1, []bool{true, true, true})
+	setTestLeader(t, reconciler, protocol, 1, 1, 0)
+	setTestLeader(t, reconciler, protocol, 1, 2, 1)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	ready := map[storagev2beta1.ConditionType]corev1.ConditionStatus{
+		storagev2beta1.ConditionReady:       corev1.ConditionTrue,
+		storagev2beta1.ConditionAvailable:   corev1.ConditionTrue,
+		storagev2beta1.ConditionDegraded:    corev1.ConditionFalse,
+		storagev2beta1.ConditionProgressing: corev1.ConditionFalse,
+		storagev2beta1.ConditionQuorumLost:  corev1.ConditionFalse,
+	}
+	member := &storagev2beta1.RaftMember{}
+	getTestObject(t, reconciler, getMemberName(protocol, 1, 1, 2), member)
+	assertTestConditions(t, member.Status.Conditions, map[storagev2beta1.ConditionType]corev1.ConditionStatus{
+		storagev2beta1.ConditionReady:       corev1.ConditionTrue,
+		storagev2beta1.ConditionAvailable:   corev1.ConditionTrue,
+		storagev2beta1.ConditionProgressing: corev1.ConditionFalse,
+	})
+	partition := &storagev2beta1.RaftPartition{}
+	getTestObject(t, reconciler, getPartitionName(protocol, 1, 1), partition)
+	assertTestConditions(t, partition.Status.Conditions, ready)
+	assert.Equal(t, getPodName(protocol, 1, 0), *partition.Status.Leader)
+	cluster := &storagev2beta1.RaftCluster{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+	assertTestConditions(t, cluster.Status.Conditions, ready)
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	assertTestConditions(t, protocol.Status.Conditions, ready)
+	assert.Equal(t, "ClustersReady", getCondition(protocol.Status.Conditions, storagev2beta1.ConditionReady).Reason)
+	available := *getCondition(protocol.Status.Conditions, storagev2beta1.ConditionAvailable)
+
+	// A minority of unready replicas degrades every resource without making it unavailable
+	setTestPods(t, reconciler, protocol, 1, []bool{true, true, false})
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	degraded := map[storagev2beta1.ConditionType]corev1.ConditionStatus{
+		storagev2beta1.ConditionReady:       corev1.ConditionFalse,
+		storagev2beta1.ConditionAvailable:   corev1.ConditionTrue,
+		storagev2beta1.ConditionDegraded:    corev1.ConditionTrue,
+		storagev2beta1.ConditionProgressing: corev1.ConditionFalse,
+		storagev2beta1.ConditionQuorumLost:  corev1.ConditionFalse,
+	}
+	getTestObject(t, reconciler, getMemberName(protocol, 1, 1, 2), member)
+	assertTestConditions(t, member.Status.Conditions, map[storagev2beta1.ConditionType]corev1.ConditionStatus{
+		storagev2beta1.ConditionReady:     corev1.ConditionFalse,
+		storagev2beta1.ConditionAvailable: corev1.ConditionFalse,
+	})
+	getTestObject(t, reconciler, getPartitionName(protocol, 1, 1), partition)
+	assertTestConditions(t, partition.Status.Conditions, degraded)
+	getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+	assertTestConditions(t, cluster.Status.Conditions, degraded)
+	assert.Equal(t, "PartitionsDegraded", getCondition(cluster.Status.Conditions, storagev2beta1.ConditionDegraded).Reason)
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	assertTestConditions(t, protocol.Status.Conditions, degraded)
+	assert.Equal(t, storagev2beta1.MultiRaftProtocolDegraded, protocol.Status.State)
+	assert.Equal(t, available.LastTransitionTime, getCondition(protocol.Status.Conditions, storagev2beta1.ConditionAvailable).LastTransitionTime)
+
+	// Losing a majority of replicas loses quorum
+	setTestPods(t, reconciler, protocol, 1, []bool{true, false, false})
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	getTestObject(t, reconciler, getPartitionName(protocol, 1, 1), partition)
+	assertTestConditions(t, partition.Status.Conditions, map[storagev2beta1.ConditionType]corev1.ConditionStatus{
+		storagev2beta1.ConditionReady:      corev1.ConditionFalse,
+		storagev2beta1.ConditionAvailable:  corev1.ConditionFalse,
+		storagev2beta1.ConditionDegraded:   corev1.ConditionFalse,
+		storagev2beta1.ConditionQuorumLost: corev1.ConditionTrue,
+	})
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	assert.True(t, isConditionTrue(protocol.Status.Conditions, storagev2beta1.ConditionQuorumLost))
+	assert.Equal(t, storagev2beta1.MultiRaftProtocolNotReady, protocol.Status.State)
+}
+
+func TestReconcilePartitionAvailability(t *testing.T) {
+	tests := []struct {
+		name            string
+		ready           []bool
+		learners        []int
+		noLeader        bool
+		state           storagev2beta1.RaftPartitionState
+		availableReason string
+		progressing     bool
+	}{
+		{
+			name:            "all members ready",
+			ready:           []bool{true, true, true},
+			state:           storagev2beta1.RaftPartitionReady,
+			availableReason: "QuorumAvailable",
+		},
+		{
+			name:            "minority not ready",
+			ready:           []bool{true, true, false},
+			state:           storagev2beta1.RaftPartitionDegraded,
+			availableReason: "QuorumAvailable",
+		},
+		{
+			name:            "majority not ready",
+			ready:           []bool{true, false, false},
+			state:           storagev2beta1.RaftPartitionNotReady,
+			availableReason: "QuorumUnavailable",
+		},
+		{
+			name:            "half of an even number of voters ready",
+			ready:           []bool{true, true, false, false},
+			state:           storagev2beta1.RaftPartitionNotReady,
+			availableReason: "QuorumUnavailable",
+		},
+		{
+			name:            "no leader",
+			ready:           []bool{true, true, true},
+			noLeader:        true,
+			state:           storagev2beta1.RaftPartitionNotReady,
+			availableReason: "NoLeader",
+		},
+		{
+			name:            "leader not ready",
+			ready:           []bool{false, true, true},
+			state:           storagev2beta1.RaftPartitionNotReady,
+			availableReason: "NoLeader",
+		},
+		{
+			name:            "learners excluded from quorum",
+			ready:           []bool{true, false, true, true},
+			learners:        []int{2, 3},
+			state:           storagev2beta1.RaftPartitionNotReady,
+			availableReason: "QuorumUnavailable",
+			progressing:     true,
+		},
+		{
+			name:            "learner not ready",
+			ready:           []bool{true, true, true, false},
+			learners:        []int{3},
+			state:           storagev2beta1.RaftPartitionDegraded,
+			availableReason: "QuorumAvailable",
+			progressing:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(1, 1, int32(len(test.ready)))
+			reconciler := newTestReconciler(protocol)
+			reconcileTestProtocol(t, reconciler, protocol)
+
+			for _, podID := range test.learners {
+				member := &storagev2beta1.RaftMember{}
+				getTestObject(t, reconciler, getMemberName(protocol, 1, 1, podID), member)
+				learner := storagev2beta1.RaftLearner
+				member.Status.Type = &learner
+				assert.NoError(t, reconciler.client.Status().Update(context.TODO(), member))
+			}
+			setTestPods(t, reconciler, protocol, 1, test.ready)
+			if !test.noLeader {
+				setTestLeader(t, reconciler, protocol, 1, 1, 0)
+			}
+			reconcileTestProtocol(t, reconciler, protocol)
+
+			partition := &storagev2beta1.RaftPartition{}
+			getTestObject(t, reconciler, getPartitionName(protocol, 1, 1), partition)
+			assert.Equal(t, test.state, partition.Status.State)
+			conditions := partition.Status.Conditions
+			assert.Equal(t, test.state == storagev2beta1.RaftPartitionReady, isConditionTrue(conditions, storagev2beta1.ConditionReady))
+			assert.Equal(t, test.state != storagev2beta1.RaftPartitionNotReady, isConditionTrue(conditions, storagev2beta1.ConditionAvailable))
+			assert.Equal(t, test.availableReason, getCondition(conditions, storagev2beta1.ConditionAvailable).Reason)
+			assert.Equal(t, test.state == storagev2beta1.RaftPartitionDegraded, isConditionTrue(conditions, storagev2beta1.ConditionDegraded))
+			assert.Equal(t, test.availableReason == "QuorumUnavailable", isConditionTrue(conditions, storagev2beta1.ConditionQuorumLost))
+			assert.Equal(t, test.progressing, isConditionTrue(conditions, storagev2beta1.ConditionProgressing))
+
+			// Available partitions are reported ready to clients even when degraded
+			getTestObject(t, reconciler, protocol.Name, protocol)
+			assert.Len(t, protocol.Status.Partitions, 1)
+			assert.Equal(t, test.state != storagev2beta1.RaftPartitionNotReady, protocol.Status.Partitions[0].Ready)
+		})
+	}
+}
+
+func TestNewMemberLaggingCondition(t *testing.T) {
+	lag := func(lag uint64) *uint64 {
+		return &lag
+	}
+
+	tests := []struct {
+		name         string
+		lag          *uint64
+		maxMemberLag int64
+		lagging      bool
+		reason       string
+	}{
+		{
+			name:   "unknown lag",
+			reason: "LagUnknown",
+		},
+		{
+			name:   "within default",
+			lag:    lag(defaultMaxMemberLag),
+			reason: "CaughtUp",
+		},
+		{
+			name:    "exceeds default",
+			lag:     lag(defaultMaxMemberLag + 1),
+			lagging: true,
+			reason:  "Lagging",
+		},
+		{
+			name:         "within configured",
+			lag:          lag(defaultMaxMemberLag + 1),
+			maxMemberLag: 5000,
+			reason:       "CaughtUp",
+		},
+		{
+			name:         "exceeds configured",
+			lag:          lag(101),
+			maxMemberLag: 100,
+			lagging:      true,
+			reason:       "Lagging",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := &storagev2beta1.MultiRaftProtocol{}
+			protocol.Spec.MaxMemberLag = test.maxMemberLag
+			member := &storagev2beta1.RaftMember{}
+			member.Status.Lag = test.lag
+			conditions := newMemberConditions(member, getMaxMemberLag(protocol))
+			assert.Equal(t, test.lagging, isConditionTrue(conditions, storagev2beta1.RaftMemberLagging))
+			assert.Equal(t, test.reason, getCondition(conditions, storagev2beta1.RaftMemberLagging).Reason)
+		})
+	}
+}
+
+func TestSetCondition(t *testing.T) {
+	conditions, changed := setCondition(nil, newCondition(storagev2beta1.ConditionReady, false, "NotReady", "", 1))
+	assert.True(t, changed)
+	assert.Len(t, conditions, 1)
+	assert.False(t, conditions[0].LastTransitionTime.IsZero())
+
+	conditions, changed = setCondition(conditions, newCondition(storagev2beta1.ConditionReady, false, "NotReady", "", 1))
+	assert.False(t, changed)
+
+	conditions, changed = setCondition(conditions, newCondition(storagev2beta1.ConditionReady, true, "Ready", "", 2))
+	assert.True(t, changed)
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, corev1.ConditionTrue, conditions[0].Status)
+	assert.Equal(t, "Ready", conditions[0].Reason)
+	assert.Equal(t, int64(2), conditions[0].ObservedGeneration)
+}
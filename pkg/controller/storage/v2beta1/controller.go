@@ -27,5 +27,14 @@ func AddControllers(mgr manager.Manager) error {
 	if err := addRaftProtocolController(mgr); err != nil {
 		return err
 	}
+	if err := addRaftOperationController(mgr); err != nil {
+		return err
+	}
 	return nil
 }
+
+// AddWebhooks registers the defaulting and validating admission webhooks for MultiRaftProtocols
+// with the Manager's webhook server
+func AddWebhooks(mgr manager.Manager) {
+	addRaftProtocolWebhooks(mgr)
+}
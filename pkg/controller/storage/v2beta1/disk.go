@@ -0,0 +1,65 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+)
+
+// defaultDiskPressureThreshold is the default percentage of a data volume's capacity above which members
+// report DiskPressure
+const defaultDiskPressureThreshold = 80
+
+// getDiskPressureThreshold returns the percentage of a data volume's capacity above which members report DiskPressure
+func getDiskPressureThreshold(protocol *storagev2beta1.MultiRaftProtocol) int {
+	if protocol.Spec.DiskPressure == nil || protocol.Spec.DiskPressure.Threshold == 0 {
+		return defaultDiskPressureThreshold
+	}
+	return int(protocol.Spec.DiskPressure.Threshold)
+}
+
+// getDiskUsedPercent returns the percentage of the data volume's capacity in use
+func getDiskUsedPercent(usage *storagev2beta1.RaftDiskUsage) int {
+	if usage.Capacity == 0 || usage.Available >= usage.Capacity {
+		return 0
+	}
+	return int((usage.Capacity - usage.Available) * 100 / usage.Capacity)
+}
+
+// compactPod snapshots and compacts the logs of the members of the given partitions hosted by a pod
+// under disk pressure to reclaim space on its data volume
+func (r *Reconciler) compactPod(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, partitionIDs []int) {
+	pod := getPodName(protocol, clusterID, podID)
+	log.Infof("Compacting members of pod %s under disk pressure", pod)
+	compacted := 0
+	for _, partitionID := range partitionIDs {
+		err := invokeReplicaAdmin(protocol, clusterID, podID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			if _, err := client.SnapshotMember(ctx, &storage.SnapshotMemberRequest{Partition: uint64(partitionID)}); err != nil {
+				return err
+			}
+			_, err := client.CompactMember(ctx, &storage.CompactMemberRequest{Partition: uint64(partitionID)})
+			return err
+		})
+		if err != nil {
+			log.Warnf("Failed to compact partition %d of pod %s: %s", partitionID, pod, err)
+			continue
+		}
+		compacted++
+	}
+	r.events.Eventf(protocol, "Normal", "DiskPressureCompaction", "Compacted %d of %d partitions of pod %s under disk pressure", compacted, len(partitionIDs), pod)
+}
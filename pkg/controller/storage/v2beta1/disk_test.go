@@ -0,0 +1,201 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"github.com/atomix/atomix-raft-storage/pkg/storage/config"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+	"time"
+)
+
+// setTestDiskUsage reports the usage of the given pod's data volume through a Raft event received from the pod
+func setTestDiskUsage(reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, available uint64, writesRejected bool) {
+	reconciler.recordEvent(protocol, clusterID, podID, &storage.RaftEvent{
+		Timestamp: time.Now(),
+		Event: &storage.RaftEvent_DiskUsage{
+			DiskUsage: &storage.DiskUsageEvent{
+				WalSize:        20,
+				SnapshotSize:   10,
+				Capacity:       100,
+				Available:      available,
+				WritesRejected: writesRejected,
+			},
+		},
+	})
+	reconciler.statuses.flush()
+}
+
+// getTestDiskPressure returns the DiskPressure condition of the given member
+func getTestDiskPressure(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, clusterID int, partitionID int, podID int) *storagev2beta1.Condition {
+	member := &storagev2beta1.RaftMember{}
+	getTestObject(t, reconciler, getMemberName(protocol, clusterID, partitionID, podID), member)
+	return getCondition(member.Status.Conditions, storagev2beta1.RaftMemberDiskPressure)
+}
+
+// waitForTestCalls waits for the given number of requests for the given method to be received by the fake admin services
+func waitForTestCalls(t *testing.T, admin *testAdmin, method string, count int) []testAdminCall {
+	for i := 0; i < 100; i++ {
+		if calls := admin.getCalls(method); len(calls) >= count {
+			return calls
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	calls := admin.getCalls(method)
+	assert.Len(t, calls, count)
+	return calls
+}
+
+func TestReconcileDiskPressure(t *testing.T) {
+	tests := []struct {
+		name      string
+		pressure  *storagev2beta1.RaftDiskPressure
+		available uint64
+		rejected  bool
+		reason    string
+	}{
+		{
+			name:      "sufficient disk",
+			available: 50,
+			reason:    "SufficientDisk",
+		},
+		{
+			name:      "default threshold",
+			available: 20,
+			reason:    "DiskPressure",
+		},
+		{
+			name:      "below configured threshold",
+			pressure:  &storagev2beta1.RaftDiskPressure{Threshold: 90},
+			available: 20,
+			reason:    "SufficientDisk",
+		},
+		{
+			name:      "configured threshold",
+			pressure:  &storagev2beta1.RaftDiskPressure{Threshold: 90},
+			available: 10,
+			reason:    "DiskPressure",
+		},
+		{
+			name:      "writes rejected",
+			pressure:  &storagev2beta1.RaftDiskPressure{Threshold: 90, WriteLimit: 95},
+			available: 5,
+			rejected:  true,
+			reason:    "WritesRejected",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(1, 2, 3)
+			protocol.Spec.DiskPressure = test.pressure
+			reconciler := newTestReconciler(protocol)
+			reconcileTestProtocol(t, reconciler, protocol)
+			for partitionID := 1; partitionID <= 2; partitionID++ {
+				assert.Equal(t, "DiskUsageUnknown", getTestDiskPressure(t, reconciler, protocol, 1, partitionID, 0).Reason)
+			}
+
+			// The usage of a pod's data volume is recorded on each of the members the pod hosts
+			setTestDiskUsage(reconciler, protocol, 1, 0, test.available, test.rejected)
+			for partitionID := 1; partitionID <= 2; partitionID++ {
+				member := &storagev2beta1.RaftMember{}
+				getTestObject(t, reconciler, getMemberName(protocol, 1, partitionID, 0), member)
+				usage := member.Status.DiskUsage
+				assert.NotNil(t, usage)
+				assert.Equal(t, uint64(20), usage.WALSize)
+				assert.Equal(t, uint64(10), usage.SnapshotSize)
+				assert.Equal(t, uint64(100), usage.Capacity)
+				assert.Equal(t, test.available, usage.Available)
+				assert.Equal(t, test.rejected, usage.WritesRejected)
+
+				condition := getCondition(member.Status.Conditions, storagev2beta1.RaftMemberDiskPressure)
+				assert.Equal(t, test.reason, condition.Reason)
+				assert.Equal(t, test.reason != "SufficientDisk", isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberDiskPressure))
+				assert.Equal(t, "DiskUsageUnknown", getTestDiskPressure(t, reconciler, protocol, 1, partitionID, 1).Reason)
+			}
+		})
+	}
+}
+
+func TestReconcileDiskPressureRelieved(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	getTestEvents(reconciler)
+
+	setTestDiskUsage(reconciler, protocol, 1, 0, 10, false)
+	assert.Equal(t, "DiskPressure", getTestDiskPressure(t, reconciler, protocol, 1, 1, 0).Reason)
+	assert.Contains(t, getTestEvents(reconciler), "Warning DiskPressure Data volume is 90% full")
+
+	// Reports of the same pressure don't repeat the event
+	setTestDiskUsage(reconciler, protocol, 1, 0, 9, false)
+	assert.NotContains(t, getTestEvents(reconciler), "Warning DiskPressure Data volume is 91% full")
+
+	setTestDiskUsage(reconciler, protocol, 1, 0, 60, false)
+	assert.Equal(t, "SufficientDisk", getTestDiskPressure(t, reconciler, protocol, 1, 1, 0).Reason)
+	assert.Contains(t, getTestEvents(reconciler), "Normal DiskPressureRelieved Data volume is 40% full")
+}
+
+func TestReconcileDiskPressureCompaction(t *testing.T) {
+	admin := newTestAdmin()
+	defer admin.close()
+
+	protocol := newTestProtocol(1, 2, 3)
+	protocol.Spec.DiskPressure = &storagev2beta1.RaftDiskPressure{
+		CompactOnPressure: true,
+	}
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	getTestEvents(reconciler)
+
+	// Members are snapshotted and compacted when their pod comes under disk pressure
+	setTestDiskUsage(reconciler, protocol, 1, 1, 10, false)
+	compactions := waitForTestCalls(t, admin, "CompactMember", 2)
+	snapshots := admin.getCalls("SnapshotMember")
+	assert.Len(t, snapshots, 2)
+	for i, partitionID := range []uint64{1, 2} {
+		assert.Equal(t, getPodName(protocol, 1, 1), snapshots[i].pod)
+		assert.Equal(t, partitionID, snapshots[i].request.(*storage.SnapshotMemberRequest).Partition)
+		assert.Equal(t, getPodName(protocol, 1, 1), compactions[i].pod)
+		assert.Equal(t, partitionID, compactions[i].request.(*storage.CompactMemberRequest).Partition)
+	}
+	admin.reset()
+
+	// Members already under pressure are not compacted again
+	setTestDiskUsage(reconciler, protocol, 1, 1, 5, false)
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, admin.getCalls("SnapshotMember"))
+}
+
+func TestReconcileDiskWriteLimit(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	protocol.Spec.DiskPressure = &storagev2beta1.RaftDiskPressure{
+		Threshold:  80,
+		WriteLimit: 95,
+	}
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	// The write limit is passed to the nodes in the protocol configuration
+	configMap := &corev1.ConfigMap{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), configMap)
+	protocolConfig := &config.ProtocolConfig{}
+	assert.NoError(t, jsonpb.UnmarshalString(configMap.Data[protocolConfigFile], protocolConfig))
+	assert.Equal(t, uint32(95), protocolConfig.GetMaxDiskUsage())
+}
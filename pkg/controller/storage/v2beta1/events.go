@@ -17,7 +17,7 @@ package v2beta1
 import (
 	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
 	"github.com/atomix/atomix-raft-storage/pkg/storage"
-	"github.com/cenkalti/backoff"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,23 +31,15 @@ func (r *Reconciler) recordPartitionReady(protocol *storagev2beta1.MultiRaftProt
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Normal", "Ready", "Member is ready to receive requests")
 
-	err = backoff.Retry(func() error {
-		member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
-		if err != nil {
-			log.Error(err)
-		}
-		state := storagev2beta1.RaftMemberReady
-		return r.updateMemberStatus(member, storagev2beta1.RaftMemberStatus{
-			State:       &state,
-			LastUpdated: &timestamp,
-		})
-	}, backoff.NewExponentialBackOff())
-	if err != nil {
-		log.Error(err)
-	}
+	state := storagev2beta1.RaftMemberReady
+	r.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{
+		State:       &state,
+		LastUpdated: &timestamp,
+	})
 }
 
 func (r *Reconciler) recordLeaderUpdated(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.LeaderUpdatedEvent, timestamp metav1.Time) {
@@ -59,6 +51,7 @@ func (r *Reconciler) recordLeaderUpdated(protocol *storagev2beta1.MultiRaftProto
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 
 	if member.Status.Term == nil || *member.Status.Term != event.Term {
@@ -71,28 +64,135 @@ func (r *Reconciler) recordLeaderUpdated(protocol *storagev2beta1.MultiRaftProto
 		r.events.Eventf(pod, "Normal", "PartitionLeaderChanged", "Leader for partition %d changed to %s for term %d", event.Partition, event.Leader, event.Term)
 	}
 
-	err = backoff.Retry(func() error {
-		member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
+	role := storagev2beta1.RaftFollower
+	if event.Leader == "" {
+		role = storagev2beta1.RaftCandidate
+	} else if event.Leader == getPodName(protocol, clusterID, podID) {
+		role = storagev2beta1.RaftLeader
+	}
+	var leader *string
+	if event.Leader != "" {
+		leader = &event.Leader
+	}
+	r.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{
+		Role:        &role,
+		Term:        &event.Term,
+		Leader:      leader,
+		LastUpdated: &timestamp,
+	})
+}
+
+func (r *Reconciler) recordMemberStats(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.MemberStatsEvent, timestamp metav1.Time) {
+	cluster, err := r.getCluster(protocol, clusterID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	var role storagev2beta1.RaftMemberRole
+	switch event.Role {
+	case storage.MemberStatsEvent_LEADER:
+		role = storagev2beta1.RaftLeader
+	case storage.MemberStatsEvent_CANDIDATE:
+		role = storagev2beta1.RaftCandidate
+	default:
+		role = storagev2beta1.RaftFollower
+	}
+
+	status := storagev2beta1.RaftMemberStatus{
+		Role:                  &role,
+		EstimatedAppliedIndex: &event.EstimatedAppliedIndex,
+		SnapshotSize:          &event.SnapshotSize,
+		LogSize:               &event.LogSize,
+		LastUpdated:           &timestamp,
+	}
+	if event.Term > 0 {
+		status.Term = &event.Term
+	}
+	if event.Leader != "" {
+		status.Leader = &event.Leader
+	}
+	if event.LastSnapshotIndex > 0 {
+		status.LastSnapshotIndex = &event.LastSnapshotIndex
+	}
+	lag, err := r.getMemberLag(protocol, cluster, event)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	status.Lag = lag
+	r.statuses.update(protocol, member, status)
+}
+
+// getMemberLag returns the estimated number of entries applied by the leader the member reporting the given stats
+// has yet to apply, or nil if the leader's applied index is unknown
+func (r *Reconciler) getMemberLag(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, event *storage.MemberStatsEvent) (*uint64, error) {
+	var lag uint64
+	if event.Role == storage.MemberStatsEvent_LEADER {
+		return &lag, nil
+	}
+	if event.Leader == "" {
+		return nil, nil
+	}
+	for _, memberID := range getMembers(cluster) {
+		leader, err := r.getMember(protocol, int(cluster.Spec.ClusterID), int(event.Partition), memberID)
 		if err != nil {
-			log.Error(err)
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if leader.Spec.Pod != event.Leader {
+			continue
 		}
-		role := storagev2beta1.RaftFollower
-		if event.Leader == getPodName(protocol, clusterID, podID) {
-			role = storagev2beta1.RaftLeader
+		if leader.Status.EstimatedAppliedIndex == nil {
+			return nil, nil
 		}
-		var leader *string
-		if event.Leader != "" {
-			leader = &event.Leader
+		if *leader.Status.EstimatedAppliedIndex > event.EstimatedAppliedIndex {
+			lag = *leader.Status.EstimatedAppliedIndex - event.EstimatedAppliedIndex
 		}
-		return r.updateMemberStatus(member, storagev2beta1.RaftMemberStatus{
-			Role:        &role,
-			Term:        &event.Term,
-			Leader:      leader,
+		return &lag, nil
+	}
+	return nil, nil
+}
+
+// recordDiskUsage records the usage of a pod's data volume on each of the members hosted by the pod
+func (r *Reconciler) recordDiskUsage(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.DiskUsageEvent, timestamp metav1.Time) {
+	usage := &storagev2beta1.RaftDiskUsage{
+		WALSize:        event.WalSize,
+		SnapshotSize:   event.SnapshotSize,
+		Capacity:       event.Capacity,
+		Available:      event.Available,
+		WritesRejected: event.WritesRejected,
+	}
+	usage.Pressure = getDiskUsedPercent(usage) >= getDiskPressureThreshold(protocol)
+
+	var pressured []int
+	for _, partitionID := range getPartitions(protocol, clusterID) {
+		member, err := r.getMember(protocol, clusterID, partitionID, podID)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				log.Error(err)
+			}
+			continue
+		}
+		if usage.Pressure && (member.Status.DiskUsage == nil || !member.Status.DiskUsage.Pressure) {
+			pressured = append(pressured, partitionID)
+		}
+		r.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{
+			DiskUsage:   usage,
 			LastUpdated: &timestamp,
 		})
-	}, backoff.NewExponentialBackOff())
-	if err != nil {
-		log.Error(err)
+	}
+
+	if len(pressured) > 0 && protocol.Spec.DiskPressure != nil && protocol.Spec.DiskPressure.CompactOnPressure {
+		go r.compactPod(protocol, clusterID, podID, pressured)
 	}
 }
 
@@ -106,6 +206,7 @@ func (r *Reconciler) recordMembershipChanged(protocol *storagev2beta1.MultiRaftP
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Normal", "MembershipChanged", "Membership changed")
 }
@@ -120,6 +221,7 @@ func (r *Reconciler) recordSendSnapshotStarted(protocol *storagev2beta1.MultiRaf
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Normal", "SendSnapshotStared", "Started sending snapshot at index %d to %s", event.Index, event.To)
 }
@@ -134,6 +236,7 @@ func (r *Reconciler) recordSendSnapshotCompleted(protocol *storagev2beta1.MultiR
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Normal", "SendSnapshotCompleted", "Completed sending snapshot at index %d to %s", event.Index, event.To)
 }
@@ -148,6 +251,7 @@ func (r *Reconciler) recordSendSnapshotAborted(protocol *storagev2beta1.MultiRaf
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Warning", "SendSnapshotAborted", "Aborted sending snapshot at index %d to %s", event.Index, event.To)
 }
@@ -162,21 +266,16 @@ func (r *Reconciler) recordSnapshotReceived(protocol *storagev2beta1.MultiRaftPr
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Normal", "SnapshotReceived", "Received snapshot at index %d from %s", event.Index, event.From)
 
-	err = backoff.Retry(func() error {
-		member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
-		if err != nil {
-			log.Error(err)
-		}
-		now := metav1.Now()
-		return r.updateMemberStatus(member, storagev2beta1.RaftMemberStatus{
-			LastSnapshotIndex: &event.Index,
-			LastSnapshotTime:  &now,
-			LastUpdated:       &timestamp,
-		})
-	}, backoff.NewExponentialBackOff())
+	now := metav1.Now()
+	r.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{
+		LastSnapshotIndex: &event.Index,
+		LastSnapshotTime:  &now,
+		LastUpdated:       &timestamp,
+	})
 }
 
 func (r *Reconciler) recordSnapshotRecovered(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.SnapshotRecoveredEvent, timestamp metav1.Time) {
@@ -189,21 +288,16 @@ func (r *Reconciler) recordSnapshotRecovered(protocol *storagev2beta1.MultiRaftP
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Normal", "SnapshotRecovered", "Recovered from snapshot at index %d", event.Index)
 
-	err = backoff.Retry(func() error {
-		member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
-		if err != nil {
-			log.Error(err)
-		}
-		now := metav1.Now()
-		return r.updateMemberStatus(member, storagev2beta1.RaftMemberStatus{
-			LastSnapshotIndex: &event.Index,
-			LastSnapshotTime:  &now,
-			LastUpdated:       &timestamp,
-		})
-	}, backoff.NewExponentialBackOff())
+	now := metav1.Now()
+	r.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{
+		LastSnapshotIndex: &event.Index,
+		LastSnapshotTime:  &now,
+		LastUpdated:       &timestamp,
+	})
 }
 
 func (r *Reconciler) recordSnapshotCreated(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.SnapshotCreatedEvent, timestamp metav1.Time) {
@@ -216,21 +310,16 @@ func (r *Reconciler) recordSnapshotCreated(protocol *storagev2beta1.MultiRaftPro
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Normal", "SnapshotCreated", "Created snapshot at index %d", event.Index)
 
-	err = backoff.Retry(func() error {
-		member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
-		if err != nil {
-			log.Error(err)
-		}
-		now := metav1.Now()
-		return r.updateMemberStatus(member, storagev2beta1.RaftMemberStatus{
-			LastSnapshotIndex: &event.Index,
-			LastSnapshotTime:  &now,
-			LastUpdated:       &timestamp,
-		})
-	}, backoff.NewExponentialBackOff())
+	now := metav1.Now()
+	r.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{
+		LastSnapshotIndex: &event.Index,
+		LastSnapshotTime:  &now,
+		LastUpdated:       &timestamp,
+	})
 }
 
 func (r *Reconciler) recordSnapshotCompacted(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.SnapshotCompactedEvent, timestamp metav1.Time) {
@@ -238,26 +327,21 @@ func (r *Reconciler) recordSnapshotCompacted(protocol *storagev2beta1.MultiRaftP
 	if err != nil {
 		log.Error(err)
 	}
-	r.events.Eventf(pod, "Normal", "PartitionSnapshotCompacted", "Compacted partition %d snapshot at index %d", event.Partition, event.Index)
+	r.events.Eventf(pod, "Normal", "PartitionSnapshotCompacted", "Compacted partition %d snapshot at index %d retaining %d snapshots", event.Partition, event.Index, event.RetainedSnapshots)
 
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
-	r.events.Eventf(member, "Normal", "SnapshotCompacted", "Compacted snapshot at index %d", event.Index)
-
-	err = backoff.Retry(func() error {
-		member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
-		if err != nil {
-			log.Error(err)
-		}
-		now := metav1.Now()
-		return r.updateMemberStatus(member, storagev2beta1.RaftMemberStatus{
-			LastSnapshotIndex: &event.Index,
-			LastSnapshotTime:  &now,
-			LastUpdated:       &timestamp,
-		})
-	}, backoff.NewExponentialBackOff())
+	r.events.Eventf(member, "Normal", "SnapshotCompacted", "Compacted snapshot at index %d retaining %d snapshots", event.Index, event.RetainedSnapshots)
+
+	now := metav1.Now()
+	r.statuses.update(protocol, member, storagev2beta1.RaftMemberStatus{
+		LastSnapshotIndex: &event.Index,
+		LastSnapshotTime:  &now,
+		LastUpdated:       &timestamp,
+	})
 }
 
 func (r *Reconciler) recordLogCompacted(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.LogCompactedEvent, timestamp metav1.Time) {
@@ -265,13 +349,19 @@ func (r *Reconciler) recordLogCompacted(protocol *storagev2beta1.MultiRaftProtoc
 	if err != nil {
 		log.Error(err)
 	}
-	r.events.Eventf(pod, "Normal", "PartitionLogCompacted", "Log in partition %d compacted at index %d", event.Partition, event.Index)
+	// Compactions requested by the compaction schedule or an operation are distinguished from automatic compactions
+	trigger := "automatically"
+	if event.Requested {
+		trigger = "on request"
+	}
+	r.events.Eventf(pod, "Normal", "PartitionLogCompacted", "Log in partition %d compacted %s at index %d retaining %d entries", event.Partition, trigger, event.Index, event.CompactionOverhead)
 
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
-	r.events.Eventf(member, "Normal", "LogCompacted", "Log compacted at index %d", event.Index)
+	r.events.Eventf(member, "Normal", "LogCompacted", "Log compacted %s at index %d retaining %d entries", trigger, event.Index, event.CompactionOverhead)
 }
 
 func (r *Reconciler) recordLogDBCompacted(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.LogDBCompactedEvent, timestamp metav1.Time) {
@@ -284,6 +374,7 @@ func (r *Reconciler) recordLogDBCompacted(protocol *storagev2beta1.MultiRaftProt
 	member, err := r.getMember(protocol, clusterID, int(event.Partition), podID)
 	if err != nil {
 		log.Error(err)
+		return
 	}
 	r.events.Eventf(member, "Normal", "LogDBCompacted", "LogDB compacted at index %d", event.Index)
 }
@@ -0,0 +1,227 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileVolumeExpansion expands the cluster's data volume claims when the storage request in the protocol's
+// volume claim template is raised. StatefulSet claim templates are immutable, so each claim is expanded in place
+// and the StatefulSet is then deleted without its pods to be re-created with the new claim template.
+func (r *Reconciler) reconcileVolumeExpansion(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	// Expansion is deferred while scaling since scaling changes the set of claims
+	if protocol.Spec.VolumeClaimTemplate == nil || cluster.Status.Scale != nil {
+		return nil
+	}
+	size, ok := protocol.Spec.VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	name := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
+	}
+	if err := r.client.Get(context.TODO(), name, statefulSet); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Wait for a StatefulSet deleted by an expansion to be removed before it is re-created
+	if statefulSet.DeletionTimestamp != nil {
+		return nil
+	}
+
+	// The StatefulSet is outdated if its claim template requests less storage than the protocol
+	outdated := false
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		if template.Name != getDataVolumeName(protocol) {
+			continue
+		}
+		current := template.Spec.Resources.Requests[corev1.ResourceStorage]
+		outdated = size.Cmp(current) > 0
+	}
+
+	status := cluster.Status.VolumeExpansion
+	if status == nil || status.Size.Cmp(size) != 0 {
+		if !outdated {
+			if status == nil {
+				return nil
+			}
+			// The request was reverted before the StatefulSet was re-created
+			cluster.Status.VolumeExpansion = nil
+			return r.client.Status().Update(context.TODO(), cluster)
+		}
+		log.Info("Expanding raft volume claims", "Name", protocol.Name, "Namespace", protocol.Namespace, "Cluster", cluster.Spec.ClusterID, "Size", size.String())
+		now := metav1.Now()
+		cluster.Status.VolumeExpansion = &storagev2beta1.RaftVolumeExpansionStatus{
+			Size:      size,
+			Phase:     storagev2beta1.RaftVolumeExpansionResizing,
+			StartTime: &now,
+		}
+		if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+			return err
+		}
+		r.events.Eventf(cluster, "Normal", "VolumeExpansionStarted", "Expanding volume claims to %s", size.String())
+		status = cluster.Status.VolumeExpansion
+	}
+
+	claims, failure, err := r.expandVolumeClaims(protocol, cluster, size)
+	if err != nil {
+		return err
+	}
+
+	// Failed expansions are retried on subsequent reconciles in case the StorageClass is changed
+	if failure != "" {
+		if status.Phase == storagev2beta1.RaftVolumeExpansionFailed && status.Message == failure {
+			return nil
+		}
+		status.Phase = storagev2beta1.RaftVolumeExpansionFailed
+		status.Message = failure
+		status.Claims = claims
+		if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+			return err
+		}
+		r.events.Eventf(cluster, "Warning", "VolumeExpansionFailed", "%s", failure)
+		return nil
+	}
+
+	if outdated {
+		log.Info("Re-creating raft StatefulSet", "Name", statefulSet.Name, "Namespace", statefulSet.Namespace)
+		if err := r.client.Delete(context.TODO(), statefulSet, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		r.events.Eventf(cluster, "Normal", "StatefulSetRecreated", "Re-creating StatefulSet %s with %s volume claims", statefulSet.Name, size.String())
+	}
+
+	// The expansion is complete once every claim's volume has been resized
+	complete := !outdated
+	for _, claim := range claims {
+		if claim.Capacity == nil || claim.Capacity.Cmp(size) < 0 {
+			complete = false
+		}
+	}
+	if complete {
+		cluster.Status.VolumeExpansion = nil
+		if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+			return err
+		}
+		r.events.Eventf(cluster, "Normal", "VolumeExpansionComplete", "Expanded volume claims to %s", size.String())
+		return nil
+	}
+
+	if status.Phase != storagev2beta1.RaftVolumeExpansionResizing || status.Message != "" || !apiequality.Semantic.DeepEqual(status.Claims, claims) {
+		status.Phase = storagev2beta1.RaftVolumeExpansionResizing
+		status.Message = ""
+		status.Claims = claims
+		return r.client.Status().Update(context.TODO(), cluster)
+	}
+	return nil
+}
+
+// expandVolumeClaims raises the storage request of each of the cluster's data volume claims to the given size,
+// returning the progress of each claim or a message describing why the claims can't be expanded
+func (r *Reconciler) expandVolumeClaims(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, size resource.Quantity) ([]storagev2beta1.RaftVolumeClaimExpansionStatus, string, error) {
+	expandable := make(map[string]bool)
+	statuses := make([]storagev2beta1.RaftVolumeClaimExpansionStatus, 0, getNumDeployedReplicas(protocol, cluster))
+	for podID := 0; podID < getNumDeployedReplicas(protocol, cluster); podID++ {
+		claim := &corev1.PersistentVolumeClaim{}
+		name := types.NamespacedName{
+			Namespace: protocol.Namespace,
+			Name:      getVolumeClaimName(protocol, int(cluster.Spec.ClusterID), podID),
+		}
+		if err := r.client.Get(context.TODO(), name, claim); err != nil {
+			// Claims that don't exist yet are created from the new claim template
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, "", err
+		}
+
+		if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName == "" {
+			return statuses, fmt.Sprintf("Volume claim %s has no StorageClass", claim.Name), nil
+		}
+		className := *claim.Spec.StorageClassName
+		if _, ok := expandable[className]; !ok {
+			storageClass := &storagev1.StorageClass{}
+			if err := r.client.Get(context.TODO(), types.NamespacedName{Name: className}, storageClass); err != nil {
+				if k8serrors.IsNotFound(err) {
+					return statuses, fmt.Sprintf("StorageClass %s of volume claim %s not found", className, claim.Name), nil
+				}
+				return nil, "", err
+			}
+			expandable[className] = storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion
+		}
+		if !expandable[className] {
+			return statuses, fmt.Sprintf("StorageClass %s of volume claim %s does not allow volume expansion", className, claim.Name), nil
+		}
+
+		request := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		if size.Cmp(request) > 0 {
+			log.Info("Expanding raft volume claim", "Name", claim.Name, "Namespace", claim.Namespace, "Size", size.String())
+			original := claim.DeepCopy()
+			if claim.Spec.Resources.Requests == nil {
+				claim.Spec.Resources.Requests = make(corev1.ResourceList)
+			}
+			claim.Spec.Resources.Requests[corev1.ResourceStorage] = size
+			if err := r.client.Patch(context.TODO(), claim, client.MergeFrom(original)); err != nil {
+				if k8serrors.IsForbidden(err) || k8serrors.IsInvalid(err) {
+					return statuses, fmt.Sprintf("Volume claim %s could not be expanded: %s", claim.Name, err), nil
+				}
+				return nil, "", err
+			}
+		}
+		statuses = append(statuses, newVolumeClaimExpansionStatus(claim))
+	}
+	return statuses, "", nil
+}
+
+// newVolumeClaimExpansionStatus returns the progress of the expansion of the given claim
+func newVolumeClaimExpansionStatus(claim *corev1.PersistentVolumeClaim) storagev2beta1.RaftVolumeClaimExpansionStatus {
+	status := storagev2beta1.RaftVolumeClaimExpansionStatus{
+		Name: claim.Name,
+	}
+	if capacity, ok := claim.Status.Capacity[corev1.ResourceStorage]; ok {
+		status.Capacity = &capacity
+	}
+	for _, condition := range claim.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case corev1.PersistentVolumeClaimResizing:
+			status.Message = "Volume is being resized"
+		case corev1.PersistentVolumeClaimFileSystemResizePending:
+			status.Message = "File system will be resized when the pod is restarted"
+		}
+	}
+	return status
+}
@@ -0,0 +1,315 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+// newTestStorageClass returns a StorageClass that allows or forbids volume expansion
+func newTestStorageClass(name string, expandable bool) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		AllowVolumeExpansion: &expandable,
+	}
+}
+
+// newTestVolumeClaim returns a data volume claim of the given pod with the given StorageClass and storage request
+func newTestVolumeClaim(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, className string, size string) *corev1.PersistentVolumeClaim {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: protocol.Namespace,
+			Name:      getVolumeClaimName(protocol, clusterID, podID),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(size),
+				},
+			},
+		},
+	}
+	if className != "" {
+		claim.Spec.StorageClassName = &className
+	}
+	return claim
+}
+
+// newTestVolumeClaimTemplate returns a volume claim template requesting the given amount of storage
+func newTestVolumeClaimTemplate(size string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(size),
+				},
+			},
+		},
+	}
+}
+
+func TestExpandVolumeClaims(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 2)
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		failure bool
+		claims  int
+		sizes   []string
+	}{
+		{
+			name: "expand claims",
+			objects: []runtime.Object{
+				newTestStorageClass("standard", true),
+				newTestVolumeClaim(protocol, 1, 0, "standard", "1Gi"),
+				newTestVolumeClaim(protocol, 1, 1, "standard", "1Gi"),
+			},
+			claims: 2,
+			sizes:  []string{"10Gi", "10Gi"},
+		},
+		{
+			name: "claims already expanded",
+			objects: []runtime.Object{
+				newTestStorageClass("standard", true),
+				newTestVolumeClaim(protocol, 1, 0, "standard", "10Gi"),
+				newTestVolumeClaim(protocol, 1, 1, "standard", "20Gi"),
+			},
+			claims: 2,
+			sizes:  []string{"10Gi", "20Gi"},
+		},
+		{
+			name: "claim not yet created",
+			objects: []runtime.Object{
+				newTestStorageClass("standard", true),
+				newTestVolumeClaim(protocol, 1, 0, "standard", "1Gi"),
+			},
+			claims: 1,
+			sizes:  []string{"10Gi"},
+		},
+		{
+			name: "StorageClass forbids expansion",
+			objects: []runtime.Object{
+				newTestStorageClass("standard", false),
+				newTestVolumeClaim(protocol, 1, 0, "standard", "1Gi"),
+				newTestVolumeClaim(protocol, 1, 1, "standard", "1Gi"),
+			},
+			failure: true,
+			sizes:   []string{"1Gi", "1Gi"},
+		},
+		{
+			name: "StorageClass not found",
+			objects: []runtime.Object{
+				newTestVolumeClaim(protocol, 1, 0, "standard", "1Gi"),
+			},
+			failure: true,
+			sizes:   []string{"1Gi"},
+		},
+		{
+			name: "claim without StorageClass",
+			objects: []runtime.Object{
+				newTestVolumeClaim(protocol, 1, 0, "", "1Gi"),
+			},
+			failure: true,
+			sizes:   []string{"1Gi"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newTestReconciler(test.objects...)
+			cluster := newTestCluster(protocol, 1, 2)
+			claims, failure, err := r.expandVolumeClaims(protocol, cluster, resource.MustParse("10Gi"))
+			assert.NoError(t, err)
+			assert.Equal(t, test.failure, failure != "")
+			if !test.failure {
+				assert.Len(t, claims, test.claims)
+			}
+
+			for podID, size := range test.sizes {
+				claim := &corev1.PersistentVolumeClaim{}
+				name := types.NamespacedName{
+					Namespace: protocol.Namespace,
+					Name:      getVolumeClaimName(protocol, 1, podID),
+				}
+				assert.NoError(t, r.client.Get(context.TODO(), name, claim))
+				request := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+				assert.Equal(t, 0, request.Cmp(resource.MustParse(size)), "claim %s requests %s", claim.Name, request.String())
+			}
+		})
+	}
+}
+
+func TestReconcileVolumeExpansion(t *testing.T) {
+	newStatefulSet := func(protocol *storagev2beta1.MultiRaftProtocol, size string) *appsv1.StatefulSet {
+		template := *newTestVolumeClaimTemplate(size)
+		template.Name = getDataVolumeName(protocol)
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: protocol.Namespace,
+				Name:      getClusterName(protocol, 1),
+			},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{template},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		size       string
+		current    string
+		expandable bool
+		phase      storagev2beta1.RaftVolumeExpansionPhase
+		recreated  bool
+	}{
+		{
+			name:       "storage request unchanged",
+			size:       "1Gi",
+			current:    "1Gi",
+			expandable: true,
+		},
+		{
+			name:       "storage request raised",
+			size:       "10Gi",
+			current:    "1Gi",
+			expandable: true,
+			phase:      storagev2beta1.RaftVolumeExpansionResizing,
+			recreated:  true,
+		},
+		{
+			name:    "StorageClass forbids expansion",
+			size:    "10Gi",
+			current: "1Gi",
+			phase:   storagev2beta1.RaftVolumeExpansionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(1, 1, 1)
+			protocol.Spec.VolumeClaimTemplate = newTestVolumeClaimTemplate(test.size)
+			cluster := newTestCluster(protocol, 1, 1)
+			r := newTestReconciler(
+				cluster,
+				newStatefulSet(protocol, test.current),
+				newTestStorageClass("standard", test.expandable),
+				newTestVolumeClaim(protocol, 1, 0, "standard", test.current),
+			)
+
+			assert.NoError(t, r.reconcileVolumeExpansion(protocol, cluster))
+			if test.phase == "" {
+				assert.Nil(t, cluster.Status.VolumeExpansion)
+			} else if assert.NotNil(t, cluster.Status.VolumeExpansion) {
+				assert.Equal(t, test.phase, cluster.Status.VolumeExpansion.Phase)
+				assert.Equal(t, 0, cluster.Status.VolumeExpansion.Size.Cmp(resource.MustParse(test.size)))
+			}
+
+			statefulSet := &appsv1.StatefulSet{}
+			err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: protocol.Namespace, Name: getClusterName(protocol, 1)}, statefulSet)
+			assert.Equal(t, test.recreated, k8serrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestNewVolumeClaimExpansionStatus(t *testing.T) {
+	capacity := resource.MustParse("1Gi")
+
+	tests := []struct {
+		name       string
+		capacity   *resource.Quantity
+		conditions []corev1.PersistentVolumeClaimCondition
+		message    string
+	}{
+		{
+			name: "no capacity",
+		},
+		{
+			name:     "resized",
+			capacity: &capacity,
+		},
+		{
+			name:     "resizing",
+			capacity: &capacity,
+			conditions: []corev1.PersistentVolumeClaimCondition{
+				{
+					Type:   corev1.PersistentVolumeClaimResizing,
+					Status: corev1.ConditionTrue,
+				},
+			},
+			message: "Volume is being resized",
+		},
+		{
+			name:     "file system resize pending",
+			capacity: &capacity,
+			conditions: []corev1.PersistentVolumeClaimCondition{
+				{
+					Type:   corev1.PersistentVolumeClaimFileSystemResizePending,
+					Status: corev1.ConditionTrue,
+				},
+			},
+			message: "File system will be resized when the pod is restarted",
+		},
+		{
+			name:     "resizing condition false",
+			capacity: &capacity,
+			conditions: []corev1.PersistentVolumeClaimCondition{
+				{
+					Type:   corev1.PersistentVolumeClaimResizing,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claim := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "data-raft-1-0",
+				},
+				Status: corev1.PersistentVolumeClaimStatus{
+					Conditions: test.conditions,
+				},
+			}
+			if test.capacity != nil {
+				claim.Status.Capacity = corev1.ResourceList{
+					corev1.ResourceStorage: *test.capacity,
+				}
+			}
+			status := newVolumeClaimExpansionStatus(claim)
+			assert.Equal(t, "data-raft-1-0", status.Name)
+			assert.Equal(t, test.message, status.Message)
+			if test.capacity == nil {
+				assert.Nil(t, status.Capacity)
+			} else if assert.NotNil(t, status.Capacity) {
+				assert.Equal(t, 0, status.Capacity.Cmp(*test.capacity))
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// protocolFinalizer is the finalizer with which the controller cleans up after a MultiRaftProtocol
+const protocolFinalizer = "storage.atomix.io/multi-raft-protocol"
+
+// hasFinalizer returns whether the given object has the given finalizer
+func hasFinalizer(object metav1.Object, finalizer string) bool {
+	for _, f := range object.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// finalizeProtocol stops monitoring the pods of a deleted protocol, discards the member statuses
+// pending for the protocol, applies the volume claim retention policy, and removes the protocol's finalizer
+func (r *Reconciler) finalizeProtocol(protocol *storagev2beta1.MultiRaftProtocol) error {
+	if !hasFinalizer(protocol, protocolFinalizer) {
+		return nil
+	}
+
+	r.stopMonitoringProtocol(protocol)
+	r.statuses.dropProtocol(protocol)
+
+	if err := r.finalizeVolumeClaims(protocol); err != nil {
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(protocol, protocolFinalizer)
+	return r.client.Update(context.TODO(), protocol)
+}
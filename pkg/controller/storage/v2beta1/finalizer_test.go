@@ -0,0 +1,63 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+func TestReconcileFinalizeProtocol(t *testing.T) {
+	now := metav1.Now()
+	protocol := newTestProtocol(1, 1, 3)
+	protocol.Finalizers = []string{protocolFinalizer}
+	protocol.DeletionTimestamp = &now
+	other := newTestProtocol(1, 1, 3)
+	other.Name = "other"
+	other.UID = "other"
+
+	reconciler := newTestReconciler(protocol, other)
+	lag := uint64(10)
+	for _, p := range []*storagev2beta1.MultiRaftProtocol{protocol, other} {
+		for podID := 0; podID < 3; podID++ {
+			member := &storagev2beta1.RaftMember{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      getMemberName(p, 1, 1, podID),
+					Namespace: p.Namespace,
+				},
+			}
+			reconciler.statuses.update(p, member, storagev2beta1.RaftMemberStatus{Lag: &lag})
+		}
+	}
+
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	finalized := &storagev2beta1.MultiRaftProtocol{}
+	getTestObject(t, reconciler, protocol.Name, finalized)
+	assert.False(t, hasFinalizer(finalized, protocolFinalizer))
+
+	pending := make([]types.NamespacedName, 0)
+	for name := range reconciler.statuses.pending {
+		pending = append(pending, name)
+	}
+	assert.ElementsMatch(t, []types.NamespacedName{
+		{Namespace: "test", Name: getMemberName(other, 1, 1, 0)},
+		{Namespace: "test", Name: getMemberName(other, 1, 1, 1)},
+		{Namespace: "test", Name: getMemberName(other, 1, 1, 2)},
+	}, pending)
+}
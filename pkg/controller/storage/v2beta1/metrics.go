@@ -0,0 +1,236 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "atomix_raft"
+
+const (
+	multiRaftProtocolKind = "MultiRaftProtocol"
+	raftClusterKind       = "RaftCluster"
+	raftPartitionKind     = "RaftPartition"
+	raftMemberKind        = "RaftMember"
+	raftOperationKind     = "RaftOperation"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of reconciling Raft resources by kind",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind"})
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of errors reconciling Raft resources by kind",
+	}, []string{"kind"})
+	monitorConnects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "monitor_connects_total",
+		Help:      "Number of monitoring streams opened to Raft pods",
+	}, []string{"namespace", "pod"})
+	monitorFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "monitor_failures_total",
+		Help:      "Number of monitoring streams to Raft pods that failed to open or were interrupted",
+	}, []string{"namespace", "pod"})
+	monitorConnected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "monitor_connected",
+		Help:      "Whether the monitoring stream to a Raft pod is connected",
+	}, []string{"namespace", "pod"})
+	monitorEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "monitor_events_total",
+		Help:      "Number of Raft events received from Raft pods by event type",
+	}, []string{"namespace", "pod", "event"})
+	memberStatusesPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "member_statuses_pending",
+		Help:      "Number of RaftMember statuses reported by Raft events waiting to be written",
+	})
+	leaderTransfers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "leader_transfers_total",
+		Help:      "Number of partition leader transfers made to balance leaders across the replicas of a cluster",
+	}, []string{"namespace", "protocol", "cluster"})
+)
+
+var (
+	partitionLeaderDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "partition", "leader"),
+		"The current leader of a Raft partition",
+		[]string{"namespace", "protocol", "cluster", "partition", "leader"}, nil)
+	partitionTermDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "partition", "term"),
+		"The current term of a Raft partition",
+		[]string{"namespace", "protocol", "cluster", "partition"}, nil)
+	partitionReadyMembersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "partition", "ready_members"),
+		"The number of ready members of a Raft partition",
+		[]string{"namespace", "protocol", "cluster", "partition"}, nil)
+	memberSnapshotAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "member", "snapshot_age_seconds"),
+		"The time since a Raft member last took or installed a snapshot",
+		[]string{"namespace", "protocol", "cluster", "partition", "pod"}, nil)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(reconcileDuration, reconcileErrors, monitorConnects, monitorFailures, monitorConnected, monitorEvents, memberStatusesPending, leaderTransfers)
+}
+
+// addStatusCollector registers a collector of gauges built from the status of Raft resources
+func addStatusCollector(c client.Reader) error {
+	return ctrlmetrics.Registry.Register(&statusCollector{client: c})
+}
+
+// observeReconcile records the duration and result of reconciling a resource of the given kind
+func observeReconcile(kind string, start time.Time, err error) {
+	reconcileDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(kind).Inc()
+	}
+}
+
+// getEventType returns the type name of the given Raft event
+func getEventType(event *storage.RaftEvent) string {
+	return getEventTypeName(event.Event)
+}
+
+// getEventTypeName returns the type name of the given Raft event wrapper
+func getEventTypeName(wrapper interface{}) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", wrapper), "*storage.RaftEvent_")
+}
+
+// getEventTypes returns the type names of all Raft events
+func getEventTypes() []string {
+	wrappers := (*storage.RaftEvent)(nil).XXX_OneofWrappers()
+	eventTypes := make([]string, 0, len(wrappers))
+	for _, wrapper := range wrappers {
+		eventTypes = append(eventTypes, getEventTypeName(wrapper))
+	}
+	return eventTypes
+}
+
+// deleteMonitorMetrics deletes the monitoring stream series of the given pod
+func deleteMonitorMetrics(namespace, pod string) {
+	monitorConnected.DeleteLabelValues(namespace, pod)
+	monitorConnects.DeleteLabelValues(namespace, pod)
+	monitorFailures.DeleteLabelValues(namespace, pod)
+	for _, eventType := range getEventTypes() {
+		monitorEvents.DeleteLabelValues(namespace, pod, eventType)
+	}
+}
+
+// statusCollector collects gauges from the status of RaftPartitions and RaftMembers when metrics are scraped,
+// so series for deleted resources or former leaders are never reported
+type statusCollector struct {
+	client client.Reader
+}
+
+func (c *statusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- partitionLeaderDesc
+	ch <- partitionTermDesc
+	ch <- partitionReadyMembersDesc
+	ch <- memberSnapshotAgeDesc
+}
+
+func (c *statusCollector) Collect(ch chan<- prometheus.Metric) {
+	clusters := &storagev2beta1.RaftClusterList{}
+	if err := c.client.List(context.TODO(), clusters); err != nil {
+		log.Warnf("Failed to list RaftClusters: %s", err)
+		return
+	}
+	partitions := &storagev2beta1.RaftPartitionList{}
+	if err := c.client.List(context.TODO(), partitions); err != nil {
+		log.Warnf("Failed to list RaftPartitions: %s", err)
+		return
+	}
+	members := &storagev2beta1.RaftMemberList{}
+	if err := c.client.List(context.TODO(), members); err != nil {
+		log.Warnf("Failed to list RaftMembers: %s", err)
+		return
+	}
+
+	// Clusters are owned by protocols, partitions by clusters, and members by partitions
+	protocols := make(map[string]string)
+	for _, cluster := range clusters.Items {
+		protocols[getNamespacedName(cluster.Namespace, cluster.Name)] = getControllerName(&cluster)
+	}
+	partitionProtocols := make(map[string]string)
+	for _, partition := range partitions.Items {
+		protocol := protocols[getNamespacedName(partition.Namespace, getControllerName(&partition))]
+		partitionProtocols[getNamespacedName(partition.Namespace, partition.Name)] = protocol
+	}
+
+	readyMembers := make(map[string]int)
+	for _, member := range members.Items {
+		partitionName := getNamespacedName(member.Namespace, getControllerName(&member))
+		if member.Status.State != nil && *member.Status.State == storagev2beta1.RaftMemberReady {
+			readyMembers[partitionName]++
+		}
+		if member.Status.LastSnapshotTime != nil {
+			ch <- prometheus.MustNewConstMetric(memberSnapshotAgeDesc, prometheus.GaugeValue,
+				time.Since(member.Status.LastSnapshotTime.Time).Seconds(),
+				member.Namespace, partitionProtocols[partitionName], fmt.Sprint(member.Spec.ClusterID),
+				fmt.Sprint(member.Spec.PartitionID), member.Spec.Pod)
+		}
+	}
+
+	for _, partition := range partitions.Items {
+		name := getNamespacedName(partition.Namespace, partition.Name)
+		protocol := partitionProtocols[name]
+		clusterID := fmt.Sprint(partition.Spec.ClusterID)
+		partitionID := fmt.Sprint(partition.Spec.PartitionID)
+		if partition.Status.Leader != nil {
+			ch <- prometheus.MustNewConstMetric(partitionLeaderDesc, prometheus.GaugeValue, 1,
+				partition.Namespace, protocol, clusterID, partitionID, *partition.Status.Leader)
+		}
+		if partition.Status.Term != nil {
+			ch <- prometheus.MustNewConstMetric(partitionTermDesc, prometheus.GaugeValue, float64(*partition.Status.Term),
+				partition.Namespace, protocol, clusterID, partitionID)
+		}
+		ch <- prometheus.MustNewConstMetric(partitionReadyMembersDesc, prometheus.GaugeValue, float64(readyMembers[name]),
+			partition.Namespace, protocol, clusterID, partitionID)
+	}
+}
+
+// getControllerName returns the name of the controller owning the given object
+func getControllerName(object metav1.Object) string {
+	owner := metav1.GetControllerOf(object)
+	if owner == nil {
+		return ""
+	}
+	return owner.Name
+}
+
+// getNamespacedName returns a key for the given namespace and name
+func getNamespacedName(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
@@ -0,0 +1,143 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strings"
+	"testing"
+)
+
+// getTestReconcileCount returns the number of reconciles of the given kind observed by the reconcile duration histogram
+func getTestReconcileCount(t *testing.T, kind string) uint64 {
+	families, err := ctrlmetrics.Registry.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "atomix_raft_reconcile_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "kind" && label.GetValue() == kind {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestReconcileMetrics(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newTestReconciler(protocol)
+
+	count := getTestReconcileCount(t, multiRaftProtocolKind)
+	errors := testutil.ToFloat64(reconcileErrors.WithLabelValues(multiRaftProtocolKind))
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Equal(t, count+1, getTestReconcileCount(t, multiRaftProtocolKind))
+	assert.Equal(t, errors, testutil.ToFloat64(reconcileErrors.WithLabelValues(multiRaftProtocolKind)))
+
+	// A client that cannot read protocols fails the reconcile
+	reconciler.client = fake.NewFakeClientWithScheme(runtime.NewScheme())
+	_, err := reconciler.Reconcile(reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: protocol.Namespace,
+			Name:      protocol.Name,
+		},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, count+2, getTestReconcileCount(t, multiRaftProtocolKind))
+	assert.Equal(t, errors+1, testutil.ToFloat64(reconcileErrors.WithLabelValues(multiRaftProtocolKind)))
+}
+
+func TestReconcileStatusMetrics(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	setTestPods(t, reconciler, protocol, 1, []bool{true, true, false})
+	setTestLeader(t, reconciler, protocol, 1, 1, 0)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	collector := &statusCollector{client: reconciler.client}
+	expected := `
+# HELP atomix_raft_partition_leader The current leader of a Raft partition
+# TYPE atomix_raft_partition_leader gauge
+atomix_raft_partition_leader{cluster="1",leader="raft-1-0",namespace="test",partition="1",protocol="raft"} 1
+# HELP atomix_raft_partition_ready_members The number of ready members of a Raft partition
+# TYPE atomix_raft_partition_ready_members gauge
+atomix_raft_partition_ready_members{cluster="1",namespace="test",partition="1",protocol="raft"} 2
+# HELP atomix_raft_partition_term The current term of a Raft partition
+# TYPE atomix_raft_partition_term gauge
+atomix_raft_partition_term{cluster="1",namespace="test",partition="1",protocol="raft"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+
+	// Series for the former leader are no longer reported once leadership changes
+	setTestPods(t, reconciler, protocol, 1, []bool{true, true, true})
+	setTestLeader(t, reconciler, protocol, 1, 1, 1)
+	reconcileTestProtocol(t, reconciler, protocol)
+	expected = `
+# HELP atomix_raft_partition_leader The current leader of a Raft partition
+# TYPE atomix_raft_partition_leader gauge
+atomix_raft_partition_leader{cluster="1",leader="raft-1-1",namespace="test",partition="1",protocol="raft"} 1
+# HELP atomix_raft_partition_ready_members The number of ready members of a Raft partition
+# TYPE atomix_raft_partition_ready_members gauge
+atomix_raft_partition_ready_members{cluster="1",namespace="test",partition="1",protocol="raft"} 3
+# HELP atomix_raft_partition_term The current term of a Raft partition
+# TYPE atomix_raft_partition_term gauge
+atomix_raft_partition_term{cluster="1",namespace="test",partition="1",protocol="raft"} 2
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
+
+// hasTestPodMetrics returns whether any series is reported for the given pod
+func hasTestPodMetrics(t *testing.T, pod string) bool {
+	families, err := ctrlmetrics.Registry.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "pod" && label.GetValue() == pod {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func TestStopMonitoringMetrics(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newTestReconciler(protocol)
+	pod := getPodName(protocol, 1, 0)
+	monitorConnects.WithLabelValues(protocol.Namespace, pod).Inc()
+	monitorFailures.WithLabelValues(protocol.Namespace, pod).Inc()
+	for _, eventType := range getEventTypes() {
+		monitorEvents.WithLabelValues(protocol.Namespace, pod, eventType).Inc()
+	}
+	assert.True(t, hasTestPodMetrics(t, pod))
+
+	// Reconciling starts monitoring the protocol's pods and stopping the monitors deletes their series
+	reconcileTestProtocol(t, reconciler, protocol)
+	for podID := 0; podID < 3; podID++ {
+		assert.False(t, hasTestPodMetrics(t, getPodName(protocol, 1, podID)))
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// migratePartitionAnnotationPrefix is the prefix of MultiRaftProtocol annotations requesting a partition
+// be migrated to another cluster, e.g. storage.atomix.io/migrate-partition-3: "2"
+const migratePartitionAnnotationPrefix = "storage.atomix.io/migrate-partition-"
+
+// isMigrating returns whether a partition is being migrated to or from the given cluster
+func isMigrating(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) bool {
+	migration := protocol.Status.Migration
+	return migration != nil &&
+		(migration.SourceClusterID == cluster.Spec.ClusterID || migration.TargetClusterID == cluster.Spec.ClusterID)
+}
+
+// isMigrationTarget returns whether the given partition is being migrated to the given cluster
+func isMigrationTarget(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, partitionID int) bool {
+	migration := protocol.Status.Migration
+	return migration != nil &&
+		migration.TargetClusterID == cluster.Spec.ClusterID &&
+		int(migration.PartitionID) == partitionID
+}
+
+// getServingClusterID returns the cluster to which clients of the given partition are directed
+func getServingClusterID(protocol *storagev2beta1.MultiRaftProtocol, partitionID int) int {
+	migration := protocol.Status.Migration
+	if migration != nil && int(migration.PartitionID) == partitionID &&
+		migration.Phase == storagev2beta1.PartitionMigrationRemovingMembers {
+		return int(migration.TargetClusterID)
+	}
+	for _, partition := range protocol.Status.Placement {
+		if int(partition.PartitionID) == partitionID {
+			return int(partition.ClusterID)
+		}
+	}
+	return 0
+}
+
+// getRequestedMigrations returns the target clusters requested for partitions by the protocol's annotations
+func getRequestedMigrations(protocol *storagev2beta1.MultiRaftProtocol) map[int]int {
+	migrations := make(map[int]int)
+	for key, value := range protocol.Annotations {
+		if !strings.HasPrefix(key, migratePartitionAnnotationPrefix) {
+			continue
+		}
+		partitionID, err := strconv.Atoi(strings.TrimPrefix(key, migratePartitionAnnotationPrefix))
+		if err != nil {
+			log.Info("Ignoring invalid migration annotation", "Name", protocol.Name, "Namespace", protocol.Namespace, "Annotation", key)
+			continue
+		}
+		clusterID, err := strconv.Atoi(value)
+		if err != nil || clusterID < 1 || clusterID > getNumClusters(protocol) {
+			log.Info("Ignoring invalid migration annotation", "Name", protocol.Name, "Namespace", protocol.Namespace, "Annotation", key)
+			continue
+		}
+		migrations[partitionID] = clusterID
+	}
+	return migrations
+}
+
+// reconcileMigration moves a single partition at a time to the cluster requested by the protocol's annotations.
+// Members are added to the target cluster as learners, promoted once they've caught up, and leadership is
+// transferred to the target cluster before the members in the source cluster are removed.
+func (r *Reconciler) reconcileMigration(protocol *storagev2beta1.MultiRaftProtocol) error {
+	if protocol.Status.Migration == nil {
+		return r.startMigration(protocol)
+	}
+
+	migration := protocol.Status.Migration
+	source, err := r.getCluster(protocol, int(migration.SourceClusterID))
+	if err != nil {
+		return err
+	}
+	target, err := r.getCluster(protocol, int(migration.TargetClusterID))
+	if err != nil {
+		return err
+	}
+
+	switch migration.Phase {
+	case storagev2beta1.PartitionMigrationAddingMembers:
+		return r.reconcileMigrationAddingMembers(protocol, source, target)
+	case storagev2beta1.PartitionMigrationCatchingUp:
+		return r.reconcileMigrationCatchingUp(protocol, source, target)
+	case storagev2beta1.PartitionMigrationTransferringLeadership:
+		return r.reconcileMigrationTransferringLeadership(protocol, source, target)
+	case storagev2beta1.PartitionMigrationRemovingMembers:
+		return r.reconcileMigrationRemovingMembers(protocol, source, target)
+	}
+	return nil
+}
+
+func (r *Reconciler) startMigration(protocol *storagev2beta1.MultiRaftProtocol) error {
+	migrations := getRequestedMigrations(protocol)
+	partitionIDs := make([]int, 0, len(migrations))
+	for partitionID := range migrations {
+		partitionIDs = append(partitionIDs, partitionID)
+	}
+	sort.Ints(partitionIDs)
+
+	for _, partitionID := range partitionIDs {
+		sourceID := getServingClusterID(protocol, partitionID)
+		targetID := migrations[partitionID]
+		if sourceID == 0 || sourceID == targetID {
+			continue
+		}
+
+		source, err := r.getCluster(protocol, sourceID)
+		if err != nil {
+			return err
+		}
+		target, err := r.getCluster(protocol, targetID)
+		if err != nil {
+			return err
+		}
+
+		// Migrations are only started when both clusters are stable and available
+		if source.Status.Scale != nil || source.Status.Upgrade != nil || target.Status.Scale != nil || target.Status.Upgrade != nil {
+			return nil
+		}
+		for _, cluster := range []*storagev2beta1.RaftCluster{source, target} {
+			for replicaID := 0; replicaID < int(cluster.Status.Replicas); replicaID++ {
+				ready, err := r.isReplicaReady(protocol, int(cluster.Spec.ClusterID), replicaID)
+				if err != nil {
+					return err
+				} else if !ready {
+					return nil
+				}
+			}
+		}
+
+		// Node IDs in the target cluster must not conflict with any node previously added to the partition
+		// or any node subsequently added to the target cluster
+		baseNodeID := target.Status.LastNodeID
+		for _, replicaID := range getMembers(source) {
+			member, err := r.getMember(protocol, sourceID, partitionID, replicaID)
+			if err != nil {
+				return err
+			}
+			if nodeID := getMemberNodeID(member); nodeID > baseNodeID {
+				baseNodeID = nodeID
+			}
+		}
+		target.Status.LastNodeID = baseNodeID + target.Status.Replicas
+		if err := r.client.Status().Update(context.TODO(), target); err != nil {
+			return err
+		}
+
+		log.Info("Migrating partition", "Name", protocol.Name, "Namespace", protocol.Namespace, "Partition", partitionID, "Source", sourceID, "Target", targetID)
+		now := metav1.Now()
+		protocol.Status.Migration = &storagev2beta1.PartitionMigrationStatus{
+			PartitionID:     int32(partitionID),
+			SourceClusterID: int32(sourceID),
+			TargetClusterID: int32(targetID),
+			BaseNodeID:      baseNodeID,
+			Phase:           storagev2beta1.PartitionMigrationAddingMembers,
+			StartTime:       &now,
+		}
+		if err := r.client.Status().Update(context.TODO(), protocol); err != nil {
+			return err
+		}
+		r.events.Eventf(protocol, "Normal", "MigratingPartition", "Migrating partition %d from cluster %d to cluster %d", partitionID, sourceID, targetID)
+		return nil
+	}
+	return nil
+}
+
+// reconcileMigrationAddingMembers adds the target cluster's replicas to the partition as learners
+func (r *Reconciler) reconcileMigrationAddingMembers(protocol *storagev2beta1.MultiRaftProtocol, source, target *storagev2beta1.RaftCluster) error {
+	migration := protocol.Status.Migration
+	partitionID := int(migration.PartitionID)
+	for _, replicaID := range getMembers(target) {
+		member, err := r.getMember(protocol, int(target.Spec.ClusterID), partitionID, replicaID)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if member.Status.Type != nil {
+			continue
+		}
+
+		nodeID := uint64(getMemberNodeID(member))
+		request := &storage.AddMemberRequest{
+			Partition: uint64(partitionID),
+			NodeID:    nodeID,
+			Address:   fmt.Sprintf("%s:%d", getPodDNSName(protocol, int(target.Spec.ClusterID), replicaID), protocolPort),
+			Learner:   true,
+		}
+		if err := r.addRaftMember(protocol, source, partitionID, -1, request); err != nil {
+			log.Error(err, "Adding learner", "Name", member.Name, "Namespace", member.Namespace)
+			return nil
+		}
+
+		// The new member recovers the partition from a snapshot sent by the leader followed by the remaining log
+		err = invokeReplicaAdmin(protocol, int(target.Spec.ClusterID), replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			_, err := client.StartMember(ctx, &storage.StartMemberRequest{
+				Partition: uint64(partitionID),
+				NodeID:    nodeID,
+				Join:      true,
+				Learner:   true,
+			})
+			return err
+		})
+		if err != nil {
+			log.Error(err, "Starting learner", "Name", member.Name, "Namespace", member.Namespace)
+			return nil
+		}
+
+		memberType := storagev2beta1.RaftLearner
+		if err := r.patchMemberStatus(member, storagev2beta1.RaftMemberStatus{Type: &memberType}, getMaxMemberLag(protocol)); err != nil {
+			return err
+		}
+		r.events.Eventf(member, "Normal", "LearnerAdded", "Added member as learner with node ID %d", nodeID)
+	}
+
+	migration.Phase = storagev2beta1.PartitionMigrationCatchingUp
+	return r.client.Status().Update(context.TODO(), protocol)
+}
+
+// reconcileMigrationCatchingUp promotes the target cluster's replicas to voting members once they've caught up
+func (r *Reconciler) reconcileMigrationCatchingUp(protocol *storagev2beta1.MultiRaftProtocol, source, target *storagev2beta1.RaftCluster) error {
+	migration := protocol.Status.Migration
+	partitionID := int(migration.PartitionID)
+	for _, replicaID := range getMembers(target) {
+		member, err := r.getMember(protocol, int(target.Spec.ClusterID), partitionID, replicaID)
+		if err != nil {
+			return err
+		}
+		if member.Status.Type != nil && *member.Status.Type == storagev2beta1.RaftVoter {
+			continue
+		}
+
+		if err := r.syncRaftMember(protocol, target, partitionID, replicaID); err != nil {
+			log.Info("Waiting for learner to catch up", "Name", member.Name, "Namespace", member.Namespace, "Error", err.Error())
+			return nil
+		}
+
+		request := &storage.AddMemberRequest{
+			Partition: uint64(partitionID),
+			NodeID:    uint64(getMemberNodeID(member)),
+			Address:   fmt.Sprintf("%s:%d", getPodDNSName(protocol, int(target.Spec.ClusterID), replicaID), protocolPort),
+		}
+		if err := r.addRaftMember(protocol, source, partitionID, -1, request); err != nil {
+			log.Error(err, "Promoting learner", "Name", member.Name, "Namespace", member.Namespace)
+			return nil
+		}
+
+		memberType := storagev2beta1.RaftVoter
+		if err := r.patchMemberStatus(member, storagev2beta1.RaftMemberStatus{Type: &memberType}, getMaxMemberLag(protocol)); err != nil {
+			return err
+		}
+		r.events.Eventf(member, "Normal", "LearnerPromoted", "Promoted learner to voting member")
+	}
+
+	migration.Phase = storagev2beta1.PartitionMigrationTransferringLeadership
+	return r.client.Status().Update(context.TODO(), protocol)
+}
+
+// reconcileMigrationTransferringLeadership moves leadership of the partition to the target cluster
+func (r *Reconciler) reconcileMigrationTransferringLeadership(protocol *storagev2beta1.MultiRaftProtocol, source, target *storagev2beta1.RaftCluster) error {
+	migration := protocol.Status.Migration
+	partitionID := int(migration.PartitionID)
+	member, err := r.getMember(protocol, int(target.Spec.ClusterID), partitionID, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, replicaID := range getMembers(source) {
+		err := invokeReplicaAdmin(protocol, int(source.Spec.ClusterID), replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			_, err := client.TransferLeadership(ctx, &storage.TransferLeadershipRequest{
+				Partition: uint64(partitionID),
+				NodeID:    uint64(getMemberNodeID(member)),
+			})
+			return err
+		})
+		if err != nil {
+			log.Error(err, "Transferring leadership", "Name", protocol.Name, "Namespace", protocol.Namespace, "Partition", partitionID)
+			return nil
+		}
+	}
+
+	migration.Phase = storagev2beta1.PartitionMigrationRemovingMembers
+	if err := r.client.Status().Update(context.TODO(), protocol); err != nil {
+		return err
+	}
+	r.events.Eventf(protocol, "Normal", "PartitionLeaderMigrated", "Transferred leadership of partition %d to cluster %d", partitionID, target.Spec.ClusterID)
+	return nil
+}
+
+// reconcileMigrationRemovingMembers removes the source cluster's replicas from the partition and completes the migration
+func (r *Reconciler) reconcileMigrationRemovingMembers(protocol *storagev2beta1.MultiRaftProtocol, source, target *storagev2beta1.RaftCluster) error {
+	migration := protocol.Status.Migration
+	partitionID := int(migration.PartitionID)
+	for replicaID := 0; replicaID < int(source.Status.Replicas); replicaID++ {
+		member, err := r.getMember(protocol, int(source.Spec.ClusterID), partitionID, replicaID)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		request := &storage.RemoveMemberRequest{
+			Partition: uint64(partitionID),
+			NodeID:    uint64(getMemberNodeID(member)),
+		}
+		if err := r.removeRaftMember(protocol, target, partitionID, -1, request); err != nil {
+			log.Error(err, "Removing member", "Name", member.Name, "Namespace", member.Namespace)
+			return nil
+		}
+
+		err = invokeReplicaAdmin(protocol, int(source.Spec.ClusterID), replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			_, err := client.StopMember(ctx, &storage.StopMemberRequest{
+				Partition: uint64(partitionID),
+			})
+			return err
+		})
+		if err != nil {
+			log.Error(err, "Stopping member", "Name", member.Name, "Namespace", member.Namespace)
+			return nil
+		}
+
+		if err := r.client.Delete(context.TODO(), member); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	partition, err := r.getPartition(protocol, int(source.Spec.ClusterID), partitionID)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	} else if err == nil {
+		if err := r.client.Delete(context.TODO(), partition); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	for i, placement := range protocol.Status.Placement {
+		if int(placement.PartitionID) == partitionID {
+			protocol.Status.Placement[i].ClusterID = target.Spec.ClusterID
+		}
+	}
+	protocol.Status.Migration = nil
+	if err := r.client.Status().Update(context.TODO(), protocol); err != nil {
+		return err
+	}
+	r.events.Eventf(protocol, "Normal", "PartitionMigrated", "Migrated partition %d from cluster %d to cluster %d", partitionID, source.Spec.ClusterID, target.Spec.ClusterID)
+	return nil
+}
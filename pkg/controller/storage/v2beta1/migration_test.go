@@ -0,0 +1,262 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+func TestGetRequestedMigrations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    map[int]int
+	}{
+		{
+			name:     "no annotations",
+			expected: map[int]int{},
+		},
+		{
+			name: "migrations",
+			annotations: map[string]string{
+				migratePartitionAnnotationPrefix + "1": "2",
+				migratePartitionAnnotationPrefix + "3": "1",
+				"example.com/annotation":               "1",
+			},
+			expected: map[int]int{1: 2, 3: 1},
+		},
+		{
+			name: "invalid partition",
+			annotations: map[string]string{
+				migratePartitionAnnotationPrefix + "one": "2",
+				migratePartitionAnnotationPrefix:         "2",
+			},
+			expected: map[int]int{},
+		},
+		{
+			name: "invalid cluster",
+			annotations: map[string]string{
+				migratePartitionAnnotationPrefix + "1": "two",
+				migratePartitionAnnotationPrefix + "2": "0",
+				migratePartitionAnnotationPrefix + "3": "3",
+			},
+			expected: map[int]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(2, 4, 3)
+			protocol.Annotations = test.annotations
+			assert.Equal(t, test.expected, getRequestedMigrations(protocol))
+		})
+	}
+}
+
+// newMigrationTestReconciler returns a reconciler for a reconciled protocol with two clusters of ready pods
+// and two partitions, with partition 2 placed on cluster 1
+func newMigrationTestReconciler(t *testing.T, protocol *storagev2beta1.MultiRaftProtocol) *Reconciler {
+	reconciler := newTestReconciler(protocol)
+	for clusterID := 1; clusterID <= 2; clusterID++ {
+		for podID := 0; podID < 3; podID++ {
+			assert.NoError(t, reconciler.client.Create(context.TODO(), newTestPod(protocol, clusterID, podID, true)))
+		}
+	}
+	reconcileTestProtocol(t, reconciler, protocol)
+	return reconciler
+}
+
+// requestTestMigration annotates the protocol to migrate the given partition to the given cluster
+func requestTestMigration(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, partitionID string, clusterID string) {
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	protocol.Annotations = map[string]string{
+		migratePartitionAnnotationPrefix + partitionID: clusterID,
+	}
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+}
+
+// getTestMigration returns the protocol's migration status after reconciling it
+func getTestMigration(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol) *storagev2beta1.PartitionMigrationStatus {
+	reconcileTestProtocol(t, reconciler, protocol)
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	return protocol.Status.Migration
+}
+
+// getTestMemberType returns the type of the given member, or nil if the member doesn't exist
+func getTestMemberType(t *testing.T, reconciler *Reconciler, name string) *storagev2beta1.RaftMemberType {
+	member := &storagev2beta1.RaftMember{}
+	err := reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: name}, member)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	assert.NoError(t, err)
+	return member.Status.Type
+}
+
+func TestReconcileMigration(t *testing.T) {
+	admin := newTestAdmin()
+	defer admin.close()
+
+	protocol := newTestProtocol(2, 2, 3)
+	reconciler := newMigrationTestReconciler(t, protocol)
+	assert.Equal(t, 1, getServingClusterID(protocol, 2))
+	requestTestMigration(t, reconciler, protocol, "2", "2")
+	getTestEvents(reconciler)
+
+	// Node IDs of the target members follow all nodes previously added to the partition
+	migration := getTestMigration(t, reconciler, protocol)
+	assert.NotNil(t, migration)
+	assert.Equal(t, storagev2beta1.PartitionMigrationAddingMembers, migration.Phase)
+	assert.Equal(t, int32(2), migration.PartitionID)
+	assert.Equal(t, int32(1), migration.SourceClusterID)
+	assert.Equal(t, int32(2), migration.TargetClusterID)
+	assert.Equal(t, int32(3), migration.BaseNodeID)
+	assert.Contains(t, getTestEvents(reconciler), "Normal MigratingPartition Migrating partition 2 from cluster 1 to cluster 2")
+	assert.True(t, isMigrationTarget(protocol, newTestCluster(protocol, 2, 3), 2))
+
+	// Target members are added as learners through the source cluster and started on the target pods
+	migration = getTestMigration(t, reconciler, protocol)
+	assert.Equal(t, storagev2beta1.PartitionMigrationCatchingUp, migration.Phase)
+	adds := admin.getCalls("AddMember")
+	assert.Len(t, adds, 3)
+	for i, add := range adds {
+		request := add.request.(*storage.AddMemberRequest)
+		assert.True(t, request.Learner)
+		assert.Equal(t, uint64(2), request.Partition)
+		assert.Equal(t, uint64(4+i), request.NodeID)
+		assert.Contains(t, add.pod, "raft-1-")
+	}
+	starts := admin.getCalls("StartMember")
+	assert.Len(t, starts, 3)
+	for i, start := range starts {
+		assert.Equal(t, getPodName(protocol, 2, i), start.pod)
+		assert.True(t, start.request.(*storage.StartMemberRequest).Join)
+	}
+	for memberID := 0; memberID < 3; memberID++ {
+		assert.Equal(t, storagev2beta1.RaftLearner, *getTestMemberType(t, reconciler, getMemberName(protocol, 2, 2, memberID)))
+	}
+	admin.reset()
+
+	// Learners are promoted once they've caught up
+	migration = getTestMigration(t, reconciler, protocol)
+	assert.Equal(t, storagev2beta1.PartitionMigrationTransferringLeadership, migration.Phase)
+	assert.Len(t, admin.getCalls("SyncMember"), 3)
+	for _, add := range admin.getCalls("AddMember") {
+		assert.False(t, add.request.(*storage.AddMemberRequest).Learner)
+	}
+	for memberID := 0; memberID < 3; memberID++ {
+		assert.Equal(t, storagev2beta1.RaftVoter, *getTestMemberType(t, reconciler, getMemberName(protocol, 2, 2, memberID)))
+	}
+	admin.reset()
+
+	// Leadership is transferred to the target cluster before clients are directed to it
+	assert.Equal(t, 1, getServingClusterID(protocol, 2))
+	migration = getTestMigration(t, reconciler, protocol)
+	assert.Equal(t, storagev2beta1.PartitionMigrationRemovingMembers, migration.Phase)
+	transfers := admin.getCalls("TransferLeadership")
+	assert.Len(t, transfers, 3)
+	for _, transfer := range transfers {
+		assert.Equal(t, uint64(4), transfer.request.(*storage.TransferLeadershipRequest).NodeID)
+	}
+	assert.Equal(t, 2, getServingClusterID(protocol, 2))
+	admin.reset()
+
+	// Source members are removed and stopped and the partition is placed on the target cluster
+	migration = getTestMigration(t, reconciler, protocol)
+	assert.Nil(t, migration)
+	assert.Len(t, admin.getCalls("RemoveMember"), 3)
+	assert.Len(t, admin.getCalls("StopMember"), 3)
+	for memberID := 0; memberID < 3; memberID++ {
+		assert.Nil(t, getTestMemberType(t, reconciler, getMemberName(protocol, 1, 2, memberID)))
+	}
+	_, err := reconciler.getPartition(protocol, 1, 2)
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Equal(t, 2, getServingClusterID(protocol, 2))
+	assert.Equal(t, []int{1, 2}, getPartitions(protocol, 2))
+	assert.Empty(t, getPartitions(protocol, 1))
+	assert.Contains(t, getTestEvents(reconciler), "Normal PartitionMigrated Migrated partition 2 from cluster 1 to cluster 2")
+	admin.reset()
+
+	// The completed migration is not restarted
+	assert.Nil(t, getTestMigration(t, reconciler, protocol))
+	assert.Empty(t, admin.getCalls("AddMember"))
+}
+
+func TestReconcileMigrationRetriesFailedStop(t *testing.T) {
+	admin := newTestAdmin()
+	defer admin.close()
+
+	protocol := newTestProtocol(2, 2, 3)
+	reconciler := newMigrationTestReconciler(t, protocol)
+	requestTestMigration(t, reconciler, protocol, "2", "2")
+	for i := 0; i < 4; i++ {
+		reconcileTestProtocol(t, reconciler, protocol)
+	}
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	assert.Equal(t, storagev2beta1.PartitionMigrationRemovingMembers, protocol.Status.Migration.Phase)
+
+	// A member that can't be stopped is retained so its removal is retried
+	admin.fail(getPodName(protocol, 1, 1), "StopMember")
+	migration := getTestMigration(t, reconciler, protocol)
+	assert.NotNil(t, migration)
+	assert.Equal(t, storagev2beta1.PartitionMigrationRemovingMembers, migration.Phase)
+	assert.Nil(t, getTestMemberType(t, reconciler, getMemberName(protocol, 1, 2, 0)))
+	assert.NotNil(t, getTestMemberType(t, reconciler, getMemberName(protocol, 1, 2, 1)))
+	assert.NotNil(t, getTestMemberType(t, reconciler, getMemberName(protocol, 1, 2, 2)))
+	_, err := reconciler.getPartition(protocol, 1, 2)
+	assert.NoError(t, err)
+
+	admin.allow(getPodName(protocol, 1, 1), "StopMember")
+	admin.reset()
+	assert.Nil(t, getTestMigration(t, reconciler, protocol))
+	stops := admin.getCalls("StopMember")
+	assert.Len(t, stops, 2)
+	assert.Equal(t, getPodName(protocol, 1, 1), stops[0].pod)
+	assert.Nil(t, getTestMemberType(t, reconciler, getMemberName(protocol, 1, 2, 1)))
+}
+
+func TestReconcileMigrationWaitsForReadyReplicas(t *testing.T) {
+	admin := newTestAdmin()
+	defer admin.close()
+
+	protocol := newTestProtocol(2, 2, 3)
+	reconciler := newMigrationTestReconciler(t, protocol)
+	pod, err := reconciler.getPod(protocol, 2, 1)
+	assert.NoError(t, err)
+	pod.Status.Conditions[0].Status = corev1.ConditionFalse
+	assert.NoError(t, reconciler.client.Status().Update(context.TODO(), pod))
+
+	requestTestMigration(t, reconciler, protocol, "2", "2")
+	assert.Nil(t, getTestMigration(t, reconciler, protocol))
+}
+
+func TestReconcileMigrationIgnoresInvalidAnnotations(t *testing.T) {
+	admin := newTestAdmin()
+	defer admin.close()
+
+	protocol := newTestProtocol(2, 2, 3)
+	reconciler := newMigrationTestReconciler(t, protocol)
+	requestTestMigration(t, reconciler, protocol, "2", "3")
+	assert.Nil(t, getTestMigration(t, reconciler, protocol))
+	requestTestMigration(t, reconciler, protocol, "2", "1")
+	assert.Nil(t, getTestMigration(t, reconciler, protocol))
+}
@@ -0,0 +1,363 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"github.com/cenkalti/backoff"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	monitorInitialBackoff = 500 * time.Millisecond
+	monitorMaxBackoff     = 30 * time.Second
+	monitorConnectTimeout = 10 * time.Second
+	monitorStopTimeout    = 30 * time.Second
+	monitorEventsBuffer   = 1024
+)
+
+// newMonitorManager returns a new manager of the monitoring streams of the given reconciler
+func newMonitorManager(reconciler *Reconciler) *monitorManager {
+	return &monitorManager{
+		reconciler: reconciler,
+		monitors:   make(map[string]*podMonitor),
+		events:     make(chan event.GenericEvent, monitorEventsBuffer),
+	}
+}
+
+// monitorManager maintains a stream of Raft events from each Raft pod. Streams are opened asynchronously
+// and reconnected with jittered backoff, and changes to their state enqueue the protocol for reconciliation.
+type monitorManager struct {
+	reconciler *Reconciler
+	monitors   map[string]*podMonitor
+	events     chan event.GenericEvent
+	mu         sync.RWMutex
+}
+
+// start starts monitoring the given pod if it's not already being monitored
+func (m *monitorManager) start(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, podID int) {
+	name := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getPodName(protocol, int(cluster.Spec.ClusterID), podID),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.monitors[name.String()]; ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	monitor := &podMonitor{
+		manager: m,
+		protocol: types.NamespacedName{
+			Namespace: protocol.Namespace,
+			Name:      protocol.Name,
+		},
+		protocolUID: protocol.UID,
+		clusterID:   int(cluster.Spec.ClusterID),
+		podID:       podID,
+		pod:         name,
+		address:     fmt.Sprintf("%s:%d", getPodDNSName(protocol, int(cluster.Spec.ClusterID), podID), monitoringPort),
+		state:       storagev2beta1.RaftPodMonitorConnecting,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		wake:        make(chan struct{}, 1),
+	}
+	m.monitors[name.String()] = monitor
+	monitorConnected.WithLabelValues(name.Namespace, name.Name).Set(0)
+	go monitor.run(ctx)
+}
+
+// stop stops monitoring the given pod and waits for the monitor to exit
+func (m *monitorManager) stop(name types.NamespacedName) {
+	m.mu.Lock()
+	monitor, ok := m.monitors[name.String()]
+	delete(m.monitors, name.String())
+	m.mu.Unlock()
+	if ok {
+		monitor.stop()
+	}
+}
+
+// stopProtocol stops monitoring all pods of the given protocol and waits for the monitors to exit
+func (m *monitorManager) stopProtocol(protocol *storagev2beta1.MultiRaftProtocol) {
+	m.mu.Lock()
+	monitors := make([]*podMonitor, 0)
+	for key, monitor := range m.monitors {
+		if monitor.protocolUID == protocol.UID {
+			monitors = append(monitors, monitor)
+			delete(m.monitors, key)
+		}
+	}
+	m.mu.Unlock()
+	for _, monitor := range monitors {
+		monitor.stop()
+	}
+}
+
+// notify wakes a disconnected monitor for the given pod to reconnect without waiting for its backoff
+func (m *monitorManager) notify(object metav1.Object) {
+	name := types.NamespacedName{
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
+	}
+	m.mu.RLock()
+	monitor, ok := m.monitors[name.String()]
+	m.mu.RUnlock()
+	if ok && monitor.getStatus().State != storagev2beta1.RaftPodMonitorConnected {
+		select {
+		case monitor.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// getStatus returns the status of the monitors of the given cluster's pods
+func (m *monitorManager) getStatus(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) []storagev2beta1.RaftPodMonitorStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	statuses := make([]storagev2beta1.RaftPodMonitorStatus, 0)
+	for _, monitor := range m.monitors {
+		if monitor.protocolUID == protocol.UID && monitor.clusterID == int(cluster.Spec.ClusterID) {
+			statuses = append(statuses, monitor.getStatus())
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Pod < statuses[j].Pod
+	})
+	return statuses
+}
+
+// enqueue enqueues the given protocol for reconciliation without blocking
+func (m *monitorManager) enqueue(name types.NamespacedName) {
+	protocol := &storagev2beta1.MultiRaftProtocol{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+	}
+	select {
+	case m.events <- event.GenericEvent{Meta: protocol, Object: protocol}:
+	default:
+		log.Warnf("Dropped reconcile request for %s", name)
+	}
+}
+
+// podMonitor is a stream of Raft events from a pod
+type podMonitor struct {
+	manager     *monitorManager
+	protocol    types.NamespacedName
+	protocolUID types.UID
+	clusterID   int
+	podID       int
+	pod         types.NamespacedName
+	address     string
+	cancel      context.CancelFunc
+	done        chan struct{}
+	wake        chan struct{}
+
+	state           storagev2beta1.RaftPodMonitorState
+	lastConnectTime *metav1.Time
+	lastError       string
+	mu              sync.RWMutex
+}
+
+// run connects to the pod until the monitor is stopped, backing off between attempts
+func (m *podMonitor) run(ctx context.Context) {
+	defer close(m.done)
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = monitorInitialBackoff
+	b.MaxInterval = monitorMaxBackoff
+	b.MaxElapsedTime = 0
+	for {
+		connected, err := m.connect(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			b.Reset()
+		}
+		m.setState(storagev2beta1.RaftPodMonitorDisconnected, err)
+
+		select {
+		case <-time.After(b.NextBackOff()):
+		case <-m.wake:
+		case <-ctx.Done():
+			return
+		}
+		m.setState(storagev2beta1.RaftPodMonitorConnecting, nil)
+	}
+}
+
+// connect opens a stream of events from the pod and records events until the stream is closed,
+// returning whether the stream was opened
+func (m *podMonitor) connect(ctx context.Context) (bool, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, monitorConnectTimeout)
+	conn, err := grpc.DialContext(dialCtx, m.address, grpc.WithInsecure(), grpc.WithBlock())
+	cancel()
+	if err != nil {
+		monitorFailures.WithLabelValues(m.pod.Namespace, m.pod.Name).Inc()
+		return false, err
+	}
+	defer conn.Close()
+
+	protocol := &storagev2beta1.MultiRaftProtocol{}
+	if err := m.manager.reconciler.client.Get(ctx, m.protocol, protocol); err != nil {
+		return false, err
+	}
+
+	stream, err := storage.NewRaftEventsClient(conn).Subscribe(ctx, &storage.SubscribeRequest{})
+	if err != nil {
+		monitorFailures.WithLabelValues(m.pod.Namespace, m.pod.Name).Inc()
+		return false, err
+	}
+	monitorConnects.WithLabelValues(m.pod.Namespace, m.pod.Name).Inc()
+	m.setState(storagev2beta1.RaftPodMonitorConnected, nil)
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				monitorFailures.WithLabelValues(m.pod.Namespace, m.pod.Name).Inc()
+			}
+			return true, err
+		}
+		log.Debugf("Received event %+v from %s", event, m.pod.Name)
+		monitorEvents.WithLabelValues(m.pod.Namespace, m.pod.Name, getEventType(event)).Inc()
+		m.manager.reconciler.recordEvent(protocol, m.clusterID, m.podID, event)
+	}
+}
+
+// setState updates the state of the monitor, enqueueing the protocol for reconciliation if the state changed
+func (m *podMonitor) setState(state storagev2beta1.RaftPodMonitorState, err error) {
+	m.mu.Lock()
+	changed := m.state != state
+	m.state = state
+	if state == storagev2beta1.RaftPodMonitorConnected {
+		// Truncate the time to the precision with which it's stored in the cluster status
+		now := metav1.NewTime(time.Now().Truncate(time.Second))
+		m.lastConnectTime = &now
+	}
+	if err != nil {
+		m.lastError = err.Error()
+	}
+	m.mu.Unlock()
+
+	if state == storagev2beta1.RaftPodMonitorConnected {
+		monitorConnected.WithLabelValues(m.pod.Namespace, m.pod.Name).Set(1)
+	} else {
+		monitorConnected.WithLabelValues(m.pod.Namespace, m.pod.Name).Set(0)
+	}
+	if changed {
+		m.manager.enqueue(m.protocol)
+	}
+}
+
+// getStatus returns the status of the monitor
+func (m *podMonitor) getStatus() storagev2beta1.RaftPodMonitorStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return storagev2beta1.RaftPodMonitorStatus{
+		Pod:             m.pod.Name,
+		State:           m.state,
+		LastConnectTime: m.lastConnectTime,
+		LastError:       m.lastError,
+	}
+}
+
+// stop cancels the monitor and waits for status writes in progress to complete before deleting its series
+func (m *podMonitor) stop() {
+	m.cancel()
+	select {
+	case <-m.done:
+	case <-time.After(monitorStopTimeout):
+		log.Info("Timed out waiting for monitor to stop")
+	}
+	deleteMonitorMetrics(m.pod.Namespace, m.pod.Name)
+}
+
+// startMonitoringCluster starts monitoring the pods of the given cluster
+func (r *Reconciler) startMonitoringCluster(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) {
+	for podID := range getReplicas(protocol, cluster) {
+		r.monitors.start(protocol, cluster, podID)
+	}
+}
+
+// stopMonitoringPod stops the monitoring stream for the given pod and waits for it to exit
+func (r *Reconciler) stopMonitoringPod(protocol *storagev2beta1.MultiRaftProtocol, podName string) {
+	r.monitors.stop(types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      podName,
+	})
+}
+
+// stopMonitoringProtocol stops the monitoring streams for all pods of the given protocol and waits for them to exit
+func (r *Reconciler) stopMonitoringProtocol(protocol *storagev2beta1.MultiRaftProtocol) {
+	r.monitors.stopProtocol(protocol)
+}
+
+// recordEvent records the given Raft event received from a pod
+func (r *Reconciler) recordEvent(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, event *storage.RaftEvent) {
+	timestamp := metav1.NewTime(event.Timestamp)
+	switch e := event.Event.(type) {
+	case *storage.RaftEvent_MemberReady:
+		r.recordPartitionReady(protocol, clusterID, podID, e.MemberReady, timestamp)
+	case *storage.RaftEvent_LeaderUpdated:
+		r.recordLeaderUpdated(protocol, clusterID, podID, e.LeaderUpdated, timestamp)
+	case *storage.RaftEvent_MembershipChanged:
+		r.recordMembershipChanged(protocol, clusterID, podID, e.MembershipChanged, timestamp)
+	case *storage.RaftEvent_SendSnapshotStarted:
+		r.recordSendSnapshotStarted(protocol, clusterID, podID, e.SendSnapshotStarted, timestamp)
+	case *storage.RaftEvent_SendSnapshotCompleted:
+		r.recordSendSnapshotCompleted(protocol, clusterID, podID, e.SendSnapshotCompleted, timestamp)
+	case *storage.RaftEvent_SendSnapshotAborted:
+		r.recordSendSnapshotAborted(protocol, clusterID, podID, e.SendSnapshotAborted, timestamp)
+	case *storage.RaftEvent_SnapshotReceived:
+		r.recordSnapshotReceived(protocol, clusterID, podID, e.SnapshotReceived, timestamp)
+	case *storage.RaftEvent_SnapshotRecovered:
+		r.recordSnapshotRecovered(protocol, clusterID, podID, e.SnapshotRecovered, timestamp)
+	case *storage.RaftEvent_SnapshotCreated:
+		r.recordSnapshotCreated(protocol, clusterID, podID, e.SnapshotCreated, timestamp)
+	case *storage.RaftEvent_SnapshotCompacted:
+		r.recordSnapshotCompacted(protocol, clusterID, podID, e.SnapshotCompacted, timestamp)
+	case *storage.RaftEvent_LogCompacted:
+		r.recordLogCompacted(protocol, clusterID, podID, e.LogCompacted, timestamp)
+	case *storage.RaftEvent_LogdbCompacted:
+		r.recordLogDBCompacted(protocol, clusterID, podID, e.LogdbCompacted, timestamp)
+	case *storage.RaftEvent_ConnectionEstablished:
+		r.recordConnectionEstablished(protocol, clusterID, podID, e.ConnectionEstablished, timestamp)
+	case *storage.RaftEvent_ConnectionFailed:
+		r.recordConnectionFailed(protocol, clusterID, podID, e.ConnectionFailed, timestamp)
+	case *storage.RaftEvent_MemberStats:
+		r.recordMemberStats(protocol, clusterID, podID, e.MemberStats, timestamp)
+	case *storage.RaftEvent_DiskUsage:
+		r.recordDiskUsage(protocol, clusterID, podID, e.DiskUsage, timestamp)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"os"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	controllerNameEnv      = "CONTROLLER_NAME"
+	controllerNamespaceEnv = "CONTROLLER_NAMESPACE"
+	controllerNameLabel    = "name"
+	namespaceNameLabel     = "kubernetes.io/metadata.name"
+)
+
+// reconcileNetworkPolicy maintains a NetworkPolicy restricting the traffic accepted by the cluster's pods
+// when the protocol enables network policies, and removes it when they're disabled
+func (r *Reconciler) reconcileNetworkPolicy(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	policy := &networkingv1.NetworkPolicy{}
+	name := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
+	}
+	err := r.client.Get(context.TODO(), name, policy)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if protocol.Spec.NetworkPolicy == nil {
+		if exists && metav1.IsControlledBy(policy, protocol) {
+			log.Info("Deleting raft NetworkPolicy", "Name", policy.Name, "Namespace", policy.Namespace)
+			if err := r.client.Delete(context.TODO(), policy); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	spec := newNetworkPolicySpec(protocol, cluster)
+	if !exists {
+		return r.addNetworkPolicy(protocol, cluster, spec)
+	}
+
+	if !apiequality.Semantic.DeepEqual(policy.Spec, spec) {
+		log.Info("Updating raft NetworkPolicy", "Name", policy.Name, "Namespace", policy.Namespace)
+		policy.Spec = spec
+		return r.client.Update(context.TODO(), policy)
+	}
+	return nil
+}
+
+func (r *Reconciler) addNetworkPolicy(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, spec networkingv1.NetworkPolicySpec) error {
+	log.Info("Creating raft NetworkPolicy", "Name", protocol.Name, "Namespace", protocol.Namespace, "Cluster", cluster.Spec.ClusterID)
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
+			Namespace: protocol.Namespace,
+			Labels:    newClusterLabels(protocol, int(cluster.Spec.ClusterID)),
+		},
+		Spec: spec,
+	}
+	if err := controllerutil.SetControllerReference(protocol, policy, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(context.TODO(), policy)
+}
+
+// newNetworkPolicySpec returns a NetworkPolicy spec accepting Raft traffic from the pods of the cluster,
+// monitoring traffic from the controller, and API traffic from the protocol's configured clients
+func newNetworkPolicySpec(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) networkingv1.NetworkPolicySpec {
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: newNetworkPolicyPorts(protocolPort),
+			From:  getRaftPeers(protocol, cluster),
+		},
+		{
+			Ports: newNetworkPolicyPorts(monitoringPort),
+			From:  getControllerPeers(),
+		},
+	}
+	if len(protocol.Spec.NetworkPolicy.Clients) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			Ports: newNetworkPolicyPorts(apiPort),
+			From:  protocol.Spec.NetworkPolicy.Clients,
+		})
+	}
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: newClusterLabels(protocol, int(cluster.Spec.ClusterID)),
+		},
+		Ingress:     rules,
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}
+}
+
+// getRaftPeers returns the peers allowed to send Raft traffic to the cluster. Members of both clusters
+// exchange Raft traffic while a partition is migrated between them.
+func getRaftPeers(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) []networkingv1.NetworkPolicyPeer {
+	clusterIDs := []int{int(cluster.Spec.ClusterID)}
+	if isMigrating(protocol, cluster) {
+		migration := protocol.Status.Migration
+		if migration.SourceClusterID == cluster.Spec.ClusterID {
+			clusterIDs = append(clusterIDs, int(migration.TargetClusterID))
+		} else {
+			clusterIDs = append(clusterIDs, int(migration.SourceClusterID))
+		}
+	}
+
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(clusterIDs))
+	for _, clusterID := range clusterIDs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: newClusterLabels(protocol, clusterID),
+			},
+		})
+	}
+	return peers
+}
+
+// getControllerPeers returns the peers allowed to access the monitoring port. Traffic from any source
+// is allowed if the controller's name and namespace are not known.
+func getControllerPeers() []networkingv1.NetworkPolicyPeer {
+	name := os.Getenv(controllerNameEnv)
+	namespace := os.Getenv(controllerNamespaceEnv)
+	if name == "" || namespace == "" {
+		return nil
+	}
+	return []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					namespaceNameLabel: namespace,
+				},
+			},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					controllerNameLabel: name,
+				},
+			},
+		},
+	}
+}
+
+func newNetworkPolicyPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		protocol := corev1.ProtocolTCP
+		value := intstr.FromInt(port)
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &value,
+		})
+	}
+	return policyPorts
+}
@@ -0,0 +1,204 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"testing"
+)
+
+// getTestNetworkPolicy returns the NetworkPolicy of the given cluster, or nil if it doesn't exist
+func getTestNetworkPolicy(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, clusterID int) *networkingv1.NetworkPolicy {
+	policy := &networkingv1.NetworkPolicy{}
+	name := types.NamespacedName{Namespace: protocol.Namespace, Name: getClusterName(protocol, clusterID)}
+	err := reconciler.client.Get(context.TODO(), name, policy)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	assert.NoError(t, err)
+	return policy
+}
+
+// getTestIngressRule returns the ingress rule of the given policy for the given port, or nil if no rule accepts the port
+func getTestIngressRule(policy *networkingv1.NetworkPolicy, port int) *networkingv1.NetworkPolicyIngressRule {
+	for _, rule := range policy.Spec.Ingress {
+		for _, rulePort := range rule.Ports {
+			if rulePort.Port.IntValue() == port {
+				return &rule
+			}
+		}
+	}
+	return nil
+}
+
+// getTestPeerClusters returns the IDs of the clusters whose pods are selected by the given peers
+func getTestPeerClusters(protocol *storagev2beta1.MultiRaftProtocol, peers []networkingv1.NetworkPolicyPeer) []int {
+	clusterIDs := make([]int, 0)
+	for _, peer := range peers {
+		for clusterID := 1; clusterID <= int(protocol.Spec.Clusters); clusterID++ {
+			if peer.PodSelector != nil && metav1.FormatLabelSelector(peer.PodSelector) == metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: newClusterLabels(protocol, clusterID)}) {
+				clusterIDs = append(clusterIDs, clusterID)
+			}
+		}
+	}
+	return clusterIDs
+}
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	clients := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "client"},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		clients    []networkingv1.NetworkPolicyPeer
+		controller bool
+	}{
+		{
+			name: "no clients",
+		},
+		{
+			name:    "clients",
+			clients: clients,
+		},
+		{
+			name:       "known controller",
+			clients:    clients,
+			controller: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.controller {
+				assert.NoError(t, os.Setenv(controllerNameEnv, "atomix-raft-storage-controller"))
+				assert.NoError(t, os.Setenv(controllerNamespaceEnv, "kube-system"))
+				defer os.Unsetenv(controllerNameEnv)
+				defer os.Unsetenv(controllerNamespaceEnv)
+			}
+
+			protocol := newTestProtocol(2, 2, 3)
+			protocol.Spec.NetworkPolicy = &storagev2beta1.RaftNetworkPolicy{
+				Clients: test.clients,
+			}
+			reconciler := newTestReconciler(protocol)
+			reconcileTestProtocol(t, reconciler, protocol)
+
+			for clusterID := 1; clusterID <= 2; clusterID++ {
+				policy := getTestNetworkPolicy(t, reconciler, protocol, clusterID)
+				assert.NotNil(t, policy)
+				assert.True(t, metav1.IsControlledBy(policy, protocol))
+				assert.Equal(t, newClusterLabels(protocol, clusterID), policy.Spec.PodSelector.MatchLabels)
+				assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+
+				// Raft traffic is only accepted from the pods of the same cluster
+				raft := getTestIngressRule(policy, protocolPort)
+				assert.NotNil(t, raft)
+				assert.Equal(t, []int{clusterID}, getTestPeerClusters(protocol, raft.From))
+
+				// Monitoring traffic is accepted from anywhere unless the controller is known
+				monitoring := getTestIngressRule(policy, monitoringPort)
+				assert.NotNil(t, monitoring)
+				if test.controller {
+					assert.Len(t, monitoring.From, 1)
+					assert.Equal(t, "kube-system", monitoring.From[0].NamespaceSelector.MatchLabels[namespaceNameLabel])
+					assert.Equal(t, "atomix-raft-storage-controller", monitoring.From[0].PodSelector.MatchLabels[controllerNameLabel])
+				} else {
+					assert.Empty(t, monitoring.From)
+				}
+
+				// The API port accepts no traffic without clients
+				api := getTestIngressRule(policy, apiPort)
+				if len(test.clients) == 0 {
+					assert.Nil(t, api)
+				} else {
+					assert.NotNil(t, api)
+					assert.Equal(t, test.clients, api.From)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileNetworkPolicyChange(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Nil(t, getTestNetworkPolicy(t, reconciler, protocol, 1))
+
+	// Enabling network policies creates a policy for each cluster
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	protocol.Spec.NetworkPolicy = &storagev2beta1.RaftNetworkPolicy{}
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+	policy := getTestNetworkPolicy(t, reconciler, protocol, 1)
+	assert.NotNil(t, policy)
+	assert.Nil(t, getTestIngressRule(policy, apiPort))
+
+	// Changes to the allowed clients are applied to the existing policy
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	protocol.Spec.NetworkPolicy.Clients = []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{namespaceNameLabel: "clients"},
+			},
+		},
+	}
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+	policy = getTestNetworkPolicy(t, reconciler, protocol, 1)
+	api := getTestIngressRule(policy, apiPort)
+	assert.NotNil(t, api)
+	assert.Equal(t, protocol.Spec.NetworkPolicy.Clients, api.From)
+
+	// Disabling network policies deletes the policies created for the protocol
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	protocol.Spec.NetworkPolicy = nil
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Nil(t, getTestNetworkPolicy(t, reconciler, protocol, 1))
+}
+
+func TestReconcileNetworkPolicyMigration(t *testing.T) {
+	admin := newTestAdmin()
+	defer admin.close()
+
+	protocol := newTestProtocol(2, 2, 3)
+	protocol.Spec.NetworkPolicy = &storagev2beta1.RaftNetworkPolicy{}
+	reconciler := newMigrationTestReconciler(t, protocol)
+	for clusterID := 1; clusterID <= 2; clusterID++ {
+		raft := getTestIngressRule(getTestNetworkPolicy(t, reconciler, protocol, clusterID), protocolPort)
+		assert.Equal(t, []int{clusterID}, getTestPeerClusters(protocol, raft.From))
+	}
+
+	// Members of both clusters exchange Raft traffic while a partition is migrated between them
+	requestTestMigration(t, reconciler, protocol, "2", "2")
+	assert.NotNil(t, getTestMigration(t, reconciler, protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+	for clusterID := 1; clusterID <= 2; clusterID++ {
+		raft := getTestIngressRule(getTestNetworkPolicy(t, reconciler, protocol, clusterID), protocolPort)
+		assert.ElementsMatch(t, []int{1, 2}, getTestPeerClusters(protocol, raft.From))
+	}
+}
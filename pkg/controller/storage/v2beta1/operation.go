@@ -0,0 +1,485 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func addRaftOperationController(mgr manager.Manager) error {
+	reconciler := &OperationReconciler{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		events: mgr.GetEventRecorderFor("atomix-raft-storage"),
+	}
+
+	// Create a new controller
+	controller, err := controller.New("raft-operation-controller", mgr, controller.Options{Reconciler: reconciler})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to operations
+	err = controller.Watch(&source.Kind{Type: &storagev2beta1.RaftOperation{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = &OperationReconciler{}
+
+// OperationReconciler runs RaftOperations against the nodes of a MultiRaftProtocol
+type OperationReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	events record.EventRecorder
+}
+
+// operationTarget is a partition or member against which an operation is run
+type operationTarget struct {
+	clusterID   int
+	partitionID int
+	// replicaID is the targeted member of the partition, or -1 if the operation targets the partition
+	replicaID int
+}
+
+// Reconcile runs a RaftOperation that has not yet completed
+func (r *OperationReconciler) Reconcile(request reconcile.Request) (result reconcile.Result, err error) {
+	log.Info("Reconcile RaftOperation")
+	defer func(start time.Time) {
+		observeReconcile(raftOperationKind, start, err)
+	}(time.Now())
+
+	operation := &storagev2beta1.RaftOperation{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, operation)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		log.Error(err, "Reconcile RaftOperation")
+		return reconcile.Result{}, err
+	}
+
+	// Operations are run once and never retried after completing
+	if operation.Status.Phase == storagev2beta1.RaftOperationSucceeded || operation.Status.Phase == storagev2beta1.RaftOperationFailed {
+		return reconcile.Result{}, nil
+	}
+
+	protocol := &storagev2beta1.MultiRaftProtocol{}
+	protocolName := types.NamespacedName{
+		Namespace: operation.Namespace,
+		Name:      operation.Spec.Protocol,
+	}
+	err = r.client.Get(context.TODO(), protocolName, protocol)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.setOperationPending(operation, fmt.Sprintf("MultiRaftProtocol %s not found", operation.Spec.Protocol))
+			return reconcile.Result{RequeueAfter: updateRequeueInterval}, err
+		}
+		return reconcile.Result{}, err
+	}
+
+	if err := validateOperation(protocol, operation); err != nil {
+		return reconcile.Result{}, r.completeOperation(operation, storagev2beta1.RaftOperationFailed, err.Error())
+	}
+
+	targets, err := r.getOperationTargets(protocol, operation)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.setOperationPending(operation, "Waiting for the targeted resources to be created")
+			return reconcile.Result{RequeueAfter: updateRequeueInterval}, err
+		}
+		return reconcile.Result{}, err
+	}
+
+	if operation.Status.Phase != storagev2beta1.RaftOperationRunning {
+		original := operation.DeepCopy()
+		now := metav1.Now()
+		operation.Status.Phase = storagev2beta1.RaftOperationRunning
+		operation.Status.Message = fmt.Sprintf("Running %s against %d targets", operation.Spec.Type, len(targets))
+		operation.Status.StartTime = &now
+		if err := r.client.Status().Patch(context.TODO(), operation, client.MergeFrom(original)); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.events.Eventf(operation, "Normal", "Started", "Running %s against %d targets", operation.Spec.Type, len(targets))
+	}
+
+	// Results are recorded as each target completes, so an interrupted operation resumes with the remaining targets
+	var previous *operationTarget
+	var previousResult *storagev2beta1.RaftOperationResult
+	for i, target := range targets {
+		pod := ""
+		if target.replicaID >= 0 {
+			pod = getPodName(protocol, target.clusterID, target.replicaID)
+		}
+		if result := getOperationResult(operation, target.partitionID, pod); result != nil {
+			previous, previousResult = &targets[i], result
+			continue
+		}
+
+		// Restarting members of a partition concurrently or before they catch up can cost the partition its quorum
+		if operation.Spec.Type == storagev2beta1.RaftRestartMemberOperation {
+			restartable, err := r.isRestartable(protocol, target, previous, previousResult)
+			if err != nil {
+				return reconcile.Result{}, err
+			} else if !restartable {
+				message := fmt.Sprintf("Waiting for partition %d to catch up before restarting %s", target.partitionID, pod)
+				if operation.Status.Message != message {
+					original := operation.DeepCopy()
+					operation.Status.Message = message
+					if err := r.client.Status().Patch(context.TODO(), operation, client.MergeFrom(original)); err != nil {
+						return reconcile.Result{}, err
+					}
+				}
+				return reconcile.Result{RequeueAfter: updateRequeueInterval}, nil
+			}
+		}
+
+		log.Infof("Running %s against partition %d %s", operation.Spec.Type, target.partitionID, pod)
+		message, err := r.runOperation(protocol, operation, target)
+		now := metav1.Now()
+		result := storagev2beta1.RaftOperationResult{
+			Partition:      int32(target.partitionID),
+			Pod:            pod,
+			Succeeded:      err == nil,
+			Message:        message,
+			CompletionTime: &now,
+		}
+		if err != nil {
+			log.Warnf("Failed to run %s against partition %d %s: %s", operation.Spec.Type, target.partitionID, pod, err)
+			result.Message = err.Error()
+		}
+
+		original := operation.DeepCopy()
+		operation.Status.Results = append(operation.Status.Results, result)
+		if err := r.client.Status().Patch(context.TODO(), operation, client.MergeFrom(original)); err != nil {
+			return reconcile.Result{}, err
+		}
+		previous, previousResult = &targets[i], &operation.Status.Results[len(operation.Status.Results)-1]
+	}
+
+	failed := 0
+	for _, result := range operation.Status.Results {
+		if !result.Succeeded {
+			failed++
+		}
+	}
+	if failed > 0 {
+		message := fmt.Sprintf("%d of %d targets failed", failed, len(operation.Status.Results))
+		return reconcile.Result{}, r.completeOperation(operation, storagev2beta1.RaftOperationFailed, message)
+	}
+	message := fmt.Sprintf("%s succeeded against %d targets", operation.Spec.Type, len(operation.Status.Results))
+	return reconcile.Result{}, r.completeOperation(operation, storagev2beta1.RaftOperationSucceeded, message)
+}
+
+// setOperationPending sets the operation's phase to Pending with the given message
+func (r *OperationReconciler) setOperationPending(operation *storagev2beta1.RaftOperation, message string) error {
+	if operation.Status.Phase == storagev2beta1.RaftOperationPending && operation.Status.Message == message {
+		return nil
+	}
+	original := operation.DeepCopy()
+	operation.Status.Phase = storagev2beta1.RaftOperationPending
+	operation.Status.Message = message
+	return r.client.Status().Patch(context.TODO(), operation, client.MergeFrom(original))
+}
+
+// completeOperation sets the operation's terminal phase
+func (r *OperationReconciler) completeOperation(operation *storagev2beta1.RaftOperation, phase storagev2beta1.RaftOperationPhase, message string) error {
+	original := operation.DeepCopy()
+	now := metav1.Now()
+	operation.Status.Phase = phase
+	operation.Status.Message = message
+	operation.Status.CompletionTime = &now
+	if err := r.client.Status().Patch(context.TODO(), operation, client.MergeFrom(original)); err != nil {
+		return err
+	}
+	if phase == storagev2beta1.RaftOperationSucceeded {
+		r.events.Event(operation, "Normal", string(phase), message)
+	} else {
+		r.events.Event(operation, "Warning", string(phase), message)
+	}
+	return nil
+}
+
+// getOperationResult returns the result of the operation against the given target, or nil if it has not been run
+func getOperationResult(operation *storagev2beta1.RaftOperation, partitionID int, pod string) *storagev2beta1.RaftOperationResult {
+	for i, result := range operation.Status.Results {
+		if int(result.Partition) == partitionID && result.Pod == pod {
+			return &operation.Status.Results[i]
+		}
+	}
+	return nil
+}
+
+// isRestartable returns whether the given member can be restarted. The member's partition must be ready, so no
+// other member of the partition is down, and the previously restarted member must have reported its status since
+// it was restarted and caught up with its leader.
+func (r *OperationReconciler) isRestartable(protocol *storagev2beta1.MultiRaftProtocol, target operationTarget, previous *operationTarget, result *storagev2beta1.RaftOperationResult) (bool, error) {
+	partition := &storagev2beta1.RaftPartition{}
+	partitionName := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getPartitionName(protocol, target.clusterID, target.partitionID),
+	}
+	if err := r.client.Get(context.TODO(), partitionName, partition); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !isConditionTrue(partition.Status.Conditions, storagev2beta1.ConditionReady) {
+		return false, nil
+	}
+
+	if previous == nil || result == nil || !result.Succeeded {
+		return true, nil
+	}
+	member := &storagev2beta1.RaftMember{}
+	memberName := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getMemberName(protocol, previous.clusterID, previous.partitionID, previous.replicaID),
+	}
+	if err := r.client.Get(context.TODO(), memberName, member); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if result.CompletionTime != nil && (member.Status.LastUpdated == nil || !member.Status.LastUpdated.After(result.CompletionTime.Time)) {
+		return false, nil
+	}
+	return isConditionTrue(member.Status.Conditions, storagev2beta1.ConditionReady) &&
+		member.Status.Lag != nil && !isConditionTrue(member.Status.Conditions, storagev2beta1.RaftMemberLagging), nil
+}
+
+// validateOperation validates the targets and parameters of the given operation
+func validateOperation(protocol *storagev2beta1.MultiRaftProtocol, operation *storagev2beta1.RaftOperation) error {
+	spec := operation.Spec
+	switch spec.Type {
+	case storagev2beta1.RaftSnapshotOperation, storagev2beta1.RaftCompactLogOperation,
+		storagev2beta1.RaftTransferLeadershipOperation, storagev2beta1.RaftRestartMemberOperation:
+	default:
+		return fmt.Errorf("unsupported operation type %q", spec.Type)
+	}
+
+	if spec.Cluster != nil {
+		found := false
+		for _, clusterID := range getClusters(protocol) {
+			if clusterID == int(*spec.Cluster) {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("cluster %d not found", *spec.Cluster)
+		}
+	}
+	if spec.Partition != nil {
+		if *spec.Partition < 1 || int(*spec.Partition) > getNumPartitions(protocol) {
+			return fmt.Errorf("partition %d not found", *spec.Partition)
+		}
+		if spec.Cluster != nil && getPartitionCluster(protocol, int(*spec.Partition)) != int(*spec.Cluster) {
+			return fmt.Errorf("partition %d is not placed on cluster %d", *spec.Partition, *spec.Cluster)
+		}
+	}
+	if spec.Member != nil {
+		if spec.Cluster == nil {
+			return fmt.Errorf("member %d requires a cluster", *spec.Member)
+		}
+		if *spec.Member < 0 {
+			return fmt.Errorf("member %d not found", *spec.Member)
+		}
+	}
+	if spec.Snapshot != nil {
+		if spec.Type != storagev2beta1.RaftSnapshotOperation {
+			return fmt.Errorf("snapshot parameters are not supported by %s operations", spec.Type)
+		}
+		if spec.Snapshot.CompactionOverhead < 0 {
+			return fmt.Errorf("snapshot compaction overhead must be positive")
+		}
+	}
+	return nil
+}
+
+// getPartitionCluster returns the cluster on which the given partition is placed, or 0 if it's unplaced
+func getPartitionCluster(protocol *storagev2beta1.MultiRaftProtocol, partitionID int) int {
+	for _, clusterID := range getClusters(protocol) {
+		for _, id := range getPartitions(protocol, clusterID) {
+			if id == partitionID {
+				return clusterID
+			}
+		}
+	}
+	return 0
+}
+
+// getOperationTargets returns the partitions or members against which the given operation is run
+func (r *OperationReconciler) getOperationTargets(protocol *storagev2beta1.MultiRaftProtocol, operation *storagev2beta1.RaftOperation) ([]operationTarget, error) {
+	spec := operation.Spec
+	var targets []operationTarget
+	for _, clusterID := range getClusters(protocol) {
+		if spec.Cluster != nil && int(*spec.Cluster) != clusterID {
+			continue
+		}
+
+		cluster := &storagev2beta1.RaftCluster{}
+		clusterName := types.NamespacedName{
+			Namespace: protocol.Namespace,
+			Name:      getClusterName(protocol, clusterID),
+		}
+		if err := r.client.Get(context.TODO(), clusterName, cluster); err != nil {
+			return nil, err
+		}
+		if spec.Member != nil && *spec.Member >= cluster.Status.Replicas {
+			return nil, k8serrors.NewNotFound(storagev2beta1.SchemeGroupVersion.WithResource("raftmembers").GroupResource(), fmt.Sprint(*spec.Member))
+		}
+
+		for _, partitionID := range getPartitions(protocol, clusterID) {
+			if spec.Partition != nil && int(*spec.Partition) != partitionID {
+				continue
+			}
+
+			// Leadership is transferred once per partition rather than once per member
+			if spec.Type == storagev2beta1.RaftTransferLeadershipOperation {
+				replicaID := -1
+				if spec.Member != nil {
+					replicaID = int(*spec.Member)
+				}
+				targets = append(targets, operationTarget{clusterID: clusterID, partitionID: partitionID, replicaID: replicaID})
+				continue
+			}
+
+			for replicaID := 0; replicaID < int(cluster.Status.Replicas); replicaID++ {
+				if spec.Member != nil && int(*spec.Member) != replicaID {
+					continue
+				}
+				targets = append(targets, operationTarget{clusterID: clusterID, partitionID: partitionID, replicaID: replicaID})
+			}
+		}
+	}
+	return targets, nil
+}
+
+// runOperation runs the given operation against a single target, returning a message describing the result
+func (r *OperationReconciler) runOperation(protocol *storagev2beta1.MultiRaftProtocol, operation *storagev2beta1.RaftOperation, target operationTarget) (string, error) {
+	partition := uint64(target.partitionID)
+	switch operation.Spec.Type {
+	case storagev2beta1.RaftSnapshotOperation:
+		request := &storage.SnapshotMemberRequest{
+			Partition: partition,
+		}
+		if operation.Spec.Snapshot != nil {
+			request.CompactionOverhead = uint64(operation.Spec.Snapshot.CompactionOverhead)
+		}
+		var index uint64
+		err := invokeReplicaAdmin(protocol, target.clusterID, target.replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			response, err := client.SnapshotMember(ctx, request)
+			if err != nil {
+				return err
+			}
+			index = response.Index
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Snapshot taken at index %d", index), nil
+	case storagev2beta1.RaftCompactLogOperation:
+		err := invokeReplicaAdmin(protocol, target.clusterID, target.replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			_, err := client.CompactMember(ctx, &storage.CompactMemberRequest{
+				Partition: partition,
+			})
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		return "Log compacted", nil
+	case storagev2beta1.RaftRestartMemberOperation:
+		err := invokeReplicaAdmin(protocol, target.clusterID, target.replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			_, err := client.RestartMember(ctx, &storage.RestartMemberRequest{
+				Partition: partition,
+			})
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		return "Member restarted", nil
+	case storagev2beta1.RaftTransferLeadershipOperation:
+		if target.replicaID >= 0 {
+			err := invokeReplicaAdmin(protocol, target.clusterID, target.replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+				_, err := client.AcquireLeadership(ctx, &storage.AcquireLeadershipRequest{
+					Partition: partition,
+				})
+				return err
+			})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Leadership transferred to %s", getPodName(protocol, target.clusterID, target.replicaID)), nil
+		}
+		return r.transferLeadership(protocol, target)
+	}
+	return "", fmt.Errorf("unsupported operation type %q", operation.Spec.Type)
+}
+
+// transferLeadership transfers leadership of the targeted partition away from its current leader
+func (r *OperationReconciler) transferLeadership(protocol *storagev2beta1.MultiRaftProtocol, target operationTarget) (string, error) {
+	partition := &storagev2beta1.RaftPartition{}
+	partitionName := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getPartitionName(protocol, target.clusterID, target.partitionID),
+	}
+	if err := r.client.Get(context.TODO(), partitionName, partition); err != nil {
+		return "", err
+	}
+	if partition.Status.Leader == nil {
+		return "", fmt.Errorf("partition %d has no leader", target.partitionID)
+	}
+
+	leader := *partition.Status.Leader
+	for replicaID := 0; replicaID < maxReplicas; replicaID++ {
+		if getPodName(protocol, target.clusterID, replicaID) != leader {
+			continue
+		}
+		err := invokeReplicaAdmin(protocol, target.clusterID, replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+			_, err := client.TransferLeadership(ctx, &storage.TransferLeadershipRequest{
+				Partition: uint64(target.partitionID),
+			})
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Leadership transferred from %s", leader), nil
+	}
+	return "", fmt.Errorf("leader %s of partition %d is not a replica of cluster %d", leader, target.partitionID, target.clusterID)
+}
@@ -0,0 +1,123 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"testing"
+	"time"
+)
+
+func TestIsRestartable(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	restarted := metav1.NewTime(time.Now())
+	before := metav1.NewTime(restarted.Add(-time.Minute))
+	after := metav1.NewTime(restarted.Add(time.Minute))
+
+	newPartition := func(ready bool) *storagev2beta1.RaftPartition {
+		partition := newTestPartition(protocol, 1, 1, storagev2beta1.RaftPartitionReady)
+		partition.Status.Conditions = []storagev2beta1.Condition{
+			newCondition(storagev2beta1.ConditionReady, ready, "", "", 0),
+		}
+		return partition
+	}
+	newMember := func(updated metav1.Time, ready bool, lag uint64) *storagev2beta1.RaftMember {
+		member := &storagev2beta1.RaftMember{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: protocol.Namespace,
+				Name:      getMemberName(protocol, 1, 1, 0),
+			},
+		}
+		member.Status.LastUpdated = &updated
+		member.Status.Lag = &lag
+		member.Status.Conditions = []storagev2beta1.Condition{
+			newCondition(storagev2beta1.ConditionReady, ready, "", "", 0),
+			newCondition(storagev2beta1.RaftMemberLagging, lag > defaultMaxMemberLag, "", "", 0),
+		}
+		return member
+	}
+
+	previous := &operationTarget{clusterID: 1, partitionID: 1, replicaID: 0}
+	succeeded := &storagev2beta1.RaftOperationResult{Partition: 1, Succeeded: true, CompletionTime: &restarted}
+	failed := &storagev2beta1.RaftOperationResult{Partition: 1, Succeeded: false, CompletionTime: &restarted}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		previous    *operationTarget
+		result      *storagev2beta1.RaftOperationResult
+		restartable bool
+	}{
+		{
+			name:        "first member",
+			objects:     []runtime.Object{newPartition(true)},
+			restartable: true,
+		},
+		{
+			name:    "partition not ready",
+			objects: []runtime.Object{newPartition(false)},
+		},
+		{
+			name: "partition not found",
+		},
+		{
+			name:     "previous member not reported since restart",
+			objects:  []runtime.Object{newPartition(true), newMember(before, true, 0)},
+			previous: previous,
+			result:   succeeded,
+		},
+		{
+			name:     "previous member lagging",
+			objects:  []runtime.Object{newPartition(true), newMember(after, true, defaultMaxMemberLag+1)},
+			previous: previous,
+			result:   succeeded,
+		},
+		{
+			name:     "previous member not ready",
+			objects:  []runtime.Object{newPartition(true), newMember(after, false, 0)},
+			previous: previous,
+			result:   succeeded,
+		},
+		{
+			name:        "previous member caught up",
+			objects:     []runtime.Object{newPartition(true), newMember(after, true, 0)},
+			previous:    previous,
+			result:      succeeded,
+			restartable: true,
+		},
+		{
+			name:        "previous restart failed",
+			objects:     []runtime.Object{newPartition(true)},
+			previous:    previous,
+			result:      failed,
+			restartable: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &OperationReconciler{
+				client: newTestReconciler(test.objects...).client,
+			}
+			target := operationTarget{clusterID: 1, partitionID: 1, replicaID: 1}
+			restartable, err := r.isRestartable(protocol, target, test.previous, test.result)
+			assert.NoError(t, err)
+			assert.Equal(t, test.restartable, restartable)
+		})
+	}
+}
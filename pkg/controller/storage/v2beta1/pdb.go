@@ -0,0 +1,98 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// reconcilePodDisruptionBudget maintains a PodDisruptionBudget allowing only as many replicas of the
+// cluster to be disrupted as can be lost without losing quorum
+func (r *Reconciler) reconcilePodDisruptionBudget(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	maxUnavailable, err := r.getMaxUnavailable(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	name := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
+	}
+	err = r.client.Get(context.TODO(), name, pdb)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.addPodDisruptionBudget(protocol, cluster, maxUnavailable)
+		}
+		return err
+	}
+
+	if pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.IntValue() != maxUnavailable {
+		log.Info("Updating raft PodDisruptionBudget", "Name", pdb.Name, "Namespace", pdb.Namespace, "MaxUnavailable", maxUnavailable)
+		value := intstr.FromInt(maxUnavailable)
+		pdb.Spec.MaxUnavailable = &value
+		pdb.Spec.MinAvailable = nil
+		return r.client.Update(context.TODO(), pdb)
+	}
+	return nil
+}
+
+func (r *Reconciler) addPodDisruptionBudget(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, maxUnavailable int) error {
+	log.Info("Creating raft PodDisruptionBudget", "Name", protocol.Name, "Namespace", protocol.Namespace, "MaxUnavailable", maxUnavailable)
+	value := intstr.FromInt(maxUnavailable)
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
+			Namespace: protocol.Namespace,
+			Labels:    newClusterLabels(protocol, int(cluster.Spec.ClusterID)),
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &value,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: newClusterLabels(protocol, int(cluster.Spec.ClusterID)),
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(protocol, pdb, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(context.TODO(), pdb)
+}
+
+// getMaxUnavailable returns the number of replicas in the cluster that can be disrupted while
+// preserving a quorum. No disruptions are allowed while any of the cluster's partitions is degraded.
+func (r *Reconciler) getMaxUnavailable(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) (int, error) {
+	for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+		partition, err := r.getPartition(protocol, int(cluster.Spec.ClusterID), partitionID)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return 0, nil
+			}
+			return 0, err
+		}
+		if partition.Status.State != storagev2beta1.RaftPartitionReady {
+			return 0, nil
+		}
+	}
+	return (len(getMembers(cluster)) - 1) / 2, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"testing"
+)
+
+// getTestMaxUnavailable returns the maximum number of unavailable pods allowed by the cluster's PodDisruptionBudget
+func getTestMaxUnavailable(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol) int {
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), pdb)
+	assert.Nil(t, pdb.Spec.MinAvailable)
+	assert.NotNil(t, pdb.Spec.MaxUnavailable)
+	assert.Equal(t, newClusterLabels(protocol, 1), pdb.Spec.Selector.MatchLabels)
+	return pdb.Spec.MaxUnavailable.IntValue()
+}
+
+func TestReconcilePodDisruptionBudget(t *testing.T) {
+	tests := []struct {
+		name           string
+		partitions     int32
+		ready          []bool
+		maxUnavailable int
+	}{
+		{
+			name:           "single replica",
+			partitions:     1,
+			ready:          []bool{true},
+			maxUnavailable: 0,
+		},
+		{
+			name:           "two replicas",
+			partitions:     1,
+			ready:          []bool{true, true},
+			maxUnavailable: 0,
+		},
+		{
+			name:           "three replicas",
+			partitions:     2,
+			ready:          []bool{true, true, true},
+			maxUnavailable: 1,
+		},
+		{
+			name:           "four replicas",
+			partitions:     1,
+			ready:          []bool{true, true, true, true},
+			maxUnavailable: 1,
+		},
+		{
+			name:           "five replicas",
+			partitions:     1,
+			ready:          []bool{true, true, true, true, true},
+			maxUnavailable: 2,
+		},
+		{
+			name:           "degraded partitions",
+			partitions:     2,
+			ready:          []bool{true, true, true, true, false},
+			maxUnavailable: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(1, test.partitions, int32(len(test.ready)))
+			reconciler := newTestReconciler(protocol)
+			reconcileTestProtocol(t, reconciler, protocol)
+
+			// Disruptions are blocked until the partitions are known to be ready
+			assert.Equal(t, 0, getTestMaxUnavailable(t, reconciler, protocol))
+
+			setTestPods(t, reconciler, protocol, 1, test.ready)
+			for partitionID := 1; partitionID <= int(test.partitions); partitionID++ {
+				setTestLeader(t, reconciler, protocol, 1, partitionID, 0)
+			}
+			reconcileTestProtocol(t, reconciler, protocol)
+			reconcileTestProtocol(t, reconciler, protocol)
+			assert.Equal(t, test.maxUnavailable, getTestMaxUnavailable(t, reconciler, protocol))
+		})
+	}
+}
+
+func TestReconcilePodDisruptionBudgetDegraded(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	setTestPods(t, reconciler, protocol, 1, []bool{true, true, true})
+	setTestLeader(t, reconciler, protocol, 1, 1, 0)
+	reconcileTestProtocol(t, reconciler, protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Equal(t, 1, getTestMaxUnavailable(t, reconciler, protocol))
+
+	// Disruptions are blocked while a partition is degraded
+	setTestPods(t, reconciler, protocol, 1, []bool{true, false, true})
+	reconcileTestProtocol(t, reconciler, protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Equal(t, 0, getTestMaxUnavailable(t, reconciler, protocol))
+
+	setTestPods(t, reconciler, protocol, 1, []bool{true, true, true})
+	reconcileTestProtocol(t, reconciler, protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	assert.Equal(t, 1, getTestMaxUnavailable(t, reconciler, protocol))
+}
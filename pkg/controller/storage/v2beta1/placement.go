@@ -0,0 +1,127 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"sort"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcilePlacement assigns new partitions to clusters and records the assignments in the protocol status
+func (r *Reconciler) reconcilePlacement(protocol *storagev2beta1.MultiRaftProtocol) error {
+	placement := protocol.Status.Placement
+	if placement == nil {
+		// Partitions created before placement was recorded must remain in the clusters that hold their data
+		existing, err := r.getExistingPlacement(protocol)
+		if err != nil {
+			return err
+		}
+		placement = existing
+	}
+
+	placed := make(map[int32]bool)
+	for _, partition := range placement {
+		placed[partition.PartitionID] = true
+	}
+
+	added := false
+	for partitionID := 1; partitionID <= getNumPartitions(protocol); partitionID++ {
+		if placed[int32(partitionID)] {
+			continue
+		}
+		clusterID := placePartition(protocol, placement, partitionID)
+		log.Info("Placing partition", "Name", protocol.Name, "Namespace", protocol.Namespace, "Partition", partitionID, "Cluster", clusterID)
+		placement = append(placement, storagev2beta1.PartitionPlacement{
+			PartitionID: int32(partitionID),
+			ClusterID:   int32(clusterID),
+		})
+		added = true
+	}
+
+	if !added && protocol.Status.Placement != nil {
+		return nil
+	}
+
+	sort.Slice(placement, func(i, j int) bool {
+		return placement[i].PartitionID < placement[j].PartitionID
+	})
+	protocol.Status.Placement = placement
+	return r.client.Status().Update(context.TODO(), protocol)
+}
+
+// getExistingPlacement returns the placement of the RaftPartitions created for the given protocol
+func (r *Reconciler) getExistingPlacement(protocol *storagev2beta1.MultiRaftProtocol) ([]storagev2beta1.PartitionPlacement, error) {
+	clusters := &storagev2beta1.RaftClusterList{}
+	if err := r.client.List(context.TODO(), clusters, client.InNamespace(protocol.Namespace)); err != nil {
+		return nil, err
+	}
+
+	clusterUIDs := make(map[types.UID]bool)
+	for _, cluster := range clusters.Items {
+		owner := metav1.GetControllerOf(&cluster)
+		if owner != nil && owner.UID == protocol.UID {
+			clusterUIDs[cluster.UID] = true
+		}
+	}
+	if len(clusterUIDs) == 0 {
+		return []storagev2beta1.PartitionPlacement{}, nil
+	}
+
+	partitions := &storagev2beta1.RaftPartitionList{}
+	if err := r.client.List(context.TODO(), partitions, client.InNamespace(protocol.Namespace)); err != nil {
+		return nil, err
+	}
+
+	placement := make([]storagev2beta1.PartitionPlacement, 0, len(partitions.Items))
+	for _, partition := range partitions.Items {
+		owner := metav1.GetControllerOf(&partition)
+		if owner != nil && clusterUIDs[owner.UID] {
+			placement = append(placement, storagev2beta1.PartitionPlacement{
+				PartitionID: partition.Spec.PartitionID,
+				ClusterID:   partition.Spec.ClusterID,
+			})
+		}
+	}
+	return placement, nil
+}
+
+// placePartition returns the cluster to which to assign a new partition using the protocol's placement strategy
+func placePartition(protocol *storagev2beta1.MultiRaftProtocol, placement []storagev2beta1.PartitionPlacement, partitionID int) int {
+	numClusters := getNumClusters(protocol)
+	switch protocol.Spec.Placement {
+	case storagev2beta1.ContiguousPlacement:
+		partitionsPerCluster := (getNumPartitions(protocol) + numClusters - 1) / numClusters
+		return ((partitionID - 1) / partitionsPerCluster) + 1
+	case storagev2beta1.LeastLoadedPlacement:
+		counts := make(map[int]int)
+		for _, partition := range placement {
+			counts[int(partition.ClusterID)]++
+		}
+		clusterID := 1
+		for i := 2; i <= numClusters; i++ {
+			if counts[i] < counts[clusterID] {
+				clusterID = i
+			}
+		}
+		return clusterID
+	default:
+		return (partitionID % numClusters) + 1
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// assertTestPlacement asserts the protocol's recorded placement and that each partition was created in its cluster
+func assertTestPlacement(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, expected map[int]int) {
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	placement := make(map[int]int)
+	for _, partition := range protocol.Status.Placement {
+		placement[int(partition.PartitionID)] = int(partition.ClusterID)
+	}
+	assert.Equal(t, expected, placement)
+
+	partitions := &storagev2beta1.RaftPartitionList{}
+	assert.NoError(t, reconciler.client.List(context.TODO(), partitions))
+	assert.Len(t, partitions.Items, len(expected))
+	for _, partition := range partitions.Items {
+		assert.Equal(t, expected[int(partition.Spec.PartitionID)], int(partition.Spec.ClusterID), partition.Name)
+		assert.Equal(t, getPartitionName(protocol, int(partition.Spec.ClusterID), int(partition.Spec.PartitionID)), partition.Name)
+	}
+}
+
+func TestReconcilePlacement(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategy   storagev2beta1.PartitionPlacementStrategy
+		clusters   int32
+		partitions int32
+		expected   map[int]int
+	}{
+		{
+			name:       "round robin",
+			strategy:   storagev2beta1.RoundRobinPlacement,
+			clusters:   3,
+			partitions: 6,
+			expected:   map[int]int{1: 2, 2: 3, 3: 1, 4: 2, 5: 3, 6: 1},
+		},
+		{
+			name:       "default",
+			clusters:   2,
+			partitions: 3,
+			expected:   map[int]int{1: 2, 2: 1, 3: 2},
+		},
+		{
+			name:       "single cluster",
+			clusters:   1,
+			partitions: 3,
+			expected:   map[int]int{1: 1, 2: 1, 3: 1},
+		},
+		{
+			name:       "contiguous",
+			strategy:   storagev2beta1.ContiguousPlacement,
+			clusters:   3,
+			partitions: 6,
+			expected:   map[int]int{1: 1, 2: 1, 3: 2, 4: 2, 5: 3, 6: 3},
+		},
+		{
+			name:       "contiguous uneven",
+			strategy:   storagev2beta1.ContiguousPlacement,
+			clusters:   2,
+			partitions: 5,
+			expected:   map[int]int{1: 1, 2: 1, 3: 1, 4: 2, 5: 2},
+		},
+		{
+			name:       "least loaded",
+			strategy:   storagev2beta1.LeastLoadedPlacement,
+			clusters:   3,
+			partitions: 5,
+			expected:   map[int]int{1: 1, 2: 2, 3: 3, 4: 1, 5: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(test.clusters, test.partitions, 3)
+			protocol.Spec.Placement = test.strategy
+			protocol.Status.Placement = nil
+			reconciler := newTestReconciler(protocol)
+			reconcileTestProtocol(t, reconciler, protocol)
+			assertTestPlacement(t, reconciler, protocol, test.expected)
+		})
+	}
+}
+
+func TestReconcilePlacementIsStable(t *testing.T) {
+	protocol := newTestProtocol(2, 2, 3)
+	protocol.Spec.Placement = storagev2beta1.LeastLoadedPlacement
+	protocol.Status.Placement = []storagev2beta1.PartitionPlacement{
+		{PartitionID: 1, ClusterID: 1},
+		{PartitionID: 2, ClusterID: 1},
+	}
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	assertTestPlacement(t, reconciler, protocol, map[int]int{1: 1, 2: 1})
+
+	// Added partitions are placed in the least loaded cluster without moving existing partitions
+	protocol.Spec.Partitions = 4
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+	assertTestPlacement(t, reconciler, protocol, map[int]int{1: 1, 2: 1, 3: 2, 4: 2})
+}
+
+func TestReconcilePlacementOfExistingPartitions(t *testing.T) {
+	protocol := newTestProtocol(2, 4, 3)
+	protocol.Spec.Placement = storagev2beta1.ContiguousPlacement
+	protocol.Status.Placement = nil
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+	assertTestPlacement(t, reconciler, protocol, map[int]int{1: 1, 2: 1, 3: 2, 4: 2})
+
+	// Partitions created before placement was recorded remain in the clusters that hold their data
+	protocol.Spec.Placement = storagev2beta1.RoundRobinPlacement
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+	protocol.Status.Placement = nil
+	assert.NoError(t, reconciler.client.Status().Update(context.TODO(), protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+	assertTestPlacement(t, reconciler, protocol, map[int]int{1: 1, 2: 1, 3: 2, 4: 2})
+}
@@ -0,0 +1,148 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const podTemplateHashAnnotation = "storage.atomix.io/pod-template-hash"
+
+// getPodTemplateHash returns a hash of the pod template overrides, topology spreading and encryption key Secret
+// for the given protocol
+func getPodTemplateHash(protocol *storagev2beta1.MultiRaftProtocol) (string, error) {
+	if protocol.Spec.PodTemplate == nil && protocol.Spec.TopologySpread == nil && protocol.Spec.Encryption == nil {
+		return "", nil
+	}
+	values := []interface{}{protocol.Spec.PodTemplate, protocol.Spec.TopologySpread}
+	if protocol.Spec.Encryption != nil {
+		values = append(values, protocol.Spec.Encryption.SecretName)
+	}
+	bytes, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// applyPodTemplate merges the protocol's pod template overrides into the generated pod template.
+// Labels, annotations, environment variables, containers and volumes generated by the controller
+// take precedence over overrides with the same key or name. Scheduling fields and resources are
+// taken from the overrides, and an override affinity replaces the default anti-affinity.
+func applyPodTemplate(protocol *storagev2beta1.MultiRaftProtocol, template *corev1.PodTemplateSpec) {
+	overrides := protocol.Spec.PodTemplate
+	if overrides == nil {
+		return
+	}
+	overrides = overrides.DeepCopy()
+
+	template.Labels = mergeStrings(overrides.Labels, template.Labels)
+	template.Annotations = mergeStrings(overrides.Annotations, template.Annotations)
+
+	if overrides.NodeSelector != nil {
+		template.Spec.NodeSelector = overrides.NodeSelector
+	}
+	if overrides.Tolerations != nil {
+		template.Spec.Tolerations = overrides.Tolerations
+	}
+	if overrides.Affinity != nil {
+		template.Spec.Affinity = overrides.Affinity
+	}
+	if overrides.PriorityClassName != "" {
+		template.Spec.PriorityClassName = overrides.PriorityClassName
+	}
+
+	for i, container := range template.Spec.Containers {
+		if container.Name != raftContainerName {
+			continue
+		}
+		template.Spec.Containers[i].Resources = overrides.Resources
+		for _, env := range overrides.Env {
+			if !hasEnv(container.Env, env.Name) {
+				template.Spec.Containers[i].Env = append(template.Spec.Containers[i].Env, env)
+			}
+		}
+	}
+
+	for _, container := range overrides.InitContainers {
+		if !hasContainer(template.Spec.InitContainers, container.Name) {
+			template.Spec.InitContainers = append(template.Spec.InitContainers, container)
+		} else {
+			log.Info("Ignoring init container override", "Name", protocol.Name, "Namespace", protocol.Namespace, "Container", container.Name)
+		}
+	}
+	for _, container := range overrides.Containers {
+		if !hasContainer(template.Spec.Containers, container.Name) {
+			template.Spec.Containers = append(template.Spec.Containers, container)
+		} else {
+			log.Info("Ignoring container override", "Name", protocol.Name, "Namespace", protocol.Namespace, "Container", container.Name)
+		}
+	}
+	for _, volume := range overrides.Volumes {
+		if !hasVolume(template.Spec.Volumes, volume.Name) {
+			template.Spec.Volumes = append(template.Spec.Volumes, volume)
+		} else {
+			log.Info("Ignoring volume override", "Name", protocol.Name, "Namespace", protocol.Namespace, "Volume", volume.Name)
+		}
+	}
+}
+
+// mergeStrings returns the union of the given maps, with values in the second map taking precedence
+func mergeStrings(base, overrides map[string]string) map[string]string {
+	if base == nil && overrides == nil {
+		return nil
+	}
+	merged := make(map[string]string)
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+func hasEnv(env []corev1.EnvVar, name string) bool {
+	for _, e := range env {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasContainer(containers []corev1.Container, name string) bool {
+	for _, container := range containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
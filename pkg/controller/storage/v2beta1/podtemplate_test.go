@@ -0,0 +1,214 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"testing"
+)
+
+// getTestRaftContainer returns the Raft container of the given pod template
+func getTestRaftContainer(t *testing.T, template corev1.PodTemplateSpec) corev1.Container {
+	for _, container := range template.Spec.Containers {
+		if container.Name == raftContainerName {
+			return container
+		}
+	}
+	assert.Fail(t, "no raft container")
+	return corev1.Container{}
+}
+
+func TestReconcilePodTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides *storagev2beta1.RaftPodTemplate
+		check     func(*testing.T, corev1.PodTemplateSpec)
+	}{
+		{
+			name: "no overrides",
+			check: func(t *testing.T, template corev1.PodTemplateSpec) {
+				assert.Empty(t, template.Annotations[podTemplateHashAnnotation])
+				assert.NotNil(t, template.Spec.Affinity.PodAntiAffinity)
+				assert.Len(t, template.Spec.Containers, 1)
+			},
+		},
+		{
+			name: "labels and annotations",
+			overrides: &storagev2beta1.RaftPodTemplate{
+				Labels: map[string]string{
+					databaseLabel: "override",
+					"team":        "storage",
+				},
+				Annotations: map[string]string{
+					configHashAnnotation: "override",
+					"example.com/owner":  "storage",
+				},
+			},
+			check: func(t *testing.T, template corev1.PodTemplateSpec) {
+				assert.Equal(t, "raft.test", template.Labels[databaseLabel])
+				assert.Equal(t, "storage", template.Labels["team"])
+				assert.NotEqual(t, "override", template.Annotations[configHashAnnotation])
+				assert.Equal(t, "storage", template.Annotations["example.com/owner"])
+				assert.NotEmpty(t, template.Annotations[podTemplateHashAnnotation])
+			},
+		},
+		{
+			name: "scheduling",
+			overrides: &storagev2beta1.RaftPodTemplate{
+				NodeSelector: map[string]string{
+					"disk": "ssd",
+				},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+					},
+				},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{},
+				},
+				PriorityClassName: "high",
+			},
+			check: func(t *testing.T, template corev1.PodTemplateSpec) {
+				assert.Equal(t, map[string]string{"disk": "ssd"}, template.Spec.NodeSelector)
+				assert.Len(t, template.Spec.Tolerations, 1)
+				assert.NotNil(t, template.Spec.Affinity.NodeAffinity)
+				assert.Nil(t, template.Spec.Affinity.PodAntiAffinity)
+				assert.Equal(t, "high", template.Spec.PriorityClassName)
+			},
+		},
+		{
+			name: "resources and environment",
+			overrides: &storagev2beta1.RaftPodTemplate{
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+				Env: []corev1.EnvVar{
+					{
+						Name:  "NODE_ID",
+						Value: "override",
+					},
+					{
+						Name:  "GOGC",
+						Value: "50",
+					},
+				},
+			},
+			check: func(t *testing.T, template corev1.PodTemplateSpec) {
+				container := getTestRaftContainer(t, template)
+				assert.Equal(t, resource.MustParse("1Gi"), container.Resources.Limits[corev1.ResourceMemory])
+				env := make(map[string]corev1.EnvVar)
+				for _, e := range container.Env {
+					env[e.Name] = e
+				}
+				assert.Empty(t, env["NODE_ID"].Value)
+				assert.NotNil(t, env["NODE_ID"].ValueFrom)
+				assert.Equal(t, "50", env["GOGC"].Value)
+			},
+		},
+		{
+			name: "containers and volumes",
+			overrides: &storagev2beta1.RaftPodTemplate{
+				InitContainers: []corev1.Container{
+					{
+						Name: "init",
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name:  raftContainerName,
+						Image: "override",
+					},
+					{
+						Name: "sidecar",
+					},
+				},
+				Volumes: []corev1.Volume{
+					{
+						Name: configVolume,
+						VolumeSource: corev1.VolumeSource{
+							EmptyDir: &corev1.EmptyDirVolumeSource{},
+						},
+					},
+					{
+						Name: "cache",
+					},
+				},
+			},
+			check: func(t *testing.T, template corev1.PodTemplateSpec) {
+				assert.Len(t, template.Spec.InitContainers, 1)
+				assert.Len(t, template.Spec.Containers, 2)
+				assert.NotEqual(t, "override", getTestRaftContainer(t, template).Image)
+				volumes := make(map[string]corev1.Volume)
+				for _, volume := range template.Spec.Volumes {
+					volumes[volume.Name] = volume
+				}
+				assert.Nil(t, volumes[configVolume].EmptyDir)
+				assert.Contains(t, volumes, "cache")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(1, 1, 3)
+			protocol.Spec.PodTemplate = test.overrides
+			reconciler := newTestReconciler(protocol)
+			reconcileTestProtocol(t, reconciler, protocol)
+
+			statefulSet := &appsv1.StatefulSet{}
+			getTestObject(t, reconciler, getClusterName(protocol, 1), statefulSet)
+			test.check(t, statefulSet.Spec.Template)
+		})
+	}
+}
+
+func TestReconcilePodTemplateChange(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	statefulSet := &appsv1.StatefulSet{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), statefulSet)
+	assert.Empty(t, statefulSet.Spec.Template.Spec.PriorityClassName)
+
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	protocol.Spec.PodTemplate = &storagev2beta1.RaftPodTemplate{
+		PriorityClassName: "high",
+	}
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	getTestObject(t, reconciler, getClusterName(protocol, 1), statefulSet)
+	assert.Equal(t, "high", statefulSet.Spec.Template.Spec.PriorityClassName)
+	high := statefulSet.Spec.Template.Annotations[podTemplateHashAnnotation]
+	assert.NotEmpty(t, high)
+
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	protocol.Spec.PodTemplate.PriorityClassName = "low"
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	getTestObject(t, reconciler, getClusterName(protocol, 1), statefulSet)
+	assert.Equal(t, "low", statefulSet.Spec.Template.Spec.PriorityClassName)
+	assert.NotEqual(t, high, statefulSet.Spec.Template.Annotations[podTemplateHashAnnotation])
+}
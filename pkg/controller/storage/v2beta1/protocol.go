@@ -16,22 +16,24 @@ package v2beta1
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	protocolapi "github.com/atomix/atomix-api/go/atomix/protocol"
 	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
-	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"github.com/atomix/atomix-raft-storage/pkg/storage/config"
 	"github.com/gogo/protobuf/jsonpb"
-	"google.golang.org/grpc"
-	"io"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	"os"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sort"
+	"time"
 )
 
 const (
@@ -49,24 +51,33 @@ const (
 )
 
 const (
-	configPath         = "/etc/atomix"
-	clusterConfigFile  = "cluster.json"
-	protocolConfigFile = "protocol.json"
-	dataPath           = "/var/lib/atomix"
+	configPath           = "/etc/atomix"
+	clusterConfigFile    = "cluster.json"
+	protocolConfigFile   = "protocol.json"
+	membershipConfigFile = "membership.json"
+	dataPath             = "/var/lib/atomix"
+	keysPath             = "/etc/atomix-raft/keys"
 )
 
 const (
 	configVolume = "config"
 	dataVolume   = "data"
+	keysVolume   = "keys"
 )
 
 const monitoringPort = 5000
 
+const raftContainerName = "raft"
+
+const configHashAnnotation = "storage.atomix.io/config-hash"
+
 const clusterDomainEnv = "CLUSTER_DOMAIN"
 
 func (r *Reconciler) reconcileClusters(protocol *storagev2beta1.MultiRaftProtocol) error {
 	for _, clusterID := range getClusters(protocol) {
+		start := time.Now()
 		err := r.reconcileCluster(protocol, clusterID)
+		observeReconcile(raftClusterKind, start, err)
 		if err != nil {
 			return err
 		}
@@ -96,6 +107,11 @@ func (r *Reconciler) reconcileCluster(protocol *storagev2beta1.MultiRaftProtocol
 		}
 	}
 
+	err = r.initClusterStatus(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcilePartitions(protocol, cluster)
 	if err != nil {
 		return err
@@ -106,6 +122,11 @@ func (r *Reconciler) reconcileCluster(protocol *storagev2beta1.MultiRaftProtocol
 		return err
 	}
 
+	err = r.reconcileVolumeExpansion(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileStatefulSet(protocol, cluster)
 	if err != nil {
 		return err
@@ -116,11 +137,38 @@ func (r *Reconciler) reconcileCluster(protocol *storagev2beta1.MultiRaftProtocol
 		return err
 	}
 
-	// TODO: Stop monitoring clusters too!
-	err = r.startMonitoringCluster(protocol, cluster)
+	err = r.reconcilePodDisruptionBudget(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileNetworkPolicy(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileScale(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileUpgrade(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileTopology(protocol, cluster)
 	if err != nil {
 		return err
 	}
+
+	err = r.reconcileLeaderBalance(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	// Monitoring streams are opened asynchronously and never fail reconciliation
+	r.startMonitoringCluster(protocol, cluster)
 	return nil
 }
 
@@ -142,7 +190,9 @@ func (r *Reconciler) addCluster(protocol *storagev2beta1.MultiRaftProtocol, clus
 
 func (r *Reconciler) reconcilePartitions(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
 	for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+		start := time.Now()
 		err := r.reconcilePartition(protocol, cluster, partitionID)
+		observeReconcile(raftPartitionKind, start, err)
 		if err != nil {
 			return err
 		}
@@ -192,8 +242,10 @@ func (r *Reconciler) addPartition(protocol *storagev2beta1.MultiRaftProtocol, cl
 }
 
 func (r *Reconciler) reconcileMembers(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, partition *storagev2beta1.RaftPartition) error {
-	for memberID := range getMembers(protocol, int(cluster.Spec.ClusterID), int(partition.Spec.PartitionID)) {
+	for _, memberID := range getMembers(cluster) {
+		start := time.Now()
 		err := r.reconcileMember(protocol, cluster, partition, memberID)
+		observeReconcile(raftMemberKind, start, err)
 		if err != nil {
 			return err
 		}
@@ -219,6 +271,18 @@ func (r *Reconciler) reconcileMember(protocol *storagev2beta1.MultiRaftProtocol,
 }
 
 func (r *Reconciler) addMember(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, partition *storagev2beta1.RaftPartition, memberID int) error {
+	// Members of the initial replicas bootstrap the partition, while members added by
+	// scaling or migration join the running partition with the node IDs allocated for them
+	nodeID := int32(memberID + 1)
+	join := false
+	if isMigrationTarget(protocol, cluster, int(partition.Spec.PartitionID)) {
+		nodeID = protocol.Status.Migration.BaseNodeID + int32(memberID) + 1
+		join = true
+	} else if int32(memberID) >= cluster.Status.Replicas {
+		nodeID = cluster.Status.LastNodeID
+		join = true
+	}
+
 	member := &storagev2beta1.RaftMember{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: protocol.Namespace,
@@ -229,6 +293,8 @@ func (r *Reconciler) addMember(protocol *storagev2beta1.MultiRaftProtocol, clust
 			PartitionID: partition.Spec.PartitionID,
 			MemberID:    int32(memberID),
 			Pod:         getPodName(protocol, int(cluster.Spec.ClusterID), memberID),
+			NodeID:      nodeID,
+			Join:        join,
 		},
 	}
 	if err := controllerutil.SetControllerReference(partition, member, r.scheme); err != nil {
@@ -237,130 +303,43 @@ func (r *Reconciler) addMember(protocol *storagev2beta1.MultiRaftProtocol, clust
 	return r.client.Create(context.TODO(), member)
 }
 
-func (r *Reconciler) startMonitoringCluster(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
-	for replicaID := range getReplicas(protocol, int(cluster.Spec.ClusterID)) {
-		err := r.startMonitoringPod(protocol, cluster, replicaID)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (r *Reconciler) startMonitoringPod(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, podID int) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	replicaID := getPodName(protocol, int(cluster.Spec.ClusterID), podID)
-	_, ok := r.streams[replicaID]
-	if ok {
-		return nil
-	}
-
-	pod := &corev1.Pod{}
-	podName := types.NamespacedName{
+func (r *Reconciler) reconcileConfigMap(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	log.Info("Reconcile raft protocol config map")
+	cm := &corev1.ConfigMap{}
+	name := types.NamespacedName{
 		Namespace: protocol.Namespace,
-		Name:      replicaID,
-	}
-	if err := r.client.Get(context.TODO(), podName, pod); err != nil {
-		return err
+		Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
 	}
-
-	conn, err := grpc.Dial(
-		fmt.Sprintf("%s:%d", pod.Status.PodIP, monitoringPort),
-		grpc.WithInsecure())
+	err := r.client.Get(context.TODO(), name, cm)
 	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.addConfigMap(protocol, cluster)
+		}
 		return err
 	}
 
-	client := storage.NewRaftEventsClient(conn)
-	ctx, cancel := context.WithCancel(context.Background())
-
-	stream, err := client.Subscribe(ctx, &storage.SubscribeRequest{})
+	data, err := r.newConfigMapData(protocol, cluster)
 	if err != nil {
-		cancel()
 		return err
 	}
 
-	r.streams[replicaID] = cancel
-	go func() {
-		defer func() {
-			cancel()
-			r.mu.Lock()
-			delete(r.streams, replicaID)
-			r.mu.Unlock()
-			go r.startMonitoringPod(protocol, cluster, podID)
-		}()
-
-		for {
-			event, err := stream.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return
-			}
-
-			log.Infof("Received event %+v from %s", event, replicaID)
-			switch e := event.Event.(type) {
-			case *storage.RaftEvent_MemberReady:
-				r.recordPartitionReady(protocol, int(cluster.Spec.ClusterID), podID, e.MemberReady, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_LeaderUpdated:
-				r.recordLeaderUpdated(protocol, int(cluster.Spec.ClusterID), podID, e.LeaderUpdated, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_MembershipChanged:
-				r.recordMembershipChanged(protocol, int(cluster.Spec.ClusterID), podID, e.MembershipChanged, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_SendSnapshotStarted:
-				r.recordSendSnapshotStarted(protocol, int(cluster.Spec.ClusterID), podID, e.SendSnapshotStarted, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_SendSnapshotCompleted:
-				r.recordSendSnapshotCompleted(protocol, int(cluster.Spec.ClusterID), podID, e.SendSnapshotCompleted, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_SendSnapshotAborted:
-				r.recordSendSnapshotAborted(protocol, int(cluster.Spec.ClusterID), podID, e.SendSnapshotAborted, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_SnapshotReceived:
-				r.recordSnapshotReceived(protocol, int(cluster.Spec.ClusterID), podID, e.SnapshotReceived, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_SnapshotRecovered:
-				r.recordSnapshotRecovered(protocol, int(cluster.Spec.ClusterID), podID, e.SnapshotRecovered, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_SnapshotCreated:
-				r.recordSnapshotCreated(protocol, int(cluster.Spec.ClusterID), podID, e.SnapshotCreated, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_SnapshotCompacted:
-				r.recordSnapshotCompacted(protocol, int(cluster.Spec.ClusterID), podID, e.SnapshotCompacted, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_LogCompacted:
-				r.recordLogCompacted(protocol, int(cluster.Spec.ClusterID), podID, e.LogCompacted, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_LogdbCompacted:
-				r.recordLogDBCompacted(protocol, int(cluster.Spec.ClusterID), podID, e.LogdbCompacted, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_ConnectionEstablished:
-				r.recordConnectionEstablished(protocol, int(cluster.Spec.ClusterID), podID, e.ConnectionEstablished, metav1.NewTime(event.Timestamp))
-			case *storage.RaftEvent_ConnectionFailed:
-				r.recordConnectionFailed(protocol, int(cluster.Spec.ClusterID), podID, e.ConnectionFailed, metav1.NewTime(event.Timestamp))
-			}
+	updated := false
+	for key, value := range data {
+		if cm.Data[key] != value {
+			updated = true
 		}
-	}()
-	return nil
-}
-
-func (r *Reconciler) reconcileConfigMap(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
-	log.Info("Reconcile raft protocol config map")
-	cm := &corev1.ConfigMap{}
-	name := types.NamespacedName{
-		Namespace: protocol.Namespace,
-		Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
 	}
-	err := r.client.Get(context.TODO(), name, cm)
-	if err != nil && k8serrors.IsNotFound(err) {
-		err = r.addConfigMap(protocol, cluster)
+	if updated {
+		log.Info("Updating raft ConfigMap", "Name", protocol.Name, "Namespace", protocol.Namespace)
+		cm.Data = data
+		return r.client.Update(context.TODO(), cm)
 	}
-	return err
+	return nil
 }
 
 func (r *Reconciler) addConfigMap(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
 	log.Info("Creating raft ConfigMap", "Name", protocol.Name, "Namespace", protocol.Namespace)
-	var config interface{}
-
-	clusterConfig, err := newNodeConfigString(protocol, cluster)
-	if err != nil {
-		return err
-	}
-
-	protocolConfig, err := newProtocolConfigString(config)
+	data, err := r.newConfigMapData(protocol, cluster)
 	if err != nil {
 		return err
 	}
@@ -371,10 +350,7 @@ func (r *Reconciler) addConfigMap(protocol *storagev2beta1.MultiRaftProtocol, cl
 			Namespace: protocol.Namespace,
 			Labels:    newClusterLabels(protocol, int(cluster.Spec.ClusterID)),
 		},
-		Data: map[string]string{
-			clusterConfigFile:  clusterConfig,
-			protocolConfigFile: protocolConfig,
-		},
+		Data: data,
 	}
 
 	if err := controllerutil.SetControllerReference(protocol, cm, r.scheme); err != nil {
@@ -383,9 +359,33 @@ func (r *Reconciler) addConfigMap(protocol *storagev2beta1.MultiRaftProtocol, cl
 	return r.client.Create(context.TODO(), cm)
 }
 
+// newConfigMapData creates the configuration files for the given cluster
+func (r *Reconciler) newConfigMapData(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) (map[string]string, error) {
+	clusterConfig, err := newNodeConfigString(protocol, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	protocolConfig, err := newProtocolConfigString(protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	membershipConfig, err := r.newMembershipConfigString(protocol, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		clusterConfigFile:    clusterConfig,
+		protocolConfigFile:   protocolConfig,
+		membershipConfigFile: membershipConfig,
+	}, nil
+}
+
 // newNodeConfigString creates a node configuration string for the given cluster
 func newNodeConfigString(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) (string, error) {
-	replicaIDs := getReplicas(protocol, int(cluster.Spec.ClusterID))
+	replicaIDs := getReplicas(protocol, cluster)
 	replicas := make([]protocolapi.ProtocolReplica, len(replicaIDs))
 	for i, replicaID := range replicaIDs {
 		replicas[i] = protocolapi.ProtocolReplica{
@@ -416,13 +416,83 @@ func newNodeConfigString(protocol *storagev2beta1.MultiRaftProtocol, cluster *st
 	return marshaller.MarshalToString(config)
 }
 
-// newProtocolConfigString creates a protocol configuration string for the given cluster and protocol
-func newProtocolConfigString(config interface{}) (string, error) {
-	bytes, err := json.Marshal(config)
+// newMembershipConfigString creates a Raft membership configuration string for the given cluster
+func (r *Reconciler) newMembershipConfigString(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) (string, error) {
+	partitionIDs := getPartitions(protocol, int(cluster.Spec.ClusterID))
+	partitions := make([]config.PartitionMembership, 0, len(partitionIDs))
+	for _, partitionID := range partitionIDs {
+		memberIDs := getMembers(cluster)
+		members := make([]config.MemberConfig, 0, len(memberIDs))
+		for _, memberID := range memberIDs {
+			member, err := r.getMember(protocol, int(cluster.Spec.ClusterID), partitionID, memberID)
+			if err != nil {
+				if k8serrors.IsNotFound(err) {
+					continue
+				}
+				return "", err
+			}
+			members = append(members, config.MemberConfig{
+				ReplicaID: member.Spec.Pod,
+				NodeID:    uint64(getMemberNodeID(member)),
+				Join:      member.Spec.Join,
+				Learner:   member.Spec.Join && (member.Status.Type == nil || *member.Status.Type == storagev2beta1.RaftLearner),
+			})
+		}
+		partitions = append(partitions, config.PartitionMembership{
+			PartitionID: uint32(partitionID),
+			Members:     members,
+		})
+	}
+
+	marshaller := jsonpb.Marshaler{}
+	return marshaller.MarshalToString(&config.MembershipConfig{
+		Partitions: partitions,
+	})
+}
+
+// newProtocolConfigString creates a Raft protocol configuration string for the given protocol
+func newProtocolConfigString(protocol *storagev2beta1.MultiRaftProtocol) (string, error) {
+	protocolConfig := &config.ProtocolConfig{}
+	if protocol.Spec.ElectionTimeout != nil {
+		protocolConfig.ElectionTimeout = &protocol.Spec.ElectionTimeout.Duration
+	}
+	if protocol.Spec.HeartbeatInterval != nil {
+		protocolConfig.HeartbeatInterval = &protocol.Spec.HeartbeatInterval.Duration
+	}
+	if protocol.Spec.SnapshotInterval != nil {
+		protocolConfig.SnapshotInterval = &protocol.Spec.SnapshotInterval.Duration
+	}
+	if protocol.Spec.SnapshotThreshold > 0 {
+		protocolConfig.SnapshotThreshold = uint64(protocol.Spec.SnapshotThreshold)
+	}
+	if compaction := protocol.Spec.Compaction; compaction != nil {
+		protocolConfig.CompactionOverhead = uint64(compaction.Overhead)
+		if compaction.SnapshotThresholdSize != nil {
+			protocolConfig.SnapshotThresholdBytes = uint64(compaction.SnapshotThresholdSize.Value())
+		}
+		protocolConfig.DisableAutoCompaction = compaction.DisableAutoCompaction
+		if compaction.Interval != nil {
+			protocolConfig.CompactionInterval = &compaction.Interval.Duration
+		}
+	}
+	if protocol.Spec.DiskPressure != nil && protocol.Spec.DiskPressure.WriteLimit > 0 {
+		protocolConfig.MaxDiskUsage = uint32(protocol.Spec.DiskPressure.WriteLimit)
+	}
+	if protocol.Spec.Encryption != nil {
+		protocolConfig.EncryptionKeyId = protocol.Spec.Encryption.KeyID
+	}
+	marshaller := jsonpb.Marshaler{}
+	return marshaller.MarshalToString(protocolConfig)
+}
+
+// getConfigHash returns a hash of the Raft protocol configuration with which the given protocol's pods are started
+func getConfigHash(protocol *storagev2beta1.MultiRaftProtocol) (string, error) {
+	protocolConfig, err := newProtocolConfigString(protocol)
 	if err != nil {
 		return "", err
 	}
-	return string(bytes), nil
+	hash := sha256.Sum256([]byte(protocolConfig))
+	return hex.EncodeToString(hash[:]), nil
 }
 
 func (r *Reconciler) reconcileStatefulSet(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
@@ -433,19 +503,101 @@ func (r *Reconciler) reconcileStatefulSet(protocol *storagev2beta1.MultiRaftProt
 		Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
 	}
 	err := r.client.Get(context.TODO(), name, statefulSet)
-	if err != nil && k8serrors.IsNotFound(err) {
-		err = r.addStatefulSet(protocol, cluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.addStatefulSet(protocol, cluster)
+		}
+		return err
 	}
-	return err
+
+	updated := false
+	replicas := int32(getNumDeployedReplicas(protocol, cluster))
+	if statefulSet.Spec.Replicas == nil || *statefulSet.Spec.Replicas != replicas {
+		log.Info("Updating raft replicas", "Name", protocol.Name, "Namespace", protocol.Namespace, "Replicas", replicas)
+		statefulSet.Spec.Replicas = &replicas
+		updated = true
+	}
+
+	// Pods are restarted by the controller to ensure leadership is transferred before a pod is deleted
+	if statefulSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType {
+		statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.OnDeleteStatefulSetStrategyType,
+		}
+		updated = true
+	}
+
+	// Changes to the pod template overrides, topology spreading and encryption keys are applied by regenerating the template
+	podTemplateHash, err := getPodTemplateHash(protocol)
+	if err != nil {
+		return err
+	}
+	if statefulSet.Spec.Template.Annotations[podTemplateHashAnnotation] != podTemplateHash {
+		log.Info("Updating raft pod template", "Name", protocol.Name, "Namespace", protocol.Namespace, "Hash", podTemplateHash)
+		desired, err := newStatefulSet(protocol, cluster)
+		if err != nil {
+			return err
+		}
+		statefulSet.Spec.Template = desired.Spec.Template
+		updated = true
+	}
+
+	// Changes to the protocol configuration are applied by restarting pods through the upgrade process
+	configHash, err := getConfigHash(protocol)
+	if err != nil {
+		return err
+	}
+	if statefulSet.Spec.Template.Annotations[configHashAnnotation] != configHash {
+		log.Info("Updating raft protocol configuration", "Name", protocol.Name, "Namespace", protocol.Namespace, "Hash", configHash)
+		if statefulSet.Spec.Template.Annotations == nil {
+			statefulSet.Spec.Template.Annotations = make(map[string]string)
+		}
+		statefulSet.Spec.Template.Annotations[configHashAnnotation] = configHash
+		r.events.Eventf(protocol, "Normal", "ConfigurationChanged", "Restarting cluster %d to apply protocol configuration", cluster.Spec.ClusterID)
+		updated = true
+	}
+
+	image := getImage(protocol)
+	pullPolicy := getImagePullPolicy(protocol)
+	for i, container := range statefulSet.Spec.Template.Spec.Containers {
+		if container.Name == raftContainerName && (container.Image != image || container.ImagePullPolicy != pullPolicy) {
+			log.Info("Updating raft image", "Name", protocol.Name, "Namespace", protocol.Namespace, "Image", image)
+			statefulSet.Spec.Template.Spec.Containers[i].Image = image
+			statefulSet.Spec.Template.Spec.Containers[i].ImagePullPolicy = pullPolicy
+			updated = true
+		}
+	}
+
+	if updated {
+		return r.client.Update(context.TODO(), statefulSet)
+	}
+	return nil
 }
 
 func (r *Reconciler) addStatefulSet(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
 	log.Info("Creating raft replicas", "Name", protocol.Name, "Namespace", protocol.Namespace)
+	set, err := newStatefulSet(protocol, cluster)
+	if err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(protocol, set, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(context.TODO(), set)
+}
 
+// newStatefulSet creates the StatefulSet for the given cluster
+func newStatefulSet(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) (*appsv1.StatefulSet, error) {
 	image := getImage(protocol)
-	pullPolicy := protocol.Spec.ImagePullPolicy
-	if pullPolicy == "" {
-		pullPolicy = corev1.PullIfNotPresent
+	pullPolicy := getImagePullPolicy(protocol)
+
+	configHash, err := getConfigHash(protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	podTemplateHash, err := getPodTemplateHash(protocol)
+	if err != nil {
+		return nil, err
 	}
 
 	volumes := []corev1.Volume{
@@ -461,16 +613,47 @@ func (r *Reconciler) addStatefulSet(protocol *storagev2beta1.MultiRaftProtocol,
 		},
 	}
 
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      configVolume,
+			MountPath: configPath,
+		},
+	}
+
+	// Encryption keys are mounted whether or not a key is active so previously encrypted entries can be read
+	if protocol.Spec.Encryption != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: keysVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: protocol.Spec.Encryption.SecretName,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      keysVolume,
+			MountPath: keysPath,
+			ReadOnly:  true,
+		})
+	}
+
 	var volumeClaimTemplates []corev1.PersistentVolumeClaim
 
 	dataVolumeName := dataVolume
 	if protocol.Spec.VolumeClaimTemplate != nil {
-		pvc := protocol.Spec.VolumeClaimTemplate
+		pvc := protocol.Spec.VolumeClaimTemplate.DeepCopy()
 		if pvc.Name == "" {
 			pvc.Name = dataVolume
 		} else {
 			dataVolumeName = pvc.Name
 		}
+		// Label claims so they can be found when the protocol is deleted
+		if pvc.Labels == nil {
+			pvc.Labels = make(map[string]string)
+		}
+		for key, value := range newClusterLabels(protocol, int(cluster.Spec.ClusterID)) {
+			pvc.Labels[key] = value
+		}
 		volumeClaimTemplates = append(volumeClaimTemplates, *pvc)
 	} else {
 		volumes = append(volumes, corev1.Volume{
@@ -489,22 +672,26 @@ func (r *Reconciler) addStatefulSet(protocol *storagev2beta1.MultiRaftProtocol,
 		},
 		Spec: appsv1.StatefulSetSpec{
 			ServiceName: getClusterHeadlessServiceName(protocol, int(cluster.Spec.ClusterID)),
-			Replicas:    &protocol.Spec.Replicas,
+			Replicas:    pointer.Int32Ptr(int32(getNumDeployedReplicas(protocol, cluster))),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: newClusterLabels(protocol, int(cluster.Spec.ClusterID)),
 			},
 			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				Type: appsv1.OnDeleteStatefulSetStrategyType,
 			},
 			PodManagementPolicy: appsv1.ParallelPodManagement,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: newClusterLabels(protocol, int(cluster.Spec.ClusterID)),
+					Annotations: map[string]string{
+						configHashAnnotation:      configHash,
+						podTemplateHashAnnotation: podTemplateHash,
+					},
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
-							Name:            "raft",
+							Name:            raftContainerName,
 							Image:           image,
 							ImagePullPolicy: pullPolicy,
 							Env: []corev1.EnvVar{
@@ -531,6 +718,7 @@ func (r *Reconciler) addStatefulSet(protocol *storagev2beta1.MultiRaftProtocol,
 								"$(NODE_ID)",
 								fmt.Sprintf("%s/%s", configPath, clusterConfigFile),
 								fmt.Sprintf("%s/%s", configPath, protocolConfigFile),
+								fmt.Sprintf("%s/%s", configPath, membershipConfigFile),
 							},
 							ReadinessProbe: &corev1.Probe{
 								Handler: corev1.Handler{
@@ -552,16 +740,12 @@ func (r *Reconciler) addStatefulSet(protocol *storagev2beta1.MultiRaftProtocol,
 								TimeoutSeconds:      10,
 							},
 							SecurityContext: protocol.Spec.SecurityContext,
-							VolumeMounts: []corev1.VolumeMount{
+							VolumeMounts: append([]corev1.VolumeMount{
 								{
 									Name:      dataVolumeName,
 									MountPath: dataPath,
 								},
-								{
-									Name:      configVolume,
-									MountPath: configPath,
-								},
-							},
+							}, volumeMounts...),
 						},
 					},
 					Affinity: &corev1.Affinity{
@@ -580,18 +764,17 @@ func (r *Reconciler) addStatefulSet(protocol *storagev2beta1.MultiRaftProtocol,
 							},
 						},
 					},
-					ImagePullSecrets: protocol.Spec.ImagePullSecrets,
-					Volumes:          volumes,
+					TopologySpreadConstraints: newTopologySpreadConstraints(protocol, int(cluster.Spec.ClusterID)),
+					ImagePullSecrets:          protocol.Spec.ImagePullSecrets,
+					Volumes:                   volumes,
 				},
 			},
 			VolumeClaimTemplates: volumeClaimTemplates,
 		},
 	}
 
-	if err := controllerutil.SetControllerReference(protocol, set, r.scheme); err != nil {
-		return err
-	}
-	return r.client.Create(context.TODO(), set)
+	applyPodTemplate(protocol, &set.Spec.Template)
+	return set, nil
 }
 
 func (r *Reconciler) reconcileService(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
@@ -705,6 +888,15 @@ func getClusters(protocol *storagev2beta1.MultiRaftProtocol) []int {
 	for i := 0; i < numClusters; i++ {
 		clusters[i] = i + 1
 	}
+
+	// Clusters removed from the spec are retained while partitions are still placed on them
+	for _, partition := range protocol.Status.Placement {
+		if int(partition.ClusterID) > len(clusters) && int(partition.PartitionID) <= getNumPartitions(protocol) {
+			for i := len(clusters) + 1; i <= int(partition.ClusterID); i++ {
+				clusters = append(clusters, i)
+			}
+		}
+	}
 	return clusters
 }
 
@@ -718,11 +910,24 @@ func getNumPartitions(protocol *storagev2beta1.MultiRaftProtocol) int {
 
 func getPartitions(protocol *storagev2beta1.MultiRaftProtocol, clusterID int) []int {
 	numPartitions := getNumPartitions(protocol)
+	migration := protocol.Status.Migration
 	partitions := make([]int, 0, numPartitions)
-	for partitionID := 1; partitionID <= numPartitions; partitionID++ {
-		if getClusterForPartitionID(protocol, partitionID) == clusterID {
-			partitions = append(partitions, partitionID)
+	for _, partition := range protocol.Status.Placement {
+		if int(partition.ClusterID) != clusterID || int(partition.PartitionID) > numPartitions {
+			continue
 		}
+		// Members of a migrating partition are removed from the source cluster once leadership has moved
+		if migration != nil && migration.PartitionID == partition.PartitionID &&
+			migration.Phase == storagev2beta1.PartitionMigrationRemovingMembers {
+			continue
+		}
+		partitions = append(partitions, int(partition.PartitionID))
+	}
+
+	// Members of a migrating partition are added to the target cluster
+	if migration != nil && int(migration.TargetClusterID) == clusterID {
+		partitions = append(partitions, int(migration.PartitionID))
+		sort.Ints(partitions)
 	}
 	return partitions
 }
@@ -735,27 +940,47 @@ func getNumReplicas(protocol *storagev2beta1.MultiRaftProtocol) int {
 	return int(protocol.Spec.Replicas)
 }
 
-func getReplicas(protocol *storagev2beta1.MultiRaftProtocol, clusterID int) []string {
-	numReplicas := getNumReplicas(protocol)
+// getNumDeployedReplicas returns the number of replicas deployed for the given cluster
+func getNumDeployedReplicas(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) int {
+	if cluster.Status.Replicas == 0 {
+		return getNumReplicas(protocol)
+	}
+	if isScalingUp(cluster) {
+		return int(cluster.Status.Replicas) + 1
+	}
+	return int(cluster.Status.Replicas)
+}
+
+func getReplicas(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) []string {
+	numReplicas := getNumDeployedReplicas(protocol, cluster)
 	replicas := make([]string, numReplicas)
 	for i := 0; i < numReplicas; i++ {
-		replicas[i] = getPodName(protocol, clusterID, i)
+		replicas[i] = getPodName(protocol, int(cluster.Spec.ClusterID), i)
 	}
 	return replicas
 }
 
-func getMembers(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, partitionID int) []string {
-	numMembers := getNumReplicas(protocol)
-	members := make([]string, numMembers)
+// getMembers returns the IDs of the replicas that are members of the cluster's partitions
+func getMembers(cluster *storagev2beta1.RaftCluster) []int {
+	numMembers := int(cluster.Status.Replicas)
+	if isScalingUp(cluster) {
+		numMembers++
+	} else if cluster.Status.Scale != nil && cluster.Status.Scale.Phase == storagev2beta1.RaftClusterRemovingMember {
+		numMembers--
+	}
+	members := make([]int, numMembers)
 	for i := 0; i < numMembers; i++ {
-		members[i] = getMemberName(protocol, clusterID, partitionID, i)
+		members[i] = i
 	}
 	return members
 }
 
-// getClusterForPartitionID returns the cluster ID for the given partition ID
-func getClusterForPartitionID(protocol *storagev2beta1.MultiRaftProtocol, partitionID int) int {
-	return (partitionID % getNumClusters(protocol)) + 1
+// getMemberNodeID returns the Raft node ID for the given member
+func getMemberNodeID(member *storagev2beta1.RaftMember) int32 {
+	if member.Spec.NodeID == 0 {
+		return member.Spec.MemberID + 1
+	}
+	return member.Spec.NodeID
 }
 
 // getClusterResourceName returns the given resource name for the given cluster
@@ -816,6 +1041,13 @@ func getImage(protocol *storagev2beta1.MultiRaftProtocol) string {
 	return getDefaultImage()
 }
 
+func getImagePullPolicy(protocol *storagev2beta1.MultiRaftProtocol) corev1.PullPolicy {
+	if protocol.Spec.ImagePullPolicy != "" {
+		return protocol.Spec.ImagePullPolicy
+	}
+	return corev1.PullIfNotPresent
+}
+
 func getDefaultImage() string {
 	image := os.Getenv(defaultImageEnv)
 	if image == "" {
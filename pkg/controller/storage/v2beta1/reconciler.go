@@ -19,14 +19,17 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	"sync"
 	"time"
 
 	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,13 +37,15 @@ import (
 )
 
 func addRaftProtocolController(mgr manager.Manager) error {
+	reconciler := &Reconciler{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		events: newAggregatingRecorder(mgr.GetEventRecorderFor("atomix-raft-storage")),
+	}
+	reconciler.monitors = newMonitorManager(reconciler)
+	reconciler.statuses = newMemberStatusAccumulator(reconciler)
 	options := controller.Options{
-		Reconciler: &Reconciler{
-			client:  mgr.GetClient(),
-			scheme:  mgr.GetScheme(),
-			events:  mgr.GetEventRecorderFor("atomix-raft-storage"),
-			streams: make(map[string]func()),
-		},
+		Reconciler:  reconciler,
 		RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond*10, time.Second*5),
 	}
 
@@ -50,6 +55,16 @@ func addRaftProtocolController(mgr manager.Manager) error {
 		return err
 	}
 
+	// Write member statuses accumulated from Raft events at a bounded rate
+	if err := mgr.Add(reconciler.statuses); err != nil {
+		return err
+	}
+
+	// Export gauges built from the status of the Raft resources managed by the controller
+	if err := addStatusCollector(mgr.GetClient()); err != nil {
+		return err
+	}
+
 	// Watch for changes to the storage resource and enqueue Clusters that reference it
 	err = controller.Watch(&source.Kind{Type: &storagev2beta1.MultiRaftProtocol{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
@@ -73,6 +88,34 @@ func addRaftProtocolController(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
+
+	// Watch for changes to secondary resource NetworkPolicy
+	err = controller.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &storagev2beta1.MultiRaftProtocol{},
+		IsController: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to pods to reconnect disconnected monitors as soon as their pods are recreated or restarted
+	err = controller.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			reconciler.monitors.notify(e.Meta)
+		},
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			reconciler.monitors.notify(e.MetaNew)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the state of monitors
+	err = controller.Watch(&source.Channel{Source: reconciler.monitors.events}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -80,19 +123,23 @@ var _ reconcile.Reconciler = &Reconciler{}
 
 // Reconciler reconciles a MultiRaftProtocol object
 type Reconciler struct {
-	client  client.Client
-	scheme  *runtime.Scheme
-	events  record.EventRecorder
-	streams map[string]func()
-	mu      sync.Mutex
+	client   client.Client
+	scheme   *runtime.Scheme
+	events   record.EventRecorder
+	monitors *monitorManager
+	statuses *memberStatusAccumulator
 }
 
 // Reconcile reads that state of the cluster for a Cluster object and makes changes based on the state read
 // and what is in the Cluster.Spec
-func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+func (r *Reconciler) Reconcile(request reconcile.Request) (result reconcile.Result, err error) {
 	log.Info("Reconcile MultiRaftProtocol")
+	defer func(start time.Time) {
+		observeReconcile(multiRaftProtocolKind, start, err)
+	}(time.Now())
+
 	protocol := &storagev2beta1.MultiRaftProtocol{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, protocol)
+	err = r.client.Get(context.TODO(), request.NamespacedName, protocol)
 	if err != nil {
 		log.Error(err, "Reconcile MultiRaftProtocol")
 		if k8serrors.IsNotFound(err) {
@@ -101,6 +148,40 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{}, err
 	}
 
+	// Tear down the protocol's monitoring before the protocol is removed
+	if protocol.DeletionTimestamp != nil {
+		log.Info("Finalize MultiRaftProtocol")
+		err = r.finalizeProtocol(protocol)
+		if err != nil {
+			log.Error(err, "Finalize MultiRaftProtocol")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if !hasFinalizer(protocol, protocolFinalizer) {
+		controllerutil.AddFinalizer(protocol, protocolFinalizer)
+		err = r.client.Update(context.TODO(), protocol)
+		if err != nil {
+			log.Error(err, "Reconcile MultiRaftProtocol")
+			return reconcile.Result{}, err
+		}
+	}
+
+	log.Info("Reconcile VolumeClaims")
+	err = r.reconcileRetainedVolumeClaims(protocol)
+	if err != nil {
+		log.Error(err, "Reconcile VolumeClaims")
+		return reconcile.Result{}, err
+	}
+
+	log.Info("Reconcile Placement")
+	err = r.reconcilePlacement(protocol)
+	if err != nil {
+		log.Error(err, "Reconcile Placement")
+		return reconcile.Result{}, err
+	}
+
 	log.Info("Reconcile Clusters")
 	err = r.reconcileClusters(protocol)
 	if err != nil {
@@ -108,11 +189,31 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{}, err
 	}
 
+	log.Info("Reconcile Migration")
+	err = r.reconcileMigration(protocol)
+	if err != nil {
+		log.Error(err, "Reconcile Migration")
+		return reconcile.Result{}, err
+	}
+
 	log.Info("Reconcile Protocol")
 	err = r.reconcileStatus(protocol)
 	if err != nil {
 		log.Error(err, "Reconcile Protocol")
 		return reconcile.Result{}, err
 	}
+
+	// Scaling and upgrades progress through asynchronous membership changes, so poll until they're complete
+	updating, err := r.isUpdating(protocol)
+	if err != nil {
+		return reconcile.Result{}, err
+	} else if updating {
+		return reconcile.Result{RequeueAfter: updateRequeueInterval}, nil
+	}
+
+	// Leader changes aren't watched, so poll to check the balance of leaders
+	if protocol.Spec.LeaderBalancing != nil {
+		return reconcile.Result{RequeueAfter: getLeaderBalanceInterval(protocol)}, nil
+	}
 	return reconcile.Result{}, nil
 }
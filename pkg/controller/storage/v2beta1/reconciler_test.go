@@ -0,0 +1,211 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"github.com/atomix/atomix-raft-storage/pkg/apis"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+	"time"
+)
+
+// newTestReconciler returns a Reconciler backed by a fake client populated with the given objects
+func newTestReconciler(objects ...runtime.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	reconciler := &Reconciler{
+		client: fake.NewFakeClientWithScheme(scheme, objects...),
+		scheme: scheme,
+		events: record.NewFakeRecorder(100),
+	}
+	reconciler.monitors = newMonitorManager(reconciler)
+	reconciler.statuses = newMemberStatusAccumulator(reconciler)
+	return reconciler
+}
+
+// newTestProtocol returns a protocol with the given number of clusters, partitions and replicas
+// with its partitions placed using the default placement strategy
+func newTestProtocol(clusters, partitions, replicas int32) *storagev2beta1.MultiRaftProtocol {
+	protocol := &storagev2beta1.MultiRaftProtocol{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raft",
+			Namespace: "test",
+			UID:       "raft",
+		},
+		Spec: storagev2beta1.MultiRaftProtocolSpec{
+			Clusters:   clusters,
+			Partitions: partitions,
+			Replicas:   replicas,
+		},
+	}
+	placement := make([]storagev2beta1.PartitionPlacement, 0, partitions)
+	for partitionID := 1; partitionID <= int(partitions); partitionID++ {
+		placement = append(placement, storagev2beta1.PartitionPlacement{
+			PartitionID: int32(partitionID),
+			ClusterID:   int32(placePartition(protocol, placement, partitionID)),
+		})
+	}
+	protocol.Status.Placement = placement
+	return protocol
+}
+
+// reconcileTestProtocol reconciles the given protocol, stopping the monitors it starts once the test completes
+func reconcileTestProtocol(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol) reconcile.Result {
+	defer reconciler.stopMonitoringProtocol(protocol)
+	result, err := reconciler.Reconcile(reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: protocol.Namespace,
+			Name:      protocol.Name,
+		},
+	})
+	assert.NoError(t, err)
+	return result
+}
+
+// getTestObject gets the object with the given name in the test namespace, replacing the object's current state
+func getTestObject(t *testing.T, reconciler *Reconciler, name string, object runtime.Object) {
+	value := reflect.ValueOf(object).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	assert.NoError(t, reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: name}, object))
+}
+
+// getTestEvents returns the events recorded by the given reconciler
+func getTestEvents(reconciler *Reconciler) []string {
+	recorder := reconciler.events.(*record.FakeRecorder)
+	events := make([]string, 0)
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// newTestCluster returns a cluster of the given protocol with the given number of replicas
+func newTestCluster(protocol *storagev2beta1.MultiRaftProtocol, clusterID int32, replicas int32) *storagev2beta1.RaftCluster {
+	return &storagev2beta1.RaftCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getClusterName(protocol, int(clusterID)),
+			Namespace: protocol.Namespace,
+		},
+		Spec: storagev2beta1.RaftClusterSpec{
+			ClusterID: clusterID,
+		},
+		Status: storagev2beta1.RaftClusterStatus{
+			Replicas: replicas,
+		},
+	}
+}
+
+// newTestPod returns a pod of the given cluster with the given readiness
+func newTestPod(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getPodName(protocol, clusterID, podID),
+			Namespace: protocol.Namespace,
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: status,
+				},
+			},
+		},
+	}
+}
+
+// newTestPartition returns a partition of the given cluster in the given state
+func newTestPartition(protocol *storagev2beta1.MultiRaftProtocol, clusterID int32, partitionID int32, state storagev2beta1.RaftPartitionState) *storagev2beta1.RaftPartition {
+	return &storagev2beta1.RaftPartition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getPartitionName(protocol, int(clusterID), int(partitionID)),
+			Namespace: protocol.Namespace,
+		},
+		Spec: storagev2beta1.RaftPartitionSpec{
+			ClusterID:   clusterID,
+			PartitionID: partitionID,
+		},
+		Status: storagev2beta1.RaftPartitionStatus{
+			State: state,
+		},
+	}
+}
+
+// setTestPods creates or updates the pods of the given cluster with the given readiness
+func setTestPods(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, clusterID int, ready []bool) {
+	for podID, podReady := range ready {
+		pod := newTestPod(protocol, clusterID, podID, podReady)
+		current := &corev1.Pod{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, current)
+		if k8serrors.IsNotFound(err) {
+			assert.NoError(t, reconciler.client.Create(context.TODO(), pod))
+		} else {
+			assert.NoError(t, err)
+			current.Status = pod.Status
+			assert.NoError(t, reconciler.client.Status().Update(context.TODO(), current))
+		}
+	}
+}
+
+// setTestLeader reports the given pod as the leader of the given partition in a new term through a Raft event
+// received from the pod
+func setTestLeader(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, clusterID int, partitionID int, podID int) {
+	partition := &storagev2beta1.RaftPartition{}
+	getTestObject(t, reconciler, getPartitionName(protocol, clusterID, partitionID), partition)
+	term := uint64(1)
+	if partition.Status.Term != nil {
+		term = *partition.Status.Term + 1
+	}
+	reconciler.recordEvent(protocol, clusterID, podID, &storage.RaftEvent{
+		Timestamp: time.Now(),
+		Event: &storage.RaftEvent_LeaderUpdated{
+			LeaderUpdated: &storage.LeaderUpdatedEvent{
+				LeaderEvent: storage.LeaderEvent{
+					PartitionEvent: storage.PartitionEvent{
+						Partition: uint64(partitionID),
+					},
+					Term:   term,
+					Leader: getPodName(protocol, clusterID, podID),
+				},
+			},
+		},
+	})
+	reconciler.statuses.flush()
+}
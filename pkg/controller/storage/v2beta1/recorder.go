@@ -0,0 +1,131 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+// eventAggregationWindow is the window within which repeated events are aggregated
+const eventAggregationWindow = time.Minute
+
+// newAggregatingRecorder returns an event recorder that aggregates repeated events
+func newAggregatingRecorder(recorder record.EventRecorder) record.EventRecorder {
+	return &aggregatingRecorder{
+		recorder: recorder,
+		events:   make(map[aggregatedEventKey]*aggregatedEvent),
+	}
+}
+
+// aggregatingRecorder is an event recorder that emits the first event with a given type, reason and message for
+// an object immediately, and aggregates identical events for the object into a single event per window. Events
+// with different messages are never merged, so no information is dropped.
+type aggregatingRecorder struct {
+	recorder record.EventRecorder
+	events   map[aggregatedEventKey]*aggregatedEvent
+	mu       sync.Mutex
+}
+
+type aggregatedEventKey struct {
+	uid       types.UID
+	namespace string
+	name      string
+	eventType string
+	reason    string
+	message   string
+}
+
+type aggregatedEvent struct {
+	object runtime.Object
+	count  int
+}
+
+func (r *aggregatingRecorder) Event(object runtime.Object, eventType, reason, message string) {
+	if object == nil || reflect.ValueOf(object).IsNil() {
+		return
+	}
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		r.recorder.Event(object, eventType, reason, message)
+		return
+	}
+
+	key := aggregatedEventKey{
+		uid:       accessor.GetUID(),
+		namespace: accessor.GetNamespace(),
+		name:      accessor.GetName(),
+		eventType: eventType,
+		reason:    reason,
+		message:   message,
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if event, ok := r.events[key]; ok {
+		event.object = object
+		event.count++
+		return
+	}
+	r.events[key] = &aggregatedEvent{
+		object: object,
+	}
+	r.recorder.Event(object, eventType, reason, message)
+	time.AfterFunc(eventAggregationWindow, func() {
+		r.flush(key)
+	})
+}
+
+// flush emits the events aggregated for the given key in the last window
+func (r *aggregatingRecorder) flush(key aggregatedEventKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event, ok := r.events[key]
+	if !ok {
+		return
+	}
+	if event.count == 0 {
+		delete(r.events, key)
+		return
+	}
+	message := key.message
+	if event.count > 1 {
+		message = fmt.Sprintf("%s (%d times in the last %s)", key.message, event.count, eventAggregationWindow)
+	}
+	r.recorder.Event(event.object, key.eventType, key.reason, message)
+	event.count = 0
+	time.AfterFunc(eventAggregationWindow, func() {
+		r.flush(key)
+	})
+}
+
+func (r *aggregatingRecorder) Eventf(object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *aggregatingRecorder) PastEventf(object runtime.Object, timestamp metav1.Time, eventType, reason, messageFmt string, args ...interface{}) {
+	r.recorder.PastEventf(object, timestamp, eventType, reason, messageFmt, args...)
+}
+
+func (r *aggregatingRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventType, reason, messageFmt string, args ...interface{}) {
+	r.recorder.AnnotatedEventf(object, annotations, eventType, reason, messageFmt, args...)
+}
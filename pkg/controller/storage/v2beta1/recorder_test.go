@@ -0,0 +1,95 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"testing"
+)
+
+func TestAggregatingRecorder(t *testing.T) {
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		record   func(recorder record.EventRecorder)
+		expected []string
+	}{
+		{
+			name: "repeated event",
+			record: func(recorder record.EventRecorder) {
+				recorder.Event(newPod("raft-0"), "Warning", "Lagging", "Member is behind")
+				recorder.Event(newPod("raft-0"), "Warning", "Lagging", "Member is behind")
+			},
+			expected: []string{"Warning Lagging Member is behind"},
+		},
+		{
+			name: "different messages",
+			record: func(recorder record.EventRecorder) {
+				recorder.Eventf(newPod("raft-0"), "Normal", "LeaderTransferred", "Transferred leadership of partition %d", 1)
+				recorder.Eventf(newPod("raft-0"), "Normal", "LeaderTransferred", "Transferred leadership of partition %d", 2)
+			},
+			expected: []string{
+				"Normal LeaderTransferred Transferred leadership of partition 1",
+				"Normal LeaderTransferred Transferred leadership of partition 2",
+			},
+		},
+		{
+			name: "different objects",
+			record: func(recorder record.EventRecorder) {
+				recorder.Event(newPod("raft-0"), "Warning", "NotReady", "Partition is not ready")
+				recorder.Event(newPod("raft-1"), "Warning", "NotReady", "Partition is not ready")
+			},
+			expected: []string{
+				"Warning NotReady Partition is not ready",
+				"Warning NotReady Partition is not ready",
+			},
+		},
+		{
+			name: "different types",
+			record: func(recorder record.EventRecorder) {
+				recorder.Event(newPod("raft-0"), "Normal", "Snapshot", "Snapshot taken")
+				recorder.Event(newPod("raft-0"), "Warning", "Snapshot", "Snapshot taken")
+			},
+			expected: []string{
+				"Normal Snapshot Snapshot taken",
+				"Warning Snapshot Snapshot taken",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events := record.NewFakeRecorder(10)
+			test.record(newAggregatingRecorder(events))
+			close(events.Events)
+			var recorded []string
+			for event := range events.Events {
+				recorded = append(recorded, event)
+			}
+			assert.Equal(t, test.expected, recorded)
+		})
+	}
+}
@@ -0,0 +1,397 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	"google.golang.org/grpc"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	updateRequeueInterval = 5 * time.Second
+	adminTimeout          = 15 * time.Second
+)
+
+// isScalingUp returns whether a replica is being added to the given cluster
+func isScalingUp(cluster *storagev2beta1.RaftCluster) bool {
+	return cluster.Status.Scale != nil &&
+		(cluster.Status.Scale.Phase == storagev2beta1.RaftClusterAddingMember ||
+			cluster.Status.Scale.Phase == storagev2beta1.RaftClusterCatchingUp)
+}
+
+// initClusterStatus initializes the replica count of clusters created before scaling was supported
+func (r *Reconciler) initClusterStatus(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	if cluster.Status.Replicas > 0 {
+		return nil
+	}
+
+	replicas := int32(getNumReplicas(protocol))
+	statefulSet := &appsv1.StatefulSet{}
+	name := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
+	}
+	if err := r.client.Get(context.TODO(), name, statefulSet); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	} else if statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas > 0 {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	cluster.Status.Replicas = replicas
+	if cluster.Status.LastNodeID < replicas {
+		cluster.Status.LastNodeID = replicas
+	}
+	return r.client.Status().Update(context.TODO(), cluster)
+}
+
+// isUpdating returns whether any cluster for the given protocol is being scaled, upgraded or migrated
+func (r *Reconciler) isUpdating(protocol *storagev2beta1.MultiRaftProtocol) (bool, error) {
+	if protocol.Status.Migration != nil {
+		return true, nil
+	}
+	for partitionID, clusterID := range getRequestedMigrations(protocol) {
+		if servingID := getServingClusterID(protocol, partitionID); servingID != 0 && servingID != clusterID {
+			return true, nil
+		}
+	}
+	for _, clusterID := range getClusters(protocol) {
+		cluster, err := r.getCluster(protocol, clusterID)
+		if err != nil {
+			return false, err
+		}
+		if cluster.Status.Scale != nil || cluster.Status.Upgrade != nil || cluster.Status.Replicas != int32(getNumReplicas(protocol)) {
+			return true, nil
+		}
+		if cluster.Status.VolumeExpansion != nil && cluster.Status.VolumeExpansion.Phase != storagev2beta1.RaftVolumeExpansionFailed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reconcileScale adds or removes a single replica at a time until the cluster matches the desired replicas.
+// The progress of the operation is stored in the cluster status so it can be resumed after a restart.
+func (r *Reconciler) reconcileScale(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	if cluster.Status.Scale == nil {
+		replicas := int32(getNumReplicas(protocol))
+		if replicas == cluster.Status.Replicas {
+			return nil
+		}
+
+		// Scaling is deferred until the replica being upgraded has rejoined the cluster
+		// and until partitions have finished migrating to or from the cluster
+		if cluster.Status.Upgrade != nil || isMigrating(protocol, cluster) {
+			return nil
+		}
+
+		// Membership changes are only safe when all current replicas are available
+		for replicaID := 0; replicaID < int(cluster.Status.Replicas); replicaID++ {
+			ready, err := r.isReplicaReady(protocol, int(cluster.Spec.ClusterID), replicaID)
+			if err != nil {
+				return err
+			} else if !ready {
+				log.Info("Waiting for replicas to become ready before scaling", "Name", cluster.Name, "Namespace", cluster.Namespace)
+				return nil
+			}
+		}
+		return r.startScale(protocol, cluster, replicas)
+	}
+
+	switch cluster.Status.Scale.Phase {
+	case storagev2beta1.RaftClusterAddingMember:
+		return r.reconcileAddingMember(protocol, cluster)
+	case storagev2beta1.RaftClusterCatchingUp:
+		return r.reconcileCatchingUp(protocol, cluster)
+	case storagev2beta1.RaftClusterRemovingMember:
+		return r.reconcileRemovingMember(protocol, cluster)
+	case storagev2beta1.RaftClusterRemovingReplica:
+		return r.reconcileRemovingReplica(protocol, cluster)
+	}
+	return nil
+}
+
+func (r *Reconciler) startScale(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, replicas int32) error {
+	now := metav1.Now()
+	if replicas > cluster.Status.Replicas {
+		replicaID := int(cluster.Status.Replicas)
+		podName := getPodName(protocol, int(cluster.Spec.ClusterID), replicaID)
+		log.Info("Adding replica", "Name", cluster.Name, "Namespace", cluster.Namespace, "Replica", podName)
+		cluster.Status.LastNodeID++
+		cluster.Status.Scale = &storagev2beta1.RaftClusterScaleStatus{
+			Replicas:  replicas,
+			Replica:   podName,
+			Phase:     storagev2beta1.RaftClusterAddingMember,
+			StartTime: &now,
+		}
+		if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+			return err
+		}
+		r.events.Eventf(cluster, "Normal", "ScalingUp", "Adding replica %s", podName)
+		r.events.Eventf(protocol, "Normal", "ScalingUp", "Adding replica %s to cluster %d", podName, cluster.Spec.ClusterID)
+		return nil
+	}
+
+	replicaID := int(cluster.Status.Replicas) - 1
+	podName := getPodName(protocol, int(cluster.Spec.ClusterID), replicaID)
+	log.Info("Removing replica", "Name", cluster.Name, "Namespace", cluster.Namespace, "Replica", podName)
+	cluster.Status.Scale = &storagev2beta1.RaftClusterScaleStatus{
+		Replicas:  replicas,
+		Replica:   podName,
+		Phase:     storagev2beta1.RaftClusterRemovingMember,
+		StartTime: &now,
+	}
+	if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+		return err
+	}
+	r.events.Eventf(cluster, "Normal", "ScalingDown", "Removing replica %s", podName)
+	r.events.Eventf(protocol, "Normal", "ScalingDown", "Removing replica %s from cluster %d", podName, cluster.Spec.ClusterID)
+	return nil
+}
+
+// reconcileAddingMember adds the new replica to each partition as a non-voting learner
+func (r *Reconciler) reconcileAddingMember(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	replicaID := int(cluster.Status.Replicas)
+	for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+		member, err := r.getMember(protocol, int(cluster.Spec.ClusterID), partitionID, replicaID)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if member.Status.Type != nil {
+			continue
+		}
+
+		request := &storage.AddMemberRequest{
+			Partition: uint64(partitionID),
+			NodeID:    uint64(getMemberNodeID(member)),
+			Address:   fmt.Sprintf("%s:%d", getPodDNSName(protocol, int(cluster.Spec.ClusterID), replicaID), protocolPort),
+			Learner:   true,
+		}
+		if err := r.addRaftMember(protocol, cluster, partitionID, replicaID, request); err != nil {
+			log.Error(err, "Adding learner", "Name", member.Name, "Namespace", member.Namespace)
+			return nil
+		}
+
+		memberType := storagev2beta1.RaftLearner
+		if err := r.patchMemberStatus(member, storagev2beta1.RaftMemberStatus{Type: &memberType}, getMaxMemberLag(protocol)); err != nil {
+			return err
+		}
+		r.events.Eventf(member, "Normal", "LearnerAdded", "Added member as learner with node ID %d", request.NodeID)
+	}
+
+	cluster.Status.Scale.Phase = storagev2beta1.RaftClusterCatchingUp
+	return r.client.Status().Update(context.TODO(), cluster)
+}
+
+// reconcileCatchingUp promotes the new replica to a voting member of each partition once it has caught up
+func (r *Reconciler) reconcileCatchingUp(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	replicaID := int(cluster.Status.Replicas)
+	ready, err := r.isReplicaReady(protocol, int(cluster.Spec.ClusterID), replicaID)
+	if err != nil {
+		return err
+	} else if !ready {
+		return nil
+	}
+
+	for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+		member, err := r.getMember(protocol, int(cluster.Spec.ClusterID), partitionID, replicaID)
+		if err != nil {
+			return err
+		}
+		if member.Status.Type != nil && *member.Status.Type == storagev2beta1.RaftVoter {
+			continue
+		}
+
+		if err := r.syncRaftMember(protocol, cluster, partitionID, replicaID); err != nil {
+			log.Info("Waiting for learner to catch up", "Name", member.Name, "Namespace", member.Namespace, "Error", err.Error())
+			return nil
+		}
+
+		request := &storage.AddMemberRequest{
+			Partition: uint64(partitionID),
+			NodeID:    uint64(getMemberNodeID(member)),
+			Address:   fmt.Sprintf("%s:%d", getPodDNSName(protocol, int(cluster.Spec.ClusterID), replicaID), protocolPort),
+		}
+		if err := r.addRaftMember(protocol, cluster, partitionID, replicaID, request); err != nil {
+			log.Error(err, "Promoting learner", "Name", member.Name, "Namespace", member.Namespace)
+			return nil
+		}
+
+		memberType := storagev2beta1.RaftVoter
+		if err := r.patchMemberStatus(member, storagev2beta1.RaftMemberStatus{Type: &memberType}, getMaxMemberLag(protocol)); err != nil {
+			return err
+		}
+		r.events.Eventf(member, "Normal", "LearnerPromoted", "Promoted learner to voting member")
+	}
+
+	podName := cluster.Status.Scale.Replica
+	cluster.Status.Replicas++
+	cluster.Status.Scale = nil
+	if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+		return err
+	}
+	r.events.Eventf(cluster, "Normal", "ReplicaAdded", "Added replica %s", podName)
+	r.events.Eventf(protocol, "Normal", "ReplicaAdded", "Added replica %s to cluster %d", podName, cluster.Spec.ClusterID)
+	return nil
+}
+
+// reconcileRemovingMember removes the last replica from each partition before its pod is deleted
+func (r *Reconciler) reconcileRemovingMember(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	replicaID := int(cluster.Status.Replicas) - 1
+	for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+		member, err := r.getMember(protocol, int(cluster.Spec.ClusterID), partitionID, replicaID)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		request := &storage.RemoveMemberRequest{
+			Partition: uint64(partitionID),
+			NodeID:    uint64(getMemberNodeID(member)),
+		}
+		if err := r.removeRaftMember(protocol, cluster, partitionID, replicaID, request); err != nil {
+			log.Error(err, "Removing member", "Name", member.Name, "Namespace", member.Namespace)
+			return nil
+		}
+		if err := r.client.Delete(context.TODO(), member); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	cluster.Status.Replicas--
+	cluster.Status.Scale.Phase = storagev2beta1.RaftClusterRemovingReplica
+	return r.client.Status().Update(context.TODO(), cluster)
+}
+
+// reconcileRemovingReplica waits for the pod of a removed replica to be deleted
+func (r *Reconciler) reconcileRemovingReplica(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	podName := cluster.Status.Scale.Replica
+	_, err := r.getPod(protocol, int(cluster.Spec.ClusterID), int(cluster.Status.Replicas))
+	if err == nil {
+		return nil
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	r.stopMonitoringPod(protocol, podName)
+	cluster.Status.Scale = nil
+	if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+		return err
+	}
+	r.events.Eventf(cluster, "Normal", "ReplicaRemoved", "Removed replica %s", podName)
+	r.events.Eventf(protocol, "Normal", "ReplicaRemoved", "Removed replica %s from cluster %d", podName, cluster.Spec.ClusterID)
+	return nil
+}
+
+// addRaftMember requests that a voting replica add the given member to a partition
+func (r *Reconciler) addRaftMember(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, partitionID int, replicaID int, request *storage.AddMemberRequest) error {
+	return r.invokeAdmin(protocol, cluster, partitionID, replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+		_, err := client.AddMember(ctx, request)
+		return err
+	})
+}
+
+// removeRaftMember requests that a voting replica remove the given member from a partition
+func (r *Reconciler) removeRaftMember(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, partitionID int, replicaID int, request *storage.RemoveMemberRequest) error {
+	return r.invokeAdmin(protocol, cluster, partitionID, replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+		_, err := client.RemoveMember(ctx, request)
+		return err
+	})
+}
+
+// syncRaftMember waits for the given replica to apply all committed entries in a partition
+func (r *Reconciler) syncRaftMember(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, partitionID int, replicaID int) error {
+	return invokeReplicaAdmin(protocol, int(cluster.Spec.ClusterID), replicaID, func(ctx context.Context, client storage.RaftAdminClient) error {
+		_, err := client.SyncMember(ctx, &storage.SyncMemberRequest{
+			Partition: uint64(partitionID),
+		})
+		return err
+	})
+}
+
+// invokeAdmin invokes a membership change on the partition leader, falling back to the other voting replicas
+func (r *Reconciler) invokeAdmin(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, partitionID int, excludeID int, f func(context.Context, storage.RaftAdminClient) error) error {
+	partition, err := r.getPartition(protocol, int(cluster.Spec.ClusterID), partitionID)
+	if err != nil {
+		return err
+	}
+
+	replicaIDs := make([]int, 0, cluster.Status.Replicas)
+	for replicaID := 0; replicaID < int(cluster.Status.Replicas); replicaID++ {
+		if replicaID == excludeID {
+			continue
+		}
+		if partition.Status.Leader != nil && *partition.Status.Leader == getPodName(protocol, int(cluster.Spec.ClusterID), replicaID) {
+			replicaIDs = append([]int{replicaID}, replicaIDs...)
+		} else {
+			replicaIDs = append(replicaIDs, replicaID)
+		}
+	}
+
+	err = fmt.Errorf("no replicas available for partition %d", partitionID)
+	for _, replicaID := range replicaIDs {
+		conn, dialErr := dialAdmin(protocol, int(cluster.Spec.ClusterID), replicaID)
+		if dialErr != nil {
+			err = dialErr
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), adminTimeout)
+		err = f(ctx, storage.NewRaftAdminClient(conn))
+		cancel()
+		conn.Close()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// invokeReplicaAdmin invokes the admin service of the given replica
+func invokeReplicaAdmin(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, replicaID int, f func(context.Context, storage.RaftAdminClient) error) error {
+	conn, err := dialAdmin(protocol, clusterID, replicaID)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), adminTimeout)
+	defer cancel()
+	return f(ctx, storage.NewRaftAdminClient(conn))
+}
+
+// adminDialOptions are the options with which the admin services of replicas are dialed
+var adminDialOptions = []grpc.DialOption{grpc.WithInsecure()}
+
+// dialAdmin connects to the admin service of the given replica
+func dialAdmin(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, replicaID int) (*grpc.ClientConn, error) {
+	return grpc.Dial(
+		fmt.Sprintf("%s:%d", getPodDNSName(protocol, clusterID, replicaID), monitoringPort),
+		adminDialOptions...)
+}
@@ -0,0 +1,65 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		phase   storagev2beta1.RaftClusterScalePhase
+		members []int
+		scaleUp bool
+	}{
+		{
+			name:    "not scaling",
+			members: []int{0, 1, 2},
+		},
+		{
+			name:    "adding member",
+			phase:   storagev2beta1.RaftClusterAddingMember,
+			members: []int{0, 1, 2, 3},
+			scaleUp: true,
+		},
+		{
+			name:    "catching up",
+			phase:   storagev2beta1.RaftClusterCatchingUp,
+			members: []int{0, 1, 2, 3},
+			scaleUp: true,
+		},
+		{
+			name:    "removing member",
+			phase:   storagev2beta1.RaftClusterRemovingMember,
+			members: []int{0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(newTestProtocol(1, 1, 3), 1, 3)
+			if test.phase != "" {
+				cluster.Status.Scale = &storagev2beta1.RaftClusterScaleStatus{
+					Phase: test.phase,
+				}
+			}
+			assert.Equal(t, test.scaleUp, isScalingUp(cluster))
+			assert.Equal(t, test.members, getMembers(cluster))
+		})
+	}
+}
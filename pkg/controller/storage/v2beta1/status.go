@@ -19,13 +19,18 @@ import (
 	corev2beta1 "github.com/atomix/atomix-controller/pkg/apis/core/v2beta1"
 	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *Reconciler) reconcileStatus(protocol *storagev2beta1.MultiRaftProtocol) error {
 	state := storagev2beta1.MultiRaftProtocolReady
+	clusterConditions := make([][]storagev2beta1.Condition, 0, getNumClusters(protocol))
+	progressing := protocol.Status.Migration != nil
+	progressingReason := "Migrating"
 	for _, clusterID := range getClusters(protocol) {
 		cluster, err := r.getCluster(protocol, clusterID)
 		if err != nil {
@@ -34,10 +39,22 @@ func (r *Reconciler) reconcileStatus(protocol *storagev2beta1.MultiRaftProtocol)
 		if err := r.reconcileClusterStatus(protocol, cluster); err != nil {
 			return err
 		}
-		if cluster.Status.State != storagev2beta1.RaftClusterReady {
+		switch cluster.Status.State {
+		case storagev2beta1.RaftClusterNotReady:
 			state = storagev2beta1.MultiRaftProtocolNotReady
+		case storagev2beta1.RaftClusterDegraded:
+			if state == storagev2beta1.MultiRaftProtocolReady {
+				state = storagev2beta1.MultiRaftProtocolDegraded
+			}
+		}
+		if !progressing && isConditionTrue(cluster.Status.Conditions, storagev2beta1.ConditionProgressing) {
+			progressing = true
+			progressingReason = getCondition(cluster.Status.Conditions, storagev2beta1.ConditionProgressing).Reason
 		}
+		clusterConditions = append(clusterConditions, cluster.Status.Conditions)
 	}
+	conditions, conditionsChanged := setConditions(protocol.Status.Conditions,
+		newAggregateConditions("Clusters", clusterConditions, progressing, progressingReason, protocol.Generation))
 
 	replicas, err := r.getProtocolReplicas(protocol)
 	if err != nil {
@@ -49,7 +66,7 @@ func (r *Reconciler) reconcileStatus(protocol *storagev2beta1.MultiRaftProtocol)
 		return err
 	}
 
-	if protocol.Status.ProtocolStatus == nil ||
+	if protocol.Status.ProtocolStatus == nil || conditionsChanged || protocol.Status.State != state ||
 		isReplicasChanged(protocol.Status.Replicas, replicas) ||
 		isPartitionsChanged(protocol.Status.Partitions, partitions) {
 		var revision int64
@@ -62,10 +79,15 @@ func (r *Reconciler) reconcileStatus(protocol *storagev2beta1.MultiRaftProtocol)
 			revision++
 		}
 
-		if state == storagev2beta1.MultiRaftProtocolReady && protocol.Status.State != storagev2beta1.MultiRaftProtocolReady {
-			r.events.Eventf(protocol, "Normal", "Ready", "Protocol is ready")
-		} else if state == storagev2beta1.MultiRaftProtocolNotReady && protocol.Status.State != storagev2beta1.MultiRaftProtocolNotReady {
-			r.events.Eventf(protocol, "Warning", "NotReady", "Protocol is not ready")
+		if state != protocol.Status.State {
+			switch state {
+			case storagev2beta1.MultiRaftProtocolReady:
+				r.events.Eventf(protocol, "Normal", "Ready", "Protocol is ready")
+			case storagev2beta1.MultiRaftProtocolDegraded:
+				r.events.Eventf(protocol, "Warning", "Degraded", "Protocol is available but not fully replicated")
+			case storagev2beta1.MultiRaftProtocolNotReady:
+				r.events.Eventf(protocol, "Warning", "NotReady", "Protocol is not ready")
+			}
 		}
 
 		protocol.Status.ProtocolStatus = &corev2beta1.ProtocolStatus{
@@ -74,6 +96,7 @@ func (r *Reconciler) reconcileStatus(protocol *storagev2beta1.MultiRaftProtocol)
 			Partitions: partitions,
 		}
 		protocol.Status.State = state
+		protocol.Status.Conditions = conditions
 		return r.client.Status().Update(context.TODO(), protocol)
 	}
 	return nil
@@ -83,34 +106,64 @@ func (r *Reconciler) reconcileClusterStatus(protocol *storagev2beta1.MultiRaftPr
 	status := storagev2beta1.RaftClusterStatus{
 		State: storagev2beta1.RaftClusterReady,
 	}
-	for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+	partitionIDs := getPartitions(protocol, int(cluster.Spec.ClusterID))
+	partitionConditions := make([][]storagev2beta1.Condition, 0, len(partitionIDs))
+	for _, partitionID := range partitionIDs {
 		partition, err := r.getPartition(protocol, int(cluster.Spec.ClusterID), partitionID)
 		if err != nil {
+			// Partitions migrating to the cluster are created when the cluster is next reconciled
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
 			return err
 		}
 		if err := r.reconcilePartitionStatus(protocol, cluster, partition); err != nil {
 			return err
 		}
-		if partition.Status.State != storagev2beta1.RaftPartitionReady {
+		switch partition.Status.State {
+		case storagev2beta1.RaftPartitionNotReady:
 			status.State = storagev2beta1.RaftClusterNotReady
+		case storagev2beta1.RaftPartitionDegraded:
+			if status.State == storagev2beta1.RaftClusterReady {
+				status.State = storagev2beta1.RaftClusterDegraded
+			}
 		}
+		partitionConditions = append(partitionConditions, partition.Status.Conditions)
 	}
 
-	if status.State == storagev2beta1.RaftClusterReady && cluster.Status.State != storagev2beta1.RaftClusterReady {
-		r.events.Eventf(cluster, "Normal", "Ready", "Cluster is ready")
-	} else if status.State == storagev2beta1.RaftClusterNotReady && cluster.Status.State != storagev2beta1.RaftClusterNotReady {
-		r.events.Eventf(cluster, "Warning", "NotReady", "Cluster is not ready")
+	var progressingReason string
+	switch {
+	case cluster.Status.Scale != nil:
+		progressingReason = "Scaling"
+	case cluster.Status.Upgrade != nil:
+		progressingReason = "Upgrading"
+	case isMigrating(protocol, cluster):
+		progressingReason = "Migrating"
+	}
+	status.Conditions = newAggregateConditions("Partitions", partitionConditions, progressingReason != "", progressingReason, cluster.Generation)
+	status.Monitors = r.monitors.getStatus(protocol, cluster)
+
+	if status.State != cluster.Status.State {
+		switch status.State {
+		case storagev2beta1.RaftClusterReady:
+			r.events.Eventf(cluster, "Normal", "Ready", "Cluster is ready")
+		case storagev2beta1.RaftClusterDegraded:
+			r.events.Eventf(cluster, "Warning", "Degraded", "Cluster is available but not fully replicated")
+		case storagev2beta1.RaftClusterNotReady:
+			r.events.Eventf(cluster, "Warning", "NotReady", "Cluster is not ready")
+		}
 	}
 	return r.updateClusterStatus(cluster, status)
 }
 
 func (r *Reconciler) reconcilePartitionStatus(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, partition *storagev2beta1.RaftPartition) error {
 	status := storagev2beta1.RaftPartitionStatus{
-		State:  storagev2beta1.RaftPartitionReady,
 		Term:   partition.Status.Term,
 		Leader: partition.Status.Leader,
 	}
-	for memberID := range getMembers(protocol, int(cluster.Spec.ClusterID), int(partition.Spec.PartitionID)) {
+	memberIDs := getMembers(cluster)
+	members := make([]*storagev2beta1.RaftMember, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
 		member, err := r.getMember(protocol, int(cluster.Spec.ClusterID), int(partition.Spec.PartitionID), memberID)
 		if err != nil {
 			return err
@@ -118,9 +171,7 @@ func (r *Reconciler) reconcilePartitionStatus(protocol *storagev2beta1.MultiRaft
 		if err := r.reconcileMemberStatus(protocol, cluster, partition, member); err != nil {
 			return err
 		}
-		if member.Status.State == nil || *member.Status.State != storagev2beta1.RaftMemberReady {
-			status.State = storagev2beta1.RaftPartitionNotReady
-		}
+		members = append(members, member)
 		if member.Status.Term != nil && (status.Term == nil || *member.Status.Term > *status.Term) {
 			status.Term = member.Status.Term
 			status.Leader = nil
@@ -132,10 +183,28 @@ func (r *Reconciler) reconcilePartitionStatus(protocol *storagev2beta1.MultiRaft
 		}
 	}
 
-	if status.State == storagev2beta1.RaftPartitionReady && partition.Status.State != storagev2beta1.RaftPartitionReady {
-		r.events.Eventf(partition, "Normal", "Ready", "Partition is ready")
-	} else if status.State == storagev2beta1.RaftPartitionNotReady && partition.Status.State != storagev2beta1.RaftPartitionNotReady {
-		r.events.Eventf(partition, "Warning", "NotReady", "Partition is not ready")
+	migrating := protocol.Status.Migration != nil && protocol.Status.Migration.PartitionID == partition.Spec.PartitionID
+	status.Conditions = newPartitionConditions(partition, members, status.Leader, migrating)
+
+	// Partitions remain available while a quorum of voting members and the leader are ready
+	switch {
+	case isConditionTrue(status.Conditions, storagev2beta1.ConditionReady):
+		status.State = storagev2beta1.RaftPartitionReady
+	case isAvailable(status.Conditions):
+		status.State = storagev2beta1.RaftPartitionDegraded
+	default:
+		status.State = storagev2beta1.RaftPartitionNotReady
+	}
+
+	if status.State != partition.Status.State {
+		switch status.State {
+		case storagev2beta1.RaftPartitionReady:
+			r.events.Eventf(partition, "Normal", "Ready", "Partition is ready")
+		case storagev2beta1.RaftPartitionDegraded:
+			r.events.Eventf(partition, "Warning", "Degraded", "Partition is available but not fully replicated")
+		case storagev2beta1.RaftPartitionNotReady:
+			r.events.Eventf(partition, "Warning", "NotReady", "Partition is not ready")
+		}
 	}
 	return r.updatePartitionStatus(partition, status)
 }
@@ -146,18 +215,28 @@ func (r *Reconciler) reconcileMemberStatus(protocol *storagev2beta1.MultiRaftPro
 		return err
 	}
 
+	// Members of the initial replicas are voting members of the partition
+	if member.Status.Type == nil && !member.Spec.Join {
+		memberType := storagev2beta1.RaftVoter
+		if err := r.patchMemberStatus(member, storagev2beta1.RaftMemberStatus{
+			Type: &memberType,
+		}, getMaxMemberLag(protocol)); err != nil {
+			return err
+		}
+	}
+
 	if ready && (member.Status.State == nil || *member.Status.State != storagev2beta1.RaftMemberReady) {
 		state := storagev2beta1.RaftMemberReady
-		return r.updateMemberStatus(member, storagev2beta1.RaftMemberStatus{
+		return r.patchMemberStatus(member, storagev2beta1.RaftMemberStatus{
 			State: &state,
-		})
+		}, getMaxMemberLag(protocol))
 	} else if !ready && (member.Status.State == nil || *member.Status.State != storagev2beta1.RaftMemberNotReady) {
 		state := storagev2beta1.RaftMemberNotReady
-		return r.updateMemberStatus(member, storagev2beta1.RaftMemberStatus{
+		return r.patchMemberStatus(member, storagev2beta1.RaftMemberStatus{
 			State: &state,
-		})
+		}, getMaxMemberLag(protocol))
 	}
-	return nil
+	return r.patchMemberStatus(member, storagev2beta1.RaftMemberStatus{}, getMaxMemberLag(protocol))
 }
 
 func isReplicasSame(a, b []corev2beta1.ReplicaStatus) bool {
@@ -241,7 +320,11 @@ func isPartitionsChanged(a, b []corev2beta1.PartitionStatus) bool {
 func (r *Reconciler) getProtocolReplicas(protocol *storagev2beta1.MultiRaftProtocol) ([]corev2beta1.ReplicaStatus, error) {
 	replicas := make([]corev2beta1.ReplicaStatus, 0, getNumReplicas(protocol)*getNumClusters(protocol))
 	for _, clusterID := range getClusters(protocol) {
-		for replicaID, replicaName := range getReplicas(protocol, clusterID) {
+		cluster, err := r.getCluster(protocol, clusterID)
+		if err != nil {
+			return nil, err
+		}
+		for replicaID, replicaName := range getReplicas(protocol, cluster) {
 			replicaReady, err := r.isReplicaReady(protocol, clusterID, replicaID)
 			if err != nil {
 				return nil, err
@@ -264,26 +347,49 @@ func (r *Reconciler) getProtocolReplicas(protocol *storagev2beta1.MultiRaftProto
 func (r *Reconciler) getProtocolPartitions(protocol *storagev2beta1.MultiRaftProtocol) ([]corev2beta1.PartitionStatus, error) {
 	partitions := make([]corev2beta1.PartitionStatus, 0, protocol.Spec.Partitions)
 	for _, clusterID := range getClusters(protocol) {
+		cluster, err := r.getCluster(protocol, clusterID)
+		if err != nil {
+			return nil, err
+		}
+		memberIDs := getMembers(cluster)
 		for _, partitionID := range getPartitions(protocol, clusterID) {
-			partitionReady := true
-			for replicaID := range getReplicas(protocol, clusterID) {
-				replicaReady, err := r.isReplicaReady(protocol, clusterID, replicaID)
-				if err != nil {
-					return nil, err
-				} else if !replicaReady {
-					partitionReady = false
-				}
+			// Drivers follow a migrating partition once leadership has moved to the target cluster
+			if getServingClusterID(protocol, partitionID) != clusterID {
+				continue
+			}
+			partition, err := r.getPartition(protocol, clusterID, partitionID)
+			if err != nil {
+				return nil, err
+			}
+			replicas := make([]string, 0, len(memberIDs))
+			for _, replicaID := range memberIDs {
+				replicas = append(replicas, getPodName(protocol, clusterID, replicaID))
 			}
 			partitions = append(partitions, corev2beta1.PartitionStatus{
 				ID:       uint32(partitionID),
-				Replicas: getReplicas(protocol, clusterID),
-				Ready:    partitionReady,
+				Replicas: replicas,
+				Ready:    isPartitionServing(partition.Status.State),
 			})
 		}
 	}
 	return partitions, nil
 }
 
+// isPartitionServing returns whether a partition in the given state is reported ready to clients
+func isPartitionServing(state storagev2beta1.RaftPartitionState) bool {
+	switch state {
+	case storagev2beta1.RaftPartitionReady:
+		return true
+	case storagev2beta1.RaftPartitionDegraded:
+		// A degraded partition still has a ready leader and a quorum of ready voters, so it commits writes and
+		// serves reads while its other members recover. Reporting it unready would fail client requests the
+		// partition can still serve.
+		return true
+	default:
+		return false
+	}
+}
+
 func (r *Reconciler) isReplicaReady(protocol *storagev2beta1.MultiRaftProtocol, cluster int, replica int) (bool, error) {
 	podName := types.NamespacedName{
 		Namespace: protocol.Namespace,
@@ -306,11 +412,19 @@ func (r *Reconciler) isReplicaReady(protocol *storagev2beta1.MultiRaftProtocol,
 }
 
 func (r *Reconciler) updateClusterStatus(cluster *storagev2beta1.RaftCluster, status storagev2beta1.RaftClusterStatus) error {
-	updated := true
+	updated := false
 	if cluster.Status.State != status.State {
 		cluster.Status.State = status.State
 		updated = true
 	}
+	if !apiequality.Semantic.DeepEqual(cluster.Status.Monitors, status.Monitors) {
+		cluster.Status.Monitors = status.Monitors
+		updated = true
+	}
+	if conditions, changed := setConditions(cluster.Status.Conditions, status.Conditions); changed {
+		cluster.Status.Conditions = conditions
+		updated = true
+	}
 	if updated {
 		return r.client.Status().Update(context.TODO(), cluster)
 	}
@@ -318,13 +432,13 @@ func (r *Reconciler) updateClusterStatus(cluster *storagev2beta1.RaftCluster, st
 }
 
 func (r *Reconciler) updatePartitionStatus(partition *storagev2beta1.RaftPartition, status storagev2beta1.RaftPartitionStatus) error {
-	updated := true
+	updated := false
 	if status.Term != nil && (partition.Status.Term == nil || *status.Term > *partition.Status.Term) {
 		partition.Status.Term = status.Term
 		partition.Status.Leader = nil
 		updated = true
 	}
-	if status.Leader != nil && (partition.Status.Leader == nil || partition.Status.Leader != status.Leader) {
+	if status.Leader != nil && (partition.Status.Leader == nil || *partition.Status.Leader != *status.Leader) {
 		partition.Status.Leader = status.Leader
 		updated = true
 	}
@@ -332,45 +446,105 @@ func (r *Reconciler) updatePartitionStatus(partition *storagev2beta1.RaftPartiti
 		partition.Status.State = status.State
 		updated = true
 	}
+	if conditions, changed := setConditions(partition.Status.Conditions, status.Conditions); changed {
+		partition.Status.Conditions = conditions
+		updated = true
+	}
 	if updated {
 		return r.client.Status().Update(context.TODO(), partition)
 	}
 	return nil
 }
 
-func (r *Reconciler) updateMemberStatus(member *storagev2beta1.RaftMember, status storagev2beta1.RaftMemberStatus) error {
-	updated := true
-	if status.Term != nil && (member.Status.Term == nil || *status.Term > *member.Status.Term) {
-		member.Status.Term = status.Term
-		member.Status.Leader = nil
+// updateMemberStatus merges the given status reported by Raft events into the member's status and recomputes its
+// conditions. The status is written with an update rather than a merge patch, which would replace the whole list of
+// conditions, so the member's resourceVersion rejects the write if the reconciler patched the member since it was read.
+func (r *Reconciler) updateMemberStatus(member *storagev2beta1.RaftMember, status storagev2beta1.RaftMemberStatus, maxLag uint64) error {
+	updated := mergeMemberStatus(&member.Status, status)
+	if conditions, changed := setConditions(member.Status.Conditions, newMemberConditions(member, maxLag)); changed {
+		member.Status.Conditions = conditions
 		updated = true
 	}
-	if status.Leader != nil && (member.Status.Leader == nil || member.Status.Leader != status.Leader) {
-		member.Status.Leader = status.Leader
+	if updated {
+		return r.client.Status().Update(context.TODO(), member)
+	}
+	return nil
+}
+
+// patchMemberStatus merges the given status into the member's status and recomputes its conditions, writing only the
+// fields that changed with a merge patch. The reconciler never changes the fields reported by Raft events, which are
+// written only by the member status accumulator, so the patch can't overwrite a term, leader or lag written since the
+// member was read. Lagging and disk pressure conditions computed from a stale read are corrected by the next write.
+func (r *Reconciler) patchMemberStatus(member *storagev2beta1.RaftMember, status storagev2beta1.RaftMemberStatus, maxLag uint64) error {
+	original := member.DeepCopy()
+	updated := mergeMemberStatus(&member.Status, status)
+	if conditions, changed := setConditions(member.Status.Conditions, newMemberConditions(member, maxLag)); changed {
+		member.Status.Conditions = conditions
 		updated = true
 	}
-	if status.State != nil && (member.Status.State == nil || member.Status.State != status.State) {
-		member.Status.State = status.State
+	if updated {
+		return r.client.Status().Patch(context.TODO(), member, client.MergeFrom(original))
+	}
+	return nil
+}
+
+// mergeMemberStatus merges the given status into the target status, returning whether the target changed
+func mergeMemberStatus(target *storagev2beta1.RaftMemberStatus, status storagev2beta1.RaftMemberStatus) bool {
+	updated := false
+	if status.Term != nil && (target.Term == nil || *status.Term > *target.Term) {
+		target.Term = status.Term
+		target.Leader = nil
 		updated = true
 	}
-	if status.Role != nil && (member.Status.Role == nil || *member.Status.Role != *status.Role) {
-		member.Status.Role = status.Role
+	// Leaders reported for past terms are ignored
+	if status.Leader != nil && (status.Term == nil || target.Term == nil || *status.Term >= *target.Term) &&
+		(target.Leader == nil || *target.Leader != *status.Leader) {
+		target.Leader = status.Leader
 		updated = true
 	}
-	if status.LastUpdated != nil && (member.Status.LastUpdated == nil || status.LastUpdated.After(member.Status.LastUpdated.Time)) {
-		member.Status.LastUpdated = status.LastUpdated
+	if status.State != nil && (target.State == nil || *target.State != *status.State) {
+		target.State = status.State
 		updated = true
 	}
-	if status.LastSnapshotIndex != nil && (member.Status.LastSnapshotIndex == nil || *status.LastSnapshotIndex > *member.Status.LastSnapshotIndex) {
-		member.Status.LastSnapshotIndex = status.LastSnapshotIndex
+	if status.Type != nil && (target.Type == nil || *target.Type != *status.Type) {
+		target.Type = status.Type
 		updated = true
 	}
-	if status.LastSnapshotTime != nil && (member.Status.LastSnapshotTime == nil || status.LastSnapshotTime.After(member.Status.LastSnapshotTime.Time)) {
-		member.Status.LastSnapshotTime = status.LastSnapshotTime
+	if status.Role != nil && (target.Role == nil || *target.Role != *status.Role) {
+		target.Role = status.Role
 		updated = true
 	}
-	if updated {
-		return r.client.Status().Update(context.TODO(), member)
+	if status.LastUpdated != nil && (target.LastUpdated == nil || status.LastUpdated.After(target.LastUpdated.Time)) {
+		target.LastUpdated = status.LastUpdated
+		updated = true
 	}
-	return nil
+	if status.LastSnapshotIndex != nil && (target.LastSnapshotIndex == nil || *status.LastSnapshotIndex > *target.LastSnapshotIndex) {
+		target.LastSnapshotIndex = status.LastSnapshotIndex
+		updated = true
+	}
+	if status.LastSnapshotTime != nil && (target.LastSnapshotTime == nil || status.LastSnapshotTime.After(target.LastSnapshotTime.Time)) {
+		target.LastSnapshotTime = status.LastSnapshotTime
+		updated = true
+	}
+	if status.EstimatedAppliedIndex != nil && (target.EstimatedAppliedIndex == nil || *target.EstimatedAppliedIndex != *status.EstimatedAppliedIndex) {
+		target.EstimatedAppliedIndex = status.EstimatedAppliedIndex
+		updated = true
+	}
+	if status.SnapshotSize != nil && (target.SnapshotSize == nil || *target.SnapshotSize != *status.SnapshotSize) {
+		target.SnapshotSize = status.SnapshotSize
+		updated = true
+	}
+	if status.LogSize != nil && (target.LogSize == nil || *target.LogSize != *status.LogSize) {
+		target.LogSize = status.LogSize
+		updated = true
+	}
+	if status.Lag != nil && (target.Lag == nil || *target.Lag != *status.Lag) {
+		target.Lag = status.Lag
+		updated = true
+	}
+	if status.DiskUsage != nil && (target.DiskUsage == nil || *target.DiskUsage != *status.DiskUsage) {
+		target.DiskUsage = status.DiskUsage
+		updated = true
+	}
+	return updated
 }
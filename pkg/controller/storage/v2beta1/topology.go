@@ -0,0 +1,152 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const defaultTopologyKey = "topology.kubernetes.io/zone"
+
+// getTopologyKey returns the node label identifying failure domains for the given protocol
+func getTopologyKey(protocol *storagev2beta1.MultiRaftProtocol) string {
+	if protocol.Spec.TopologySpread == nil || protocol.Spec.TopologySpread.TopologyKey == "" {
+		return defaultTopologyKey
+	}
+	return protocol.Spec.TopologySpread.TopologyKey
+}
+
+// newTopologySpreadConstraints returns the topology spread constraints for the pods in the given cluster
+func newTopologySpreadConstraints(protocol *storagev2beta1.MultiRaftProtocol, clusterID int) []corev1.TopologySpreadConstraint {
+	spread := protocol.Spec.TopologySpread
+	if spread == nil {
+		return nil
+	}
+
+	maxSkew := spread.MaxSkew
+	if maxSkew == 0 {
+		maxSkew = 1
+	}
+	whenUnsatisfiable := spread.WhenUnsatisfiable
+	if whenUnsatisfiable == "" {
+		whenUnsatisfiable = corev1.DoNotSchedule
+	}
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           maxSkew,
+			TopologyKey:       getTopologyKey(protocol),
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: newClusterLabels(protocol, clusterID),
+			},
+		},
+	}
+}
+
+// reconcileTopology checks the failure domains in which the cluster's replicas are running and sets
+// a condition when a majority of the replicas run in a single failure domain
+func (r *Reconciler) reconcileTopology(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	var condition storagev2beta1.Condition
+	if protocol.Spec.TopologySpread == nil {
+		if getCondition(cluster.Status.Conditions, storagev2beta1.RaftClusterMajorityInFailureDomain) == nil {
+			return nil
+		}
+		condition = storagev2beta1.Condition{
+			Type:    storagev2beta1.RaftClusterMajorityInFailureDomain,
+			Status:  corev1.ConditionUnknown,
+			Reason:  "TopologySpreadDisabled",
+			Message: "Replica placement is not checked when topology spreading is disabled",
+		}
+	} else {
+		domains, err := r.getFailureDomains(protocol, cluster)
+		if err != nil {
+			return err
+		}
+
+		members := getMembers(cluster)
+		counts := make(map[string]int)
+		for _, domain := range domains {
+			counts[domain]++
+		}
+
+		condition = storagev2beta1.Condition{
+			Type:    storagev2beta1.RaftClusterMajorityInFailureDomain,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ReplicasSpread",
+			Message: fmt.Sprintf("No %s holds a majority of replicas", getTopologyKey(protocol)),
+		}
+		for domain, count := range counts {
+			if count > len(members)/2 {
+				condition = storagev2beta1.Condition{
+					Type:    storagev2beta1.RaftClusterMajorityInFailureDomain,
+					Status:  corev1.ConditionTrue,
+					Reason:  "MajorityInFailureDomain",
+					Message: fmt.Sprintf("%d of %d replicas of partitions %v run in %s %s", count, len(members), getPartitions(protocol, int(cluster.Spec.ClusterID)), getTopologyKey(protocol), domain),
+				}
+				break
+			}
+		}
+	}
+
+	conditions, changed := setCondition(cluster.Status.Conditions, condition)
+	if !changed {
+		return nil
+	}
+	cluster.Status.Conditions = conditions
+	if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+		return err
+	}
+	if condition.Status == corev1.ConditionTrue {
+		r.events.Eventf(cluster, "Warning", "MajorityInFailureDomain", condition.Message)
+	}
+	return nil
+}
+
+// getFailureDomains returns the failure domain of each scheduled member replica in the given cluster
+func (r *Reconciler) getFailureDomains(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) (map[int]string, error) {
+	topologyKey := getTopologyKey(protocol)
+	domains := make(map[int]string)
+	for _, replicaID := range getMembers(cluster) {
+		pod, err := r.getPod(protocol, int(cluster.Spec.ClusterID), replicaID)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+
+		node := &corev1.Node{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if domain, ok := node.Labels[topologyKey]; ok {
+			domains[replicaID] = domain
+		}
+	}
+	return domains, nil
+}
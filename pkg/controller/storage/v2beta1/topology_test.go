@@ -0,0 +1,142 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+// newTopologyTestReconciler returns a reconciler for a reconciled single cluster protocol whose pods
+// are scheduled to nodes in the given zones. Pods in an empty zone are not scheduled.
+func newTopologyTestReconciler(t *testing.T, protocol *storagev2beta1.MultiRaftProtocol, zones []string) *Reconciler {
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	for podID, zone := range zones {
+		pod := newTestPod(protocol, 1, podID, true)
+		if zone != "" {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("node-%d", podID),
+					Labels: map[string]string{
+						getTopologyKey(protocol): zone,
+					},
+				},
+			}
+			assert.NoError(t, reconciler.client.Create(context.TODO(), node))
+			pod.Spec.NodeName = node.Name
+		}
+		assert.NoError(t, reconciler.client.Create(context.TODO(), pod))
+	}
+	return reconciler
+}
+
+func TestReconcileTopologySpreadConstraints(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	protocol.Spec.TopologySpread = &storagev2beta1.RaftTopologySpread{
+		TopologyKey: "example.com/rack",
+	}
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	statefulSet := &appsv1.StatefulSet{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), statefulSet)
+	constraints := statefulSet.Spec.Template.Spec.TopologySpreadConstraints
+	assert.Len(t, constraints, 1)
+	assert.Equal(t, "example.com/rack", constraints[0].TopologyKey)
+	assert.Equal(t, int32(1), constraints[0].MaxSkew)
+	assert.Equal(t, corev1.DoNotSchedule, constraints[0].WhenUnsatisfiable)
+	assert.Equal(t, newClusterLabels(protocol, 1), constraints[0].LabelSelector.MatchLabels)
+}
+
+func TestReconcileTopology(t *testing.T) {
+	tests := []struct {
+		name   string
+		zones  []string
+		status corev1.ConditionStatus
+		reason string
+	}{
+		{
+			name:   "replicas spread",
+			zones:  []string{"a", "b", "c"},
+			status: corev1.ConditionFalse,
+			reason: "ReplicasSpread",
+		},
+		{
+			name:   "majority in zone",
+			zones:  []string{"a", "b", "a"},
+			status: corev1.ConditionTrue,
+			reason: "MajorityInFailureDomain",
+		},
+		{
+			name:   "majority of scheduled replicas in zone",
+			zones:  []string{"a", "a", ""},
+			status: corev1.ConditionTrue,
+			reason: "MajorityInFailureDomain",
+		},
+		{
+			name:   "minority of replicas scheduled in zone",
+			zones:  []string{"a", "b", ""},
+			status: corev1.ConditionFalse,
+			reason: "ReplicasSpread",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(1, 1, 3)
+			protocol.Spec.TopologySpread = &storagev2beta1.RaftTopologySpread{}
+			reconciler := newTopologyTestReconciler(t, protocol, test.zones)
+			reconcileTestProtocol(t, reconciler, protocol)
+
+			cluster := &storagev2beta1.RaftCluster{}
+			getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+			condition := getCondition(cluster.Status.Conditions, storagev2beta1.RaftClusterMajorityInFailureDomain)
+			assert.NotNil(t, condition)
+			assert.Equal(t, test.status, condition.Status)
+			assert.Equal(t, test.reason, condition.Reason)
+
+			events := getTestEvents(reconciler)
+			if test.status == corev1.ConditionTrue {
+				assert.Contains(t, events, "Warning MajorityInFailureDomain "+condition.Message)
+			}
+		})
+	}
+}
+
+func TestReconcileTopologyDisabled(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	protocol.Spec.TopologySpread = &storagev2beta1.RaftTopologySpread{}
+	reconciler := newTopologyTestReconciler(t, protocol, []string{"a", "a", "a"})
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	getTestObject(t, reconciler, protocol.Name, protocol)
+	protocol.Spec.TopologySpread = nil
+	assert.NoError(t, reconciler.client.Update(context.TODO(), protocol))
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	cluster := &storagev2beta1.RaftCluster{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+	condition := getCondition(cluster.Status.Conditions, storagev2beta1.RaftClusterMajorityInFailureDomain)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionUnknown, condition.Status)
+	assert.Equal(t, "TopologySpreadDisabled", condition.Reason)
+}
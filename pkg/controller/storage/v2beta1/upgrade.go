@@ -0,0 +1,349 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// upgradeRestartTimeout is the time a restarted replica is counted as available while waiting for its pod to become ready
+const upgradeRestartTimeout = 10 * time.Minute
+
+// reconcileUpgrade restarts outdated pods one at a time, moving leadership away from each pod before
+// it's deleted and waiting for it to rejoin and catch up on all partitions before moving on.
+func (r *Reconciler) reconcileUpgrade(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	// Upgrades are deferred until scaling and migration operations are complete
+	if cluster.Status.Scale != nil || (cluster.Status.Upgrade == nil && isMigrating(protocol, cluster)) {
+		return nil
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	name := types.NamespacedName{
+		Namespace: protocol.Namespace,
+		Name:      getClusterName(protocol, int(cluster.Spec.ClusterID)),
+	}
+	if err := r.client.Get(context.TODO(), name, statefulSet); err != nil {
+		return err
+	}
+
+	// Wait for the StatefulSet controller to compute the revision for the latest template
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation || statefulSet.Status.UpdateRevision == "" {
+		return nil
+	}
+	revision := statefulSet.Status.UpdateRevision
+
+	quorum, err := r.hasUpgradeQuorum(protocol, cluster)
+	if err != nil {
+		return err
+	}
+	if !quorum {
+		if cluster.Status.Upgrade == nil {
+			return nil
+		}
+		message := fmt.Sprintf("Upgrade of replica %s paused until quorum is restored", cluster.Status.Upgrade.Replica)
+		return r.pauseUpgrade(cluster, "QuorumLost", message, "a partition lost quorum")
+	}
+
+	// A restarted replica that doesn't become ready stalls the upgrade even if quorum is maintained
+	if isUpgradeRestartExpired(cluster) {
+		replicaID, err := r.getUpgradeReplicaID(protocol, cluster)
+		if err != nil {
+			return err
+		}
+		ready, err := r.isReplicaReady(protocol, int(cluster.Spec.ClusterID), replicaID)
+		if err != nil {
+			return err
+		} else if !ready {
+			message := fmt.Sprintf("Replica %s did not become ready within %s of restarting", cluster.Status.Upgrade.Replica, upgradeRestartTimeout)
+			return r.pauseUpgrade(cluster, "RestartTimedOut", message, fmt.Sprintf("replica %s did not become ready", cluster.Status.Upgrade.Replica))
+		}
+	}
+
+	if condition := getCondition(cluster.Status.Conditions, storagev2beta1.RaftClusterUpgradePaused); condition != nil && condition.Status == corev1.ConditionTrue {
+		reason, message := "QuorumRestored", "quorum restored"
+		if condition.Reason == "RestartTimedOut" {
+			reason, message = "ReplicaReady", "restarted replica is ready"
+		}
+		cluster.Status.Conditions, _ = setCondition(cluster.Status.Conditions, storagev2beta1.Condition{
+			Type:    storagev2beta1.RaftClusterUpgradePaused,
+			Status:  corev1.ConditionFalse,
+			Reason:  reason,
+			Message: "Upgrade resumed",
+		})
+		if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+			return err
+		}
+		r.events.Eventf(cluster, "Normal", "UpgradeResumed", "Upgrade resumed: %s", message)
+	}
+
+	if cluster.Status.Upgrade != nil {
+		switch cluster.Status.Upgrade.Phase {
+		case storagev2beta1.RaftClusterTransferringLeadership:
+			return r.reconcileTransferringLeadership(protocol, cluster)
+		case storagev2beta1.RaftClusterRestarting:
+			return r.reconcileRestarting(protocol, cluster, revision)
+		case storagev2beta1.RaftClusterRejoining:
+			return r.reconcileRejoining(protocol, cluster)
+		}
+		return nil
+	}
+
+	// Upgrade the highest ordinal first, matching the order of StatefulSet rolling updates
+	replicaID := -1
+	for i := int(cluster.Status.Replicas) - 1; i >= 0; i-- {
+		pod, err := r.getPod(protocol, int(cluster.Spec.ClusterID), i)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if pod.Labels[appsv1.StatefulSetRevisionLabel] != revision {
+			replicaID = i
+			break
+		}
+	}
+	if replicaID == -1 {
+		return nil
+	}
+
+	// Only restart a pod when every replica is available
+	for i := 0; i < int(cluster.Status.Replicas); i++ {
+		ready, err := r.isReplicaReady(protocol, int(cluster.Spec.ClusterID), i)
+		if err != nil {
+			return err
+		} else if !ready {
+			return nil
+		}
+	}
+
+	podName := getPodName(protocol, int(cluster.Spec.ClusterID), replicaID)
+	log.Info("Upgrading replica", "Name", cluster.Name, "Namespace", cluster.Namespace, "Replica", podName, "Revision", revision)
+	now := metav1.Now()
+	cluster.Status.Upgrade = &storagev2beta1.RaftClusterUpgradeStatus{
+		Revision:  revision,
+		Replica:   podName,
+		Phase:     storagev2beta1.RaftClusterTransferringLeadership,
+		StartTime: &now,
+	}
+	if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+		return err
+	}
+	r.events.Eventf(cluster, "Normal", "UpgradingReplica", "Upgrading replica %s to revision %s", podName, revision)
+	return nil
+}
+
+// reconcileTransferringLeadership moves leadership of all partitions away from the replica and deletes its pod
+func (r *Reconciler) reconcileTransferringLeadership(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	replicaID, err := r.getUpgradeReplicaID(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	// Leadership can only be transferred when another voting member is available
+	if cluster.Status.Replicas > 1 {
+		conn, err := dialAdmin(protocol, int(cluster.Spec.ClusterID), replicaID)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		client := storage.NewRaftAdminClient(conn)
+		for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+			ctx, cancel := context.WithTimeout(context.Background(), adminTimeout)
+			_, err := client.TransferLeadership(ctx, &storage.TransferLeadershipRequest{
+				Partition: uint64(partitionID),
+			})
+			cancel()
+			if err != nil {
+				log.Error(err, "Transferring leadership", "Name", cluster.Name, "Namespace", cluster.Namespace, "Partition", partitionID)
+				return nil
+			}
+		}
+	}
+
+	pod, err := r.getPod(protocol, int(cluster.Spec.ClusterID), replicaID)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	} else if err == nil {
+		log.Info("Restarting replica", "Name", cluster.Name, "Namespace", cluster.Namespace, "Replica", pod.Name)
+		if err := r.client.Delete(context.TODO(), pod); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	now := metav1.Now()
+	cluster.Status.Upgrade.Phase = storagev2beta1.RaftClusterRestarting
+	cluster.Status.Upgrade.RestartTime = &now
+	return r.client.Status().Update(context.TODO(), cluster)
+}
+
+// reconcileRestarting waits for the pod to be recreated with the updated revision
+func (r *Reconciler) reconcileRestarting(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, revision string) error {
+	replicaID, err := r.getUpgradeReplicaID(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	pod, err := r.getPod(protocol, int(cluster.Spec.ClusterID), replicaID)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if pod.DeletionTimestamp != nil || pod.Labels[appsv1.StatefulSetRevisionLabel] != revision {
+		return nil
+	}
+
+	ready, err := r.isReplicaReady(protocol, int(cluster.Spec.ClusterID), replicaID)
+	if err != nil {
+		return err
+	} else if !ready {
+		return nil
+	}
+
+	cluster.Status.Upgrade.Revision = revision
+	cluster.Status.Upgrade.Phase = storagev2beta1.RaftClusterRejoining
+	return r.client.Status().Update(context.TODO(), cluster)
+}
+
+// reconcileRejoining waits for the restarted replica to catch up on all partitions
+func (r *Reconciler) reconcileRejoining(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) error {
+	replicaID, err := r.getUpgradeReplicaID(protocol, cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+		if err := r.syncRaftMember(protocol, cluster, partitionID, replicaID); err != nil {
+			log.Info("Waiting for replica to catch up", "Name", cluster.Name, "Namespace", cluster.Namespace, "Partition", partitionID, "Error", err.Error())
+			return nil
+		}
+	}
+
+	podName := cluster.Status.Upgrade.Replica
+	revision := cluster.Status.Upgrade.Revision
+	cluster.Status.Upgrade = nil
+	if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+		return err
+	}
+	r.events.Eventf(cluster, "Normal", "ReplicaUpgraded", "Upgraded replica %s to revision %s", podName, revision)
+	return nil
+}
+
+// getUpgradeReplicaID returns the ordinal of the replica being upgraded
+func (r *Reconciler) getUpgradeReplicaID(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) (int, error) {
+	for replicaID := 0; replicaID < int(cluster.Status.Replicas); replicaID++ {
+		if getPodName(protocol, int(cluster.Spec.ClusterID), replicaID) == cluster.Status.Upgrade.Replica {
+			return replicaID, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown replica %s", cluster.Status.Upgrade.Replica)
+}
+
+// pauseUpgrade sets the UpgradePaused condition on the given cluster, recording an event when the condition changes
+func (r *Reconciler) pauseUpgrade(cluster *storagev2beta1.RaftCluster, reason string, message string, cause string) error {
+	conditions, changed := setCondition(cluster.Status.Conditions, storagev2beta1.Condition{
+		Type:    storagev2beta1.RaftClusterUpgradePaused,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	if !changed {
+		return nil
+	}
+	cluster.Status.Conditions = conditions
+	if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+		return err
+	}
+	r.events.Eventf(cluster, "Warning", "UpgradePaused", "Upgrade paused: %s", cause)
+	return nil
+}
+
+// isUpgradeReplica returns whether the given replica is being upgraded
+func isUpgradeReplica(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster, replicaID int) bool {
+	return cluster.Status.Upgrade != nil && getPodName(protocol, int(cluster.Spec.ClusterID), replicaID) == cluster.Status.Upgrade.Replica
+}
+
+// isUpgradeRestartExpired returns whether the replica being upgraded was restarted more than upgradeRestartTimeout ago
+func isUpgradeRestartExpired(cluster *storagev2beta1.RaftCluster) bool {
+	upgrade := cluster.Status.Upgrade
+	if upgrade == nil || upgrade.Phase == storagev2beta1.RaftClusterTransferringLeadership {
+		return false
+	}
+	// Upgrades started before restart times were recorded are timed from the start of the upgrade
+	restartTime := upgrade.RestartTime
+	if restartTime == nil {
+		restartTime = upgrade.StartTime
+	}
+	return restartTime != nil && time.Since(restartTime.Time) > upgradeRestartTimeout
+}
+
+// hasUpgradeQuorum returns whether a majority of the voting members of each of the cluster's partitions
+// are available. The replica being upgraded is counted as available until its restart times out, since
+// its restart is expected.
+func (r *Reconciler) hasUpgradeQuorum(protocol *storagev2beta1.MultiRaftProtocol, cluster *storagev2beta1.RaftCluster) (bool, error) {
+	members := getMembers(cluster)
+	available := make(map[int]bool)
+	for _, replicaID := range members {
+		if isUpgradeReplica(protocol, cluster, replicaID) && !isUpgradeRestartExpired(cluster) {
+			available[replicaID] = true
+			continue
+		}
+		ready, err := r.isReplicaReady(protocol, int(cluster.Spec.ClusterID), replicaID)
+		if err != nil {
+			return false, err
+		}
+		available[replicaID] = ready
+	}
+
+	for _, partitionID := range getPartitions(protocol, int(cluster.Spec.ClusterID)) {
+		voters, availableVoters := 0, 0
+		for _, replicaID := range members {
+			member := &storagev2beta1.RaftMember{}
+			name := types.NamespacedName{
+				Namespace: protocol.Namespace,
+				Name:      getMemberName(protocol, int(cluster.Spec.ClusterID), partitionID, replicaID),
+			}
+			if err := r.client.Get(context.TODO(), name, member); err != nil {
+				if !k8serrors.IsNotFound(err) {
+					return false, err
+				}
+			} else if isLearner(member) {
+				// Learners don't vote, so they don't count toward the partition's quorum
+				continue
+			}
+			voters++
+			if available[replicaID] {
+				availableVoters++
+			}
+		}
+		if availableVoters <= voters/2 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+const (
+	oldTestRevision = "raft-1-1"
+	newTestRevision = "raft-1-2"
+)
+
+// newUpgradeTestReconciler returns a reconciler for a reconciled single cluster protocol whose StatefulSet was updated
+// to a new revision, with pods with the given readiness and revisions
+func newUpgradeTestReconciler(t *testing.T, protocol *storagev2beta1.MultiRaftProtocol, ready []bool, revisions []string) *Reconciler {
+	reconciler := newTestReconciler(protocol)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	statefulSet := &appsv1.StatefulSet{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), statefulSet)
+	statefulSet.Status.ObservedGeneration = statefulSet.Generation
+	statefulSet.Status.UpdateRevision = newTestRevision
+	assert.NoError(t, reconciler.client.Status().Update(context.TODO(), statefulSet))
+
+	for podID, podReady := range ready {
+		pod := newTestPod(protocol, 1, podID, podReady)
+		pod.Labels = map[string]string{
+			appsv1.StatefulSetRevisionLabel: revisions[podID],
+		}
+		assert.NoError(t, reconciler.client.Create(context.TODO(), pod))
+	}
+	return reconciler
+}
+
+// setTestUpgrade sets the upgrade status of the protocol's cluster
+func setTestUpgrade(t *testing.T, reconciler *Reconciler, protocol *storagev2beta1.MultiRaftProtocol, upgrade *storagev2beta1.RaftClusterUpgradeStatus, conditions ...storagev2beta1.Condition) {
+	cluster := &storagev2beta1.RaftCluster{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+	cluster.Status.Upgrade = upgrade
+	cluster.Status.Conditions = conditions
+	assert.NoError(t, reconciler.client.Status().Update(context.TODO(), cluster))
+}
+
+func TestReconcileUpgradeStart(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newUpgradeTestReconciler(t, protocol,
+		[]bool{true, true, true},
+		[]string{oldTestRevision, oldTestRevision, oldTestRevision})
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	// The highest ordinal is upgraded first
+	cluster := &storagev2beta1.RaftCluster{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+	assert.NotNil(t, cluster.Status.Upgrade)
+	assert.Equal(t, getPodName(protocol, 1, 2), cluster.Status.Upgrade.Replica)
+	assert.Equal(t, storagev2beta1.RaftClusterTransferringLeadership, cluster.Status.Upgrade.Phase)
+	assert.Equal(t, newTestRevision, cluster.Status.Upgrade.Revision)
+	assert.Contains(t, getTestEvents(reconciler), "Normal UpgradingReplica Upgrading replica raft-1-2 to revision raft-1-2")
+}
+
+func TestReconcileUpgradeNotStartedWhileReplicaUnavailable(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	reconciler := newUpgradeTestReconciler(t, protocol,
+		[]bool{true, false, true},
+		[]string{oldTestRevision, oldTestRevision, oldTestRevision})
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	cluster := &storagev2beta1.RaftCluster{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+	assert.Nil(t, cluster.Status.Upgrade)
+}
+
+func TestReconcileUpgradeRestart(t *testing.T) {
+	tests := []struct {
+		name        string
+		replicas    int32
+		ready       []bool
+		revisions   []string
+		restartTime time.Time
+		learners    []int
+		conditions  []storagev2beta1.Condition
+		phase       storagev2beta1.RaftClusterUpgradePhase
+		paused      corev1.ConditionStatus
+		reason      string
+		event       string
+	}{
+		{
+			name:        "restarting",
+			replicas:    3,
+			ready:       []bool{true, true, false},
+			revisions:   []string{oldTestRevision, oldTestRevision, newTestRevision},
+			restartTime: time.Now(),
+			phase:       storagev2beta1.RaftClusterRestarting,
+		},
+		{
+			name:        "restarted",
+			replicas:    3,
+			ready:       []bool{true, true, true},
+			revisions:   []string{oldTestRevision, oldTestRevision, newTestRevision},
+			restartTime: time.Now(),
+			phase:       storagev2beta1.RaftClusterRejoining,
+		},
+		{
+			name:        "restarting while another replica is unavailable",
+			replicas:    5,
+			ready:       []bool{true, false, true, true, false},
+			revisions:   []string{oldTestRevision, oldTestRevision, oldTestRevision, oldTestRevision, newTestRevision},
+			restartTime: time.Now(),
+			phase:       storagev2beta1.RaftClusterRestarting,
+		},
+		{
+			name:        "restarting replica does not restore quorum",
+			replicas:    5,
+			ready:       []bool{true, false, false, false, false},
+			revisions:   []string{oldTestRevision, oldTestRevision, oldTestRevision, oldTestRevision, newTestRevision},
+			restartTime: time.Now(),
+			phase:       storagev2beta1.RaftClusterRestarting,
+			paused:      corev1.ConditionTrue,
+			reason:      "QuorumLost",
+			event:       "Warning UpgradePaused Upgrade paused: a partition lost quorum",
+		},
+		{
+			name:        "learners do not count toward quorum",
+			replicas:    3,
+			ready:       []bool{true, false, false},
+			revisions:   []string{oldTestRevision, oldTestRevision, newTestRevision},
+			restartTime: time.Now(),
+			learners:    []int{0},
+			phase:       storagev2beta1.RaftClusterRestarting,
+			paused:      corev1.ConditionTrue,
+			reason:      "QuorumLost",
+			event:       "Warning UpgradePaused Upgrade paused: a partition lost quorum",
+		},
+		{
+			name:        "restart timed out",
+			replicas:    3,
+			ready:       []bool{true, true, false},
+			revisions:   []string{oldTestRevision, oldTestRevision, newTestRevision},
+			restartTime: time.Now().Add(-upgradeRestartTimeout - time.Minute),
+			phase:       storagev2beta1.RaftClusterRestarting,
+			paused:      corev1.ConditionTrue,
+			reason:      "RestartTimedOut",
+			event:       "Warning UpgradePaused Upgrade paused: replica raft-1-2 did not become ready",
+		},
+		{
+			name:        "restart timed out while another replica is unavailable",
+			replicas:    3,
+			ready:       []bool{true, false, false},
+			revisions:   []string{oldTestRevision, oldTestRevision, newTestRevision},
+			restartTime: time.Now().Add(-upgradeRestartTimeout - time.Minute),
+			phase:       storagev2beta1.RaftClusterRestarting,
+			paused:      corev1.ConditionTrue,
+			reason:      "QuorumLost",
+			event:       "Warning UpgradePaused Upgrade paused: a partition lost quorum",
+		},
+		{
+			name:        "restarted after timing out",
+			replicas:    3,
+			ready:       []bool{true, true, true},
+			revisions:   []string{oldTestRevision, oldTestRevision, newTestRevision},
+			restartTime: time.Now().Add(-upgradeRestartTimeout - time.Minute),
+			conditions: []storagev2beta1.Condition{
+				{
+					Type:   storagev2beta1.RaftClusterUpgradePaused,
+					Status: corev1.ConditionTrue,
+					Reason: "RestartTimedOut",
+				},
+			},
+			phase:  storagev2beta1.RaftClusterRejoining,
+			paused: corev1.ConditionFalse,
+			reason: "ReplicaReady",
+			event:  "Normal UpgradeResumed Upgrade resumed: restarted replica is ready",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(1, 1, test.replicas)
+			reconciler := newUpgradeTestReconciler(t, protocol, test.ready, test.revisions)
+			for _, memberID := range test.learners {
+				member := &storagev2beta1.RaftMember{}
+				getTestObject(t, reconciler, getMemberName(protocol, 1, 1, memberID), member)
+				memberType := storagev2beta1.RaftLearner
+				member.Status.Type = &memberType
+				assert.NoError(t, reconciler.client.Status().Update(context.TODO(), member))
+			}
+
+			restartTime := metav1.NewTime(test.restartTime)
+			setTestUpgrade(t, reconciler, protocol, &storagev2beta1.RaftClusterUpgradeStatus{
+				Revision:    newTestRevision,
+				Replica:     getPodName(protocol, 1, int(test.replicas)-1),
+				Phase:       storagev2beta1.RaftClusterRestarting,
+				StartTime:   &restartTime,
+				RestartTime: &restartTime,
+			}, test.conditions...)
+			getTestEvents(reconciler)
+			reconcileTestProtocol(t, reconciler, protocol)
+
+			cluster := &storagev2beta1.RaftCluster{}
+			getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+			assert.NotNil(t, cluster.Status.Upgrade)
+			assert.Equal(t, test.phase, cluster.Status.Upgrade.Phase)
+
+			condition := getCondition(cluster.Status.Conditions, storagev2beta1.RaftClusterUpgradePaused)
+			if test.paused == "" {
+				assert.Nil(t, condition)
+			} else if assert.NotNil(t, condition) {
+				assert.Equal(t, test.paused, condition.Status)
+				assert.Equal(t, test.reason, condition.Reason)
+			}
+
+			events := getTestEvents(reconciler)
+			if test.event != "" {
+				assert.Contains(t, events, test.event)
+			}
+		})
+	}
+}
+
+func TestReconcileUpgradeTransferringLeadershipRecordsRestartTime(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 1)
+	reconciler := newUpgradeTestReconciler(t, protocol, []bool{true}, []string{oldTestRevision})
+	setTestUpgrade(t, reconciler, protocol, &storagev2beta1.RaftClusterUpgradeStatus{
+		Revision: newTestRevision,
+		Replica:  getPodName(protocol, 1, 0),
+		Phase:    storagev2beta1.RaftClusterTransferringLeadership,
+	})
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	cluster := &storagev2beta1.RaftCluster{}
+	getTestObject(t, reconciler, getClusterName(protocol, 1), cluster)
+	assert.Equal(t, storagev2beta1.RaftClusterRestarting, cluster.Status.Upgrade.Phase)
+	assert.NotNil(t, cluster.Status.Upgrade.RestartTime)
+	assert.False(t, isUpgradeRestartExpired(cluster))
+
+	// The pod is deleted to be recreated by the StatefulSet with the new revision
+	_, err := reconciler.getPod(protocol, 1, 0)
+	assert.Error(t, err)
+}
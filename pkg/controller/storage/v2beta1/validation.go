@@ -0,0 +1,305 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/atomix/atomix-raft-storage/pkg/storage/config"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxReplicas is the maximum number of replicas in a Raft cluster
+const maxReplicas = 9
+
+// defaultProtocol sets the defaults for unset fields of the given protocol's spec. The defaults
+// match those assumed by the controller for protocols created without the webhook.
+func defaultProtocol(protocol *storagev2beta1.MultiRaftProtocol) {
+	spec := &protocol.Spec
+	if spec.Clusters == 0 {
+		spec.Clusters = int32(getNumClusters(protocol))
+	}
+	if spec.Partitions == 0 {
+		spec.Partitions = int32(getNumPartitions(protocol))
+	}
+	if spec.Replicas == 0 {
+		spec.Replicas = int32(getNumReplicas(protocol))
+	}
+	if spec.Placement == "" {
+		spec.Placement = storagev2beta1.RoundRobinPlacement
+	}
+	if spec.ImagePullPolicy == "" {
+		spec.ImagePullPolicy = getImagePullPolicy(protocol)
+	}
+	if spec.PersistentVolumeClaimRetentionPolicy == "" {
+		spec.PersistentVolumeClaimRetentionPolicy = getRetentionPolicy(protocol)
+	}
+	if spec.TopologySpread != nil {
+		if spec.TopologySpread.TopologyKey == "" {
+			spec.TopologySpread.TopologyKey = defaultTopologyKey
+		}
+		if spec.TopologySpread.MaxSkew == 0 {
+			spec.TopologySpread.MaxSkew = 1
+		}
+		if spec.TopologySpread.WhenUnsatisfiable == "" {
+			spec.TopologySpread.WhenUnsatisfiable = corev1.DoNotSchedule
+		}
+	}
+	if spec.LeaderBalancing != nil {
+		if spec.LeaderBalancing.MaxImbalance == 0 {
+			spec.LeaderBalancing.MaxImbalance = defaultMaxLeaderImbalance
+		}
+		if spec.LeaderBalancing.Interval == nil {
+			spec.LeaderBalancing.Interval = &metav1.Duration{Duration: defaultLeaderBalanceInterval}
+		}
+	}
+	if spec.DiskPressure != nil && spec.DiskPressure.Threshold == 0 {
+		spec.DiskPressure.Threshold = defaultDiskPressureThreshold
+	}
+}
+
+// validateProtocol validates a new protocol
+func validateProtocol(protocol *storagev2beta1.MultiRaftProtocol) field.ErrorList {
+	errs := validateProtocolSpec(protocol)
+	specPath := field.NewPath("spec")
+	if getNumPartitions(protocol) < getNumClusters(protocol) {
+		errs = append(errs, field.Invalid(specPath.Child("partitions"), protocol.Spec.Partitions, "must be at least the number of clusters"))
+	}
+	return errs
+}
+
+// validateProtocolUpdate validates an update to an existing protocol
+func validateProtocolUpdate(protocol, old *storagev2beta1.MultiRaftProtocol) field.ErrorList {
+	errs := validateProtocolSpec(protocol)
+	specPath := field.NewPath("spec")
+	if getNumPartitions(protocol) < getNumPartitions(old) {
+		errs = append(errs, field.Forbidden(specPath.Child("partitions"), "partitions cannot be removed"))
+	} else if getNumPartitions(protocol) != getNumPartitions(old) && getNumPartitions(protocol) < getNumClusters(protocol) {
+		errs = append(errs, field.Invalid(specPath.Child("partitions"), protocol.Spec.Partitions, "must be at least the number of clusters"))
+	}
+	if getNumClusters(protocol) < getNumClusters(old) {
+		errs = append(errs, field.Forbidden(specPath.Child("clusters"), "clusters cannot be removed"))
+	}
+	errs = append(errs, validateVolumeClaimTemplateUpdate(protocol.Spec.VolumeClaimTemplate, old.Spec.VolumeClaimTemplate, specPath.Child("volumeClaimTemplate"))...)
+
+	// Entries and snapshots encrypted with the keys in the Secret must remain readable once encryption is enabled
+	if old.Spec.Encryption != nil {
+		if protocol.Spec.Encryption == nil {
+			errs = append(errs, field.Forbidden(specPath.Child("encryption"), "encryption cannot be removed once enabled; clear the keyID to stop encrypting new entries"))
+		} else if protocol.Spec.Encryption.SecretName != old.Spec.Encryption.SecretName {
+			errs = append(errs, field.Forbidden(specPath.Child("encryption", "secretName"), "field is immutable; add new keys to the existing Secret instead"))
+		}
+	}
+	return errs
+}
+
+// validateVolumeClaimTemplateUpdate validates a change to the volume claim template. The storage request may be
+// raised to expand the data volumes, but it can't be lowered and the rest of the template is immutable.
+func validateVolumeClaimTemplateUpdate(template, old *corev1.PersistentVolumeClaim, path *field.Path) field.ErrorList {
+	if template == nil || old == nil {
+		if template != old {
+			return field.ErrorList{field.Forbidden(path, "field is immutable")}
+		}
+		return nil
+	}
+
+	size, hasSize := template.Spec.Resources.Requests[corev1.ResourceStorage]
+	oldSize, hasOldSize := old.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !hasSize || !hasOldSize {
+		if !apiequality.Semantic.DeepEqual(template, old) {
+			return field.ErrorList{field.Forbidden(path, "field is immutable except for the storage request")}
+		}
+		return nil
+	}
+
+	var errs field.ErrorList
+	unchanged := template.DeepCopy()
+	unchanged.Spec.Resources.Requests[corev1.ResourceStorage] = oldSize
+	if !apiequality.Semantic.DeepEqual(unchanged, old) {
+		errs = append(errs, field.Forbidden(path, "field is immutable except for the storage request"))
+	}
+	if size.Cmp(oldSize) < 0 {
+		errs = append(errs, field.Forbidden(path.Child("spec", "resources", "requests", string(corev1.ResourceStorage)), "storage request cannot be decreased"))
+	}
+	return errs
+}
+
+// validateProtocolSpec validates the fields of a protocol
+func validateProtocolSpec(protocol *storagev2beta1.MultiRaftProtocol) field.ErrorList {
+	var errs field.ErrorList
+	spec := protocol.Spec
+	specPath := field.NewPath("spec")
+
+	if spec.Clusters < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("clusters"), spec.Clusters, "must be positive"))
+	}
+	if spec.Partitions < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("partitions"), spec.Partitions, "must be positive"))
+	}
+	if spec.Replicas < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("replicas"), spec.Replicas, "must be positive"))
+	} else if spec.Replicas > maxReplicas {
+		errs = append(errs, field.Invalid(specPath.Child("replicas"), spec.Replicas, fmt.Sprintf("must be at most %d", maxReplicas)))
+	}
+
+	switch spec.Placement {
+	case "", storagev2beta1.RoundRobinPlacement, storagev2beta1.ContiguousPlacement, storagev2beta1.LeastLoadedPlacement:
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("placement"), spec.Placement, []string{
+			string(storagev2beta1.RoundRobinPlacement),
+			string(storagev2beta1.ContiguousPlacement),
+			string(storagev2beta1.LeastLoadedPlacement),
+		}))
+	}
+
+	switch spec.PersistentVolumeClaimRetentionPolicy {
+	case "", storagev2beta1.RetainPersistentVolumeClaims, storagev2beta1.DeletePersistentVolumeClaims:
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("persistentVolumeClaimRetentionPolicy"), spec.PersistentVolumeClaimRetentionPolicy, []string{
+			string(storagev2beta1.RetainPersistentVolumeClaims),
+			string(storagev2beta1.DeletePersistentVolumeClaims),
+		}))
+	}
+
+	protocolConfig := config.ProtocolConfig{}
+	if spec.ElectionTimeout != nil {
+		if spec.ElectionTimeout.Duration <= 0 {
+			errs = append(errs, field.Invalid(specPath.Child("electionTimeout"), spec.ElectionTimeout.Duration.String(), "must be positive"))
+		}
+		protocolConfig.ElectionTimeout = &spec.ElectionTimeout.Duration
+	}
+	if spec.HeartbeatInterval != nil {
+		if spec.HeartbeatInterval.Duration <= 0 {
+			errs = append(errs, field.Invalid(specPath.Child("heartbeatInterval"), spec.HeartbeatInterval.Duration.String(), "must be positive"))
+		}
+		protocolConfig.HeartbeatInterval = &spec.HeartbeatInterval.Duration
+	}
+	if spec.SnapshotInterval != nil && spec.SnapshotInterval.Duration <= 0 {
+		errs = append(errs, field.Invalid(specPath.Child("snapshotInterval"), spec.SnapshotInterval.Duration.String(), "must be positive"))
+	}
+	if spec.SnapshotThreshold < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("snapshotThreshold"), spec.SnapshotThreshold, "must be positive"))
+	}
+	if len(errs) == 0 && protocolConfig.GetElectionTimeoutOrDefault() <= 2*protocolConfig.GetHeartbeatIntervalOrDefault() {
+		errs = append(errs, field.Invalid(specPath.Child("electionTimeout"), protocolConfig.GetElectionTimeoutOrDefault().String(), "must be more than twice the heartbeat interval"))
+	}
+
+	if spec.TopologySpread != nil {
+		spreadPath := specPath.Child("topologySpread")
+		if spec.TopologySpread.MaxSkew < 0 {
+			errs = append(errs, field.Invalid(spreadPath.Child("maxSkew"), spec.TopologySpread.MaxSkew, "must be positive"))
+		}
+		switch spec.TopologySpread.WhenUnsatisfiable {
+		case "", corev1.DoNotSchedule, corev1.ScheduleAnyway:
+		default:
+			errs = append(errs, field.NotSupported(spreadPath.Child("whenUnsatisfiable"), spec.TopologySpread.WhenUnsatisfiable, []string{
+				string(corev1.DoNotSchedule),
+				string(corev1.ScheduleAnyway),
+			}))
+		}
+	}
+
+	if spec.LeaderBalancing != nil {
+		balancingPath := specPath.Child("leaderBalancing")
+		if spec.LeaderBalancing.MaxImbalance < 0 {
+			errs = append(errs, field.Invalid(balancingPath.Child("maxImbalance"), spec.LeaderBalancing.MaxImbalance, "must be positive"))
+		}
+		if spec.LeaderBalancing.Interval != nil && spec.LeaderBalancing.Interval.Duration <= 0 {
+			errs = append(errs, field.Invalid(balancingPath.Child("interval"), spec.LeaderBalancing.Interval.Duration.String(), "must be positive"))
+		}
+	}
+
+	if spec.Compaction != nil {
+		compactionPath := specPath.Child("compaction")
+		if spec.Compaction.Overhead < 0 {
+			errs = append(errs, field.Invalid(compactionPath.Child("overhead"), spec.Compaction.Overhead, "must be positive"))
+		}
+		if spec.Compaction.SnapshotThresholdSize != nil && spec.Compaction.SnapshotThresholdSize.Sign() <= 0 {
+			errs = append(errs, field.Invalid(compactionPath.Child("snapshotThresholdSize"), spec.Compaction.SnapshotThresholdSize.String(), "must be positive"))
+		}
+		if spec.Compaction.Interval != nil {
+			if spec.Compaction.Interval.Duration <= 0 {
+				errs = append(errs, field.Invalid(compactionPath.Child("interval"), spec.Compaction.Interval.Duration.String(), "must be positive"))
+			} else if !spec.Compaction.DisableAutoCompaction {
+				errs = append(errs, field.Invalid(compactionPath.Child("interval"), spec.Compaction.Interval.Duration.String(), "requires automatic compaction to be disabled"))
+			}
+		}
+	}
+
+	if spec.DiskPressure != nil {
+		pressurePath := specPath.Child("diskPressure")
+		if spec.DiskPressure.Threshold < 0 || spec.DiskPressure.Threshold > 100 {
+			errs = append(errs, field.Invalid(pressurePath.Child("threshold"), spec.DiskPressure.Threshold, "must be a percentage between 1 and 100"))
+		}
+		if spec.DiskPressure.WriteLimit < 0 || spec.DiskPressure.WriteLimit > 100 {
+			errs = append(errs, field.Invalid(pressurePath.Child("writeLimit"), spec.DiskPressure.WriteLimit, "must be a percentage between 1 and 100"))
+		} else if spec.DiskPressure.WriteLimit > 0 && spec.DiskPressure.WriteLimit < spec.DiskPressure.Threshold {
+			errs = append(errs, field.Invalid(pressurePath.Child("writeLimit"), spec.DiskPressure.WriteLimit, "must be at least the disk pressure threshold"))
+		}
+	}
+
+	if spec.MaxMemberLag < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("maxMemberLag"), spec.MaxMemberLag, "must be positive"))
+	}
+
+	if spec.Encryption != nil {
+		encryptionPath := specPath.Child("encryption")
+		if spec.Encryption.SecretName == "" {
+			errs = append(errs, field.Required(encryptionPath.Child("secretName"), "must name the Secret containing the encryption keys"))
+		}
+		if spec.Encryption.KeyID != "" {
+			// Keys are read from files in the Secret volume, which uses hidden files to update keys atomically
+			if msgs := validation.IsConfigMapKey(spec.Encryption.KeyID); len(msgs) > 0 {
+				for _, msg := range msgs {
+					errs = append(errs, field.Invalid(encryptionPath.Child("keyID"), spec.Encryption.KeyID, msg))
+				}
+			} else if strings.HasPrefix(spec.Encryption.KeyID, ".") {
+				errs = append(errs, field.Invalid(encryptionPath.Child("keyID"), spec.Encryption.KeyID, "must not start with '.'"))
+			}
+		}
+	}
+
+	annotationsPath := field.NewPath("metadata", "annotations")
+	for key, value := range protocol.Annotations {
+		if !strings.HasPrefix(key, migratePartitionAnnotationPrefix) {
+			continue
+		}
+		partitionID, err := strconv.Atoi(strings.TrimPrefix(key, migratePartitionAnnotationPrefix))
+		if err != nil || partitionID < 1 || partitionID > getNumPartitions(protocol) {
+			errs = append(errs, field.Invalid(annotationsPath.Key(key), key, "must reference an existing partition"))
+		}
+		clusterID, err := strconv.Atoi(value)
+		if err != nil || clusterID < 1 || clusterID > getNumClusters(protocol) {
+			errs = append(errs, field.Invalid(annotationsPath.Key(key), value, "must reference an existing cluster"))
+		}
+	}
+	return errs
+}
+
+// getProtocolWarnings returns warnings about valid but discouraged configurations of the given protocol
+func getProtocolWarnings(protocol *storagev2beta1.MultiRaftProtocol) []string {
+	var warnings []string
+	if replicas := getNumReplicas(protocol); replicas%2 == 0 {
+		warnings = append(warnings, fmt.Sprintf("spec.replicas: %d replicas tolerate no more failures than %d replicas", replicas, replicas-1))
+	}
+	return warnings
+}
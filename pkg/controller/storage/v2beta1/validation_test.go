@@ -0,0 +1,789 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+// protocolValidationTest is a change to a valid protocol and the fields it makes invalid
+type protocolValidationTest struct {
+	name   string
+	update func(*storagev2beta1.MultiRaftProtocol)
+	fields []string
+}
+
+// runProtocolValidationTests applies each test's change to a valid protocol and submits the result to the
+// validating webhook
+func runProtocolValidationTests(t *testing.T, tests []protocolValidationTest) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(3, 3, 3)
+			test.update(protocol)
+			assertTestAdmission(t, admitTestProtocol(t, admissionv1beta1.Create, protocol, nil), test.fields)
+		})
+	}
+}
+
+func TestDefaultProtocol(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  storagev2beta1.MultiRaftProtocolSpec
+		check func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "empty",
+			check: func(t *testing.T, patches map[string]interface{}) {
+				assert.Equal(t, float64(1), patches["/spec/clusters"])
+				assert.Equal(t, float64(1), patches["/spec/partitions"])
+				assert.Equal(t, float64(1), patches["/spec/replicas"])
+				assert.Equal(t, string(storagev2beta1.RoundRobinPlacement), patches["/spec/placement"])
+				assert.Equal(t, string(corev1.PullIfNotPresent), patches["/spec/imagePullPolicy"])
+				assert.Equal(t, string(storagev2beta1.RetainPersistentVolumeClaims), patches["/spec/persistentVolumeClaimRetentionPolicy"])
+				assert.Len(t, patches, 6)
+			},
+		},
+		{
+			name: "set fields",
+			spec: storagev2beta1.MultiRaftProtocolSpec{
+				Clusters:   2,
+				Partitions: 4,
+				Replicas:   5,
+				Placement:  storagev2beta1.ContiguousPlacement,
+			},
+			check: func(t *testing.T, patches map[string]interface{}) {
+				assert.NotContains(t, patches, "/spec/clusters")
+				assert.NotContains(t, patches, "/spec/partitions")
+				assert.NotContains(t, patches, "/spec/replicas")
+				assert.NotContains(t, patches, "/spec/placement")
+			},
+		},
+		{
+			name: "topology spread",
+			spec: storagev2beta1.MultiRaftProtocolSpec{
+				TopologySpread: &storagev2beta1.RaftTopologySpread{},
+			},
+			check: func(t *testing.T, patches map[string]interface{}) {
+				assert.Equal(t, defaultTopologyKey, patches["/spec/topologySpread/topologyKey"])
+				assert.Equal(t, float64(1), patches["/spec/topologySpread/maxSkew"])
+				assert.Equal(t, string(corev1.DoNotSchedule), patches["/spec/topologySpread/whenUnsatisfiable"])
+			},
+		},
+		{
+			name: "leader balancing",
+			spec: storagev2beta1.MultiRaftProtocolSpec{
+				LeaderBalancing: &storagev2beta1.RaftLeaderBalancing{},
+			},
+			check: func(t *testing.T, patches map[string]interface{}) {
+				assert.Equal(t, float64(defaultMaxLeaderImbalance), patches["/spec/leaderBalancing/maxImbalance"])
+				assert.Equal(t, defaultLeaderBalanceInterval.String(), patches["/spec/leaderBalancing/interval"])
+			},
+		},
+		{
+			name: "disk pressure",
+			spec: storagev2beta1.MultiRaftProtocolSpec{
+				DiskPressure: &storagev2beta1.RaftDiskPressure{},
+			},
+			check: func(t *testing.T, patches map[string]interface{}) {
+				assert.Equal(t, float64(defaultDiskPressureThreshold), patches["/spec/diskPressure/threshold"])
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := &storagev2beta1.MultiRaftProtocol{
+				Spec: test.spec,
+			}
+			test.check(t, defaultTestProtocol(t, protocol))
+		})
+	}
+}
+
+func TestValidateProtocolSize(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name:   "valid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {},
+		},
+		{
+			name: "fewer partitions than clusters",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Partitions = 2
+			},
+			fields: []string{"spec.partitions"},
+		},
+		{
+			name: "negative clusters",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Clusters = -1
+			},
+			fields: []string{"spec.clusters"},
+		},
+		{
+			name: "default replicas",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Replicas = 0
+			},
+		},
+		{
+			name: "too many replicas",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Replicas = maxReplicas + 1
+			},
+			fields: []string{"spec.replicas"},
+		},
+		{
+			name: "negative replicas",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Replicas = -1
+			},
+			fields: []string{"spec.replicas"},
+		},
+	})
+}
+
+func TestValidateProtocolPolicies(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "least loaded placement",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Placement = storagev2beta1.LeastLoadedPlacement
+			},
+		},
+		{
+			name: "unsupported placement",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Placement = "Random"
+			},
+			fields: []string{"spec.placement"},
+		},
+		{
+			name: "delete claims",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.PersistentVolumeClaimRetentionPolicy = storagev2beta1.DeletePersistentVolumeClaims
+			},
+		},
+		{
+			name: "unsupported retention policy",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.PersistentVolumeClaimRetentionPolicy = "Archive"
+			},
+			fields: []string{"spec.persistentVolumeClaimRetentionPolicy"},
+		},
+	})
+}
+
+func TestValidateProtocolTimeouts(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "valid timeouts",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.HeartbeatInterval = &metav1.Duration{Duration: time.Second}
+				protocol.Spec.ElectionTimeout = &metav1.Duration{Duration: 10 * time.Second}
+				protocol.Spec.SnapshotInterval = &metav1.Duration{Duration: time.Minute}
+			},
+		},
+		{
+			name: "election timeout too short",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.HeartbeatInterval = &metav1.Duration{Duration: time.Second}
+				protocol.Spec.ElectionTimeout = &metav1.Duration{Duration: 2 * time.Second}
+			},
+			fields: []string{"spec.electionTimeout"},
+		},
+		{
+			name: "negative heartbeat interval",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.HeartbeatInterval = &metav1.Duration{Duration: -time.Second}
+			},
+			fields: []string{"spec.heartbeatInterval"},
+		},
+		{
+			name: "zero snapshot interval",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.SnapshotInterval = &metav1.Duration{}
+			},
+			fields: []string{"spec.snapshotInterval"},
+		},
+		{
+			name: "negative snapshot threshold",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.SnapshotThreshold = -1
+			},
+			fields: []string{"spec.snapshotThreshold"},
+		},
+	})
+}
+
+func TestValidateProtocolTopologySpread(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "valid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.TopologySpread = &storagev2beta1.RaftTopologySpread{
+					MaxSkew:           1,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+				}
+			},
+		},
+		{
+			name: "invalid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.TopologySpread = &storagev2beta1.RaftTopologySpread{
+					MaxSkew:           -1,
+					WhenUnsatisfiable: "Never",
+				}
+			},
+			fields: []string{"spec.topologySpread.maxSkew", "spec.topologySpread.whenUnsatisfiable"},
+		},
+	})
+}
+
+func TestValidateProtocolLeaderBalancing(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "valid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.LeaderBalancing = &storagev2beta1.RaftLeaderBalancing{
+					MaxImbalance: 2,
+					Interval:     &metav1.Duration{Duration: time.Minute},
+				}
+			},
+		},
+		{
+			name: "invalid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.LeaderBalancing = &storagev2beta1.RaftLeaderBalancing{
+					MaxImbalance: -1,
+					Interval:     &metav1.Duration{},
+				}
+			},
+			fields: []string{"spec.leaderBalancing.maxImbalance", "spec.leaderBalancing.interval"},
+		},
+	})
+}
+
+func TestValidateProtocolCompaction(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "valid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				size := resource.MustParse("64Mi")
+				protocol.Spec.Compaction = &storagev2beta1.RaftCompaction{
+					Overhead:              1000,
+					SnapshotThresholdSize: &size,
+					DisableAutoCompaction: true,
+					Interval:              &metav1.Duration{Duration: time.Minute},
+				}
+			},
+		},
+		{
+			name: "invalid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				size := resource.MustParse("0")
+				protocol.Spec.Compaction = &storagev2beta1.RaftCompaction{
+					Overhead:              -1,
+					SnapshotThresholdSize: &size,
+				}
+			},
+			fields: []string{"spec.compaction.overhead", "spec.compaction.snapshotThresholdSize"},
+		},
+		{
+			name: "interval with automatic compaction",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Compaction = &storagev2beta1.RaftCompaction{
+					Interval: &metav1.Duration{Duration: time.Minute},
+				}
+			},
+			fields: []string{"spec.compaction.interval"},
+		},
+	})
+}
+
+func TestValidateProtocolDiskPressure(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "valid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.DiskPressure = &storagev2beta1.RaftDiskPressure{
+					Threshold:  80,
+					WriteLimit: 95,
+				}
+			},
+		},
+		{
+			name: "out of range",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.DiskPressure = &storagev2beta1.RaftDiskPressure{
+					Threshold:  101,
+					WriteLimit: -1,
+				}
+			},
+			fields: []string{"spec.diskPressure.threshold", "spec.diskPressure.writeLimit"},
+		},
+		{
+			name: "write limit below threshold",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.DiskPressure = &storagev2beta1.RaftDiskPressure{
+					Threshold:  80,
+					WriteLimit: 70,
+				}
+			},
+			fields: []string{"spec.diskPressure.writeLimit"},
+		},
+	})
+}
+
+func TestValidateProtocolMaxMemberLag(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "valid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.MaxMemberLag = 5000
+			},
+		},
+		{
+			name: "negative",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.MaxMemberLag = -1
+			},
+			fields: []string{"spec.maxMemberLag"},
+		},
+	})
+}
+
+func TestValidateProtocolEncryption(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "valid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{
+					SecretName: "raft-keys",
+					KeyID:      "key-1",
+				}
+			},
+		},
+		{
+			name: "decrypt only",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{
+					SecretName: "raft-keys",
+				}
+			},
+		},
+		{
+			name: "missing secret and invalid key",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{
+					KeyID: "raft/key",
+				}
+			},
+			fields: []string{"spec.encryption.secretName", "spec.encryption.keyID"},
+		},
+		{
+			name: "hidden key",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{
+					SecretName: "raft-keys",
+					KeyID:      ".key",
+				}
+			},
+			fields: []string{"spec.encryption.keyID"},
+		},
+	})
+}
+
+func TestValidateProtocolMigration(t *testing.T) {
+	runProtocolValidationTests(t, []protocolValidationTest{
+		{
+			name: "valid",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Annotations = map[string]string{
+					migratePartitionAnnotationPrefix + "2": "3",
+				}
+			},
+		},
+		{
+			name: "unknown cluster",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Annotations = map[string]string{
+					migratePartitionAnnotationPrefix + "2": "4",
+				}
+			},
+			fields: []string{"metadata.annotations[storage.atomix.io/migrate-partition-2]"},
+		},
+		{
+			name: "unknown partition",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Annotations = map[string]string{
+					migratePartitionAnnotationPrefix + "4": "1",
+				}
+			},
+			fields: []string{"metadata.annotations[storage.atomix.io/migrate-partition-4]"},
+		},
+	})
+}
+
+func TestValidateProtocolUpdate(t *testing.T) {
+	withVolumeSize := func(size string) func(*storagev2beta1.MultiRaftProtocol) {
+		return func(protocol *storagev2beta1.MultiRaftProtocol) {
+			protocol.Spec.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse(size),
+						},
+					},
+				},
+			}
+		}
+	}
+
+	tests := []struct {
+		name   string
+		old    func(*storagev2beta1.MultiRaftProtocol)
+		update func(*storagev2beta1.MultiRaftProtocol)
+		fields []string
+	}{
+		{
+			name: "add replicas and partitions",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Replicas = 5
+				protocol.Spec.Partitions = 8
+			},
+		},
+		{
+			name: "remove partitions",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Partitions = 2
+			},
+			fields: []string{"spec.partitions"},
+		},
+		{
+			name: "remove clusters",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Clusters = 1
+			},
+			fields: []string{"spec.clusters"},
+		},
+		{
+			name: "add volume claim template",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{}
+			},
+			fields: []string{"spec.volumeClaimTemplate"},
+		},
+		{
+			name: "rotate encryption key",
+			old: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{SecretName: "raft-keys", KeyID: "key-1"}
+			},
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption.KeyID = "key-2"
+			},
+		},
+		{
+			name: "stop encrypting new entries",
+			old: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{SecretName: "raft-keys", KeyID: "key-1"}
+			},
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption.KeyID = ""
+			},
+		},
+		{
+			name: "enable encryption",
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{SecretName: "raft-keys", KeyID: "key-1"}
+			},
+		},
+		{
+			name: "remove encryption",
+			old: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{SecretName: "raft-keys", KeyID: "key-1"}
+			},
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = nil
+			},
+			fields: []string{"spec.encryption"},
+		},
+		{
+			name: "change encryption Secret",
+			old: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption = &storagev2beta1.RaftEncryption{SecretName: "raft-keys", KeyID: "key-1"}
+			},
+			update: func(protocol *storagev2beta1.MultiRaftProtocol) {
+				protocol.Spec.Encryption.SecretName = "other-keys"
+			},
+			fields: []string{"spec.encryption.secretName"},
+		},
+		{
+			name:   "grow volume storage request",
+			old:    withVolumeSize("1Gi"),
+			update: withVolumeSize("10Gi"),
+		},
+		{
+			name:   "shrink volume storage request",
+			old:    withVolumeSize("10Gi"),
+			update: withVolumeSize("1Gi"),
+			fields: []string{"spec.volumeClaimTemplate.spec.resources.requests.storage"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			old := newTestProtocol(2, 4, 3)
+			if test.old != nil {
+				test.old(old)
+			}
+			protocol := old.DeepCopy()
+			test.update(protocol)
+			assertTestAdmission(t, admitTestProtocol(t, admissionv1beta1.Update, protocol, old), test.fields)
+		})
+	}
+}
+
+func TestValidateVolumeClaimTemplateUpdate(t *testing.T) {
+	newTemplate := func(size string) *corev1.PersistentVolumeClaim {
+		className := "standard"
+		template := &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &className,
+			},
+		}
+		if size != "" {
+			template.Spec.Resources.Requests = corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(size),
+			}
+		}
+		return template
+	}
+
+	tests := []struct {
+		name   string
+		old    *corev1.PersistentVolumeClaim
+		update func(*corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim
+		fields []string
+	}{
+		{
+			name: "unchanged",
+			old:  newTemplate("1Gi"),
+			update: func(template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+				return template
+			},
+		},
+		{
+			name: "grow storage request",
+			old:  newTemplate("1Gi"),
+			update: func(template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+				template.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("10Gi")
+				return template
+			},
+		},
+		{
+			name: "equivalent storage request",
+			old:  newTemplate("1Gi"),
+			update: func(template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+				template.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("1024Mi")
+				return template
+			},
+		},
+		{
+			name: "shrink storage request",
+			old:  newTemplate("10Gi"),
+			update: func(template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+				template.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("1Gi")
+				return template
+			},
+			fields: []string{"spec.volumeClaimTemplate.spec.resources.requests.storage"},
+		},
+		{
+			name: "change storage class",
+			old:  newTemplate("1Gi"),
+			update: func(template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+				className := "fast"
+				template.Spec.StorageClassName = &className
+				return template
+			},
+			fields: []string{"spec.volumeClaimTemplate"},
+		},
+		{
+			name: "change storage class and shrink storage request",
+			old:  newTemplate("10Gi"),
+			update: func(template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+				className := "fast"
+				template.Spec.StorageClassName = &className
+				template.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("1Gi")
+				return template
+			},
+			fields: []string{"spec.volumeClaimTemplate", "spec.volumeClaimTemplate.spec.resources.requests.storage"},
+		},
+		{
+			name: "add storage request",
+			old:  newTemplate(""),
+			update: func(template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+				return newTemplate("1Gi")
+			},
+			fields: []string{"spec.volumeClaimTemplate"},
+		},
+		{
+			name: "remove volume claim template",
+			old:  newTemplate("1Gi"),
+			update: func(template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+				return nil
+			},
+			fields: []string{"spec.volumeClaimTemplate"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			old := newTestProtocol(2, 4, 3)
+			old.Spec.VolumeClaimTemplate = test.old
+			protocol := old.DeepCopy()
+			protocol.Spec.VolumeClaimTemplate = test.update(protocol.Spec.VolumeClaimTemplate)
+			assertTestAdmission(t, admitTestProtocol(t, admissionv1beta1.Update, protocol, old), test.fields)
+		})
+	}
+}
+
+func TestProtocolWarnings(t *testing.T) {
+	tests := []struct {
+		replicas int32
+		warnings int
+	}{
+		{replicas: 1},
+		{replicas: 2, warnings: 1},
+		{replicas: 3},
+		{replicas: 4, warnings: 1},
+	}
+
+	for _, test := range tests {
+		protocol := newTestProtocol(1, 1, test.replicas)
+		response := admitTestProtocol(t, admissionv1beta1.Create, protocol, nil)
+		assert.True(t, response.Allowed)
+		assert.Len(t, getProtocolWarnings(protocol), test.warnings, "%d replicas", test.replicas)
+		if test.warnings > 0 {
+			assert.Contains(t, response.AuditAnnotations, warningAuditAnnotation, "%d replicas", test.replicas)
+		} else {
+			assert.NotContains(t, response.AuditAnnotations, warningAuditAnnotation, "%d replicas", test.replicas)
+		}
+	}
+}
+
+func TestValidateOperation(t *testing.T) {
+	cluster, otherCluster, partition, otherPartition, member := int32(1), int32(3), int32(1), int32(2), int32(0)
+	tests := []struct {
+		name  string
+		spec  storagev2beta1.RaftOperationSpec
+		valid bool
+	}{
+		{
+			name: "snapshot",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type: storagev2beta1.RaftSnapshotOperation,
+			},
+			valid: true,
+		},
+		{
+			name: "unsupported type",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type: "Reboot",
+			},
+		},
+		{
+			name: "member without cluster",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type:   storagev2beta1.RaftRestartMemberOperation,
+				Member: &member,
+			},
+		},
+		{
+			name: "member",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type:    storagev2beta1.RaftRestartMemberOperation,
+				Cluster: &cluster,
+				Member:  &member,
+			},
+			valid: true,
+		},
+		{
+			name: "unknown cluster",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type:    storagev2beta1.RaftCompactLogOperation,
+				Cluster: &otherCluster,
+			},
+		},
+		{
+			name: "partition",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type:      storagev2beta1.RaftTransferLeadershipOperation,
+				Cluster:   &cluster,
+				Partition: &partition,
+			},
+			valid: true,
+		},
+		{
+			name: "partition on another cluster",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type:      storagev2beta1.RaftTransferLeadershipOperation,
+				Cluster:   &cluster,
+				Partition: &otherPartition,
+			},
+		},
+		{
+			name: "snapshot parameters",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type:     storagev2beta1.RaftSnapshotOperation,
+				Snapshot: &storagev2beta1.RaftSnapshotParameters{CompactionOverhead: 100},
+			},
+			valid: true,
+		},
+		{
+			name: "snapshot parameters for another type",
+			spec: storagev2beta1.RaftOperationSpec{
+				Type:     storagev2beta1.RaftRestartMemberOperation,
+				Snapshot: &storagev2beta1.RaftSnapshotParameters{CompactionOverhead: 100},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol := newTestProtocol(2, 2, 3)
+			protocol.Status.Placement = []storagev2beta1.PartitionPlacement{
+				{PartitionID: 1, ClusterID: 1},
+				{PartitionID: 2, ClusterID: 2},
+			}
+			operation := &storagev2beta1.RaftOperation{
+				Spec: test.spec,
+			}
+			err := validateOperation(protocol, operation)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	retainedLabel            = "storage.atomix.io/retained"
+	retainedReasonAnnotation = "storage.atomix.io/retained-reason"
+)
+
+const retainPolicyReason = "Retained by the protocol's persistentVolumeClaimRetentionPolicy"
+
+// getRetentionPolicy returns the volume claim retention policy for the given protocol
+func getRetentionPolicy(protocol *storagev2beta1.MultiRaftProtocol) storagev2beta1.PersistentVolumeClaimRetentionPolicy {
+	if protocol.Spec.PersistentVolumeClaimRetentionPolicy == "" {
+		return storagev2beta1.RetainPersistentVolumeClaims
+	}
+	return protocol.Spec.PersistentVolumeClaimRetentionPolicy
+}
+
+// getDataVolumeName returns the name of the data volume claim template for the given protocol
+func getDataVolumeName(protocol *storagev2beta1.MultiRaftProtocol) string {
+	if protocol.Spec.VolumeClaimTemplate != nil && protocol.Spec.VolumeClaimTemplate.Name != "" {
+		return protocol.Spec.VolumeClaimTemplate.Name
+	}
+	return dataVolume
+}
+
+// getVolumeClaimName returns the name of the data volume claim created by the StatefulSet for the given pod
+func getVolumeClaimName(protocol *storagev2beta1.MultiRaftProtocol, clusterID int, podID int) string {
+	return fmt.Sprintf("%s-%s", getDataVolumeName(protocol), getPodName(protocol, clusterID, podID))
+}
+
+// getVolumeClaims returns the data volume claims created for the given protocol's pods
+func (r *Reconciler) getVolumeClaims(protocol *storagev2beta1.MultiRaftProtocol) ([]corev1.PersistentVolumeClaim, error) {
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := r.client.List(context.TODO(), claims, client.InNamespace(protocol.Namespace)); err != nil {
+		return nil, err
+	}
+
+	// Claims created before the claim template was labeled are matched by the StatefulSet naming scheme
+	pattern := regexp.MustCompile(fmt.Sprintf("^%s-%s-[0-9]+-[0-9]+$", regexp.QuoteMeta(getDataVolumeName(protocol)), regexp.QuoteMeta(protocol.Name)))
+	database := fmt.Sprintf("%s.%s", protocol.Name, protocol.Namespace)
+
+	matches := make([]corev1.PersistentVolumeClaim, 0, len(claims.Items))
+	for _, claim := range claims.Items {
+		if claim.Labels[databaseLabel] == database || (claim.Labels[databaseLabel] == "" && pattern.MatchString(claim.Name)) {
+			if claim.Labels == nil {
+				claim.Labels = make(map[string]string)
+			}
+			matches = append(matches, claim)
+		}
+	}
+	return matches, nil
+}
+
+// reconcileRetainedVolumeClaims releases the retained claims reused by a protocol of the same name
+func (r *Reconciler) reconcileRetainedVolumeClaims(protocol *storagev2beta1.MultiRaftProtocol) error {
+	claims, err := r.getVolumeClaims(protocol)
+	if err != nil {
+		return err
+	}
+	for _, claim := range claims {
+		if claim.Labels[retainedLabel] != "true" {
+			continue
+		}
+		log.Info("Reusing retained volume claim", "Name", claim.Name, "Namespace", claim.Namespace)
+		delete(claim.Labels, retainedLabel)
+		delete(claim.Annotations, retainedReasonAnnotation)
+		if err := r.client.Update(context.TODO(), &claim); err != nil {
+			return err
+		}
+		r.events.Eventf(protocol, "Normal", "VolumeClaimReused", "Reusing retained volume claim %s", claim.Name)
+	}
+	return nil
+}
+
+// finalizeVolumeClaims applies the protocol's retention policy to its data volume claims
+func (r *Reconciler) finalizeVolumeClaims(protocol *storagev2beta1.MultiRaftProtocol) error {
+	claims, err := r.getVolumeClaims(protocol)
+	if err != nil {
+		return err
+	}
+	if len(claims) == 0 {
+		return nil
+	}
+
+	if getRetentionPolicy(protocol) == storagev2beta1.DeletePersistentVolumeClaims {
+		for _, claim := range claims {
+			log.Info("Deleting volume claim", "Name", claim.Name, "Namespace", claim.Namespace)
+			if err := r.client.Delete(context.TODO(), &claim); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			r.events.Eventf(protocol, "Normal", "VolumeClaimDeleted", "Deleted volume claim %s", claim.Name)
+		}
+		return nil
+	}
+
+	retained := make([]storagev2beta1.RetainedVolumeClaim, 0, len(claims))
+	names := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		if claim.Labels[retainedLabel] != "true" {
+			log.Info("Retaining volume claim", "Name", claim.Name, "Namespace", claim.Namespace)
+			if claim.Annotations == nil {
+				claim.Annotations = make(map[string]string)
+			}
+			claim.Labels[retainedLabel] = "true"
+			claim.Annotations[retainedReasonAnnotation] = retainPolicyReason
+			if err := r.client.Update(context.TODO(), &claim); err != nil {
+				return err
+			}
+		}
+		retained = append(retained, storagev2beta1.RetainedVolumeClaim{
+			Name:   claim.Name,
+			Reason: retainPolicyReason,
+		})
+		names = append(names, claim.Name)
+	}
+
+	protocol.Status.RetainedVolumeClaims = retained
+	if err := r.client.Status().Update(context.TODO(), protocol); err != nil {
+		return err
+	}
+	r.events.Eventf(protocol, "Normal", "VolumeClaimsRetained", "Retained volume claims %s: %s", strings.Join(names, ", "), retainPolicyReason)
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+// newTestLabeledVolumeClaim returns a data volume claim with the given name and labels
+func newTestLabeledVolumeClaim(name string, labels map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    labels,
+		},
+	}
+}
+
+// newVolumeTestReconciler returns a reconciler for a deleted protocol with the given retention policy and
+// the claims of the protocol's pods, a claim created before claims were labeled, and a claim of another protocol
+func newVolumeTestReconciler(policy storagev2beta1.PersistentVolumeClaimRetentionPolicy) (*Reconciler, *storagev2beta1.MultiRaftProtocol) {
+	now := metav1.Now()
+	protocol := newTestProtocol(1, 1, 3)
+	protocol.Finalizers = []string{protocolFinalizer}
+	protocol.DeletionTimestamp = &now
+	protocol.Spec.PersistentVolumeClaimRetentionPolicy = policy
+	objects := []runtime.Object{
+		protocol,
+		newTestLabeledVolumeClaim(getVolumeClaimName(protocol, 1, 0), map[string]string{databaseLabel: "raft.test"}),
+		newTestLabeledVolumeClaim(getVolumeClaimName(protocol, 1, 1), map[string]string{databaseLabel: "raft.test"}),
+		newTestLabeledVolumeClaim(getVolumeClaimName(protocol, 1, 2), nil),
+		newTestLabeledVolumeClaim("data-other-1-0", map[string]string{databaseLabel: "other.test"}),
+	}
+	return newTestReconciler(objects...), protocol
+}
+
+// hasTestVolumeClaim returns whether the claim with the given name exists
+func hasTestVolumeClaim(t *testing.T, reconciler *Reconciler, name string) bool {
+	claim := &corev1.PersistentVolumeClaim{}
+	err := reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: name}, claim)
+	if k8serrors.IsNotFound(err) {
+		return false
+	}
+	assert.NoError(t, err)
+	return true
+}
+
+func TestReconcileFinalizeVolumeClaimsDelete(t *testing.T) {
+	reconciler, protocol := newVolumeTestReconciler(storagev2beta1.DeletePersistentVolumeClaims)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	for podID := 0; podID < 3; podID++ {
+		assert.False(t, hasTestVolumeClaim(t, reconciler, getVolumeClaimName(protocol, 1, podID)))
+	}
+	assert.True(t, hasTestVolumeClaim(t, reconciler, "data-other-1-0"))
+
+	finalized := &storagev2beta1.MultiRaftProtocol{}
+	getTestObject(t, reconciler, protocol.Name, finalized)
+	assert.False(t, hasFinalizer(finalized, protocolFinalizer))
+	assert.Empty(t, finalized.Status.RetainedVolumeClaims)
+	assert.Len(t, getTestEvents(reconciler), 3)
+}
+
+func TestReconcileFinalizeVolumeClaimsRetain(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy storagev2beta1.PersistentVolumeClaimRetentionPolicy
+	}{
+		{
+			name: "default policy",
+		},
+		{
+			name:   "retain policy",
+			policy: storagev2beta1.RetainPersistentVolumeClaims,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reconciler, protocol := newVolumeTestReconciler(test.policy)
+			reconcileTestProtocol(t, reconciler, protocol)
+
+			names := []string{
+				getVolumeClaimName(protocol, 1, 0),
+				getVolumeClaimName(protocol, 1, 1),
+				getVolumeClaimName(protocol, 1, 2),
+			}
+			for _, name := range names {
+				claim := &corev1.PersistentVolumeClaim{}
+				getTestObject(t, reconciler, name, claim)
+				assert.Equal(t, "true", claim.Labels[retainedLabel])
+				assert.Equal(t, retainPolicyReason, claim.Annotations[retainedReasonAnnotation])
+			}
+			other := &corev1.PersistentVolumeClaim{}
+			getTestObject(t, reconciler, "data-other-1-0", other)
+			assert.NotContains(t, other.Labels, retainedLabel)
+
+			finalized := &storagev2beta1.MultiRaftProtocol{}
+			getTestObject(t, reconciler, protocol.Name, finalized)
+			assert.False(t, hasFinalizer(finalized, protocolFinalizer))
+			retained := make([]string, 0)
+			for _, claim := range finalized.Status.RetainedVolumeClaims {
+				assert.Equal(t, retainPolicyReason, claim.Reason)
+				retained = append(retained, claim.Name)
+			}
+			assert.ElementsMatch(t, names, retained)
+
+			events := getTestEvents(reconciler)
+			assert.Len(t, events, 1)
+			assert.Contains(t, events[0], "VolumeClaimsRetained")
+		})
+	}
+}
+
+func TestReconcileRetainedVolumeClaimsReused(t *testing.T) {
+	protocol := newTestProtocol(1, 1, 3)
+	retained := newTestLabeledVolumeClaim(getVolumeClaimName(protocol, 1, 0), map[string]string{
+		databaseLabel: "raft.test",
+		retainedLabel: "true",
+	})
+	retained.Annotations = map[string]string{retainedReasonAnnotation: retainPolicyReason}
+	reconciler := newTestReconciler(protocol, retained)
+	reconcileTestProtocol(t, reconciler, protocol)
+
+	claim := &corev1.PersistentVolumeClaim{}
+	getTestObject(t, reconciler, retained.Name, claim)
+	assert.NotContains(t, claim.Labels, retainedLabel)
+	assert.NotContains(t, claim.Annotations, retainedReasonAnnotation)
+	assert.Equal(t, "raft.test", claim.Labels[databaseLabel])
+	assert.Contains(t, getTestEvents(reconciler), "Normal VolumeClaimReused Reusing retained volume claim "+retained.Name)
+}
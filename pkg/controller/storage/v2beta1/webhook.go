@@ -0,0 +1,120 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	defaultProtocolPath  = "/mutate-storage-atomix-io-v2beta1-multiraftprotocol"
+	validateProtocolPath = "/validate-storage-atomix-io-v2beta1-multiraftprotocol"
+)
+
+// warningAuditAnnotation is the audit annotation with which warnings about an admitted protocol are recorded
+const warningAuditAnnotation = "warning"
+
+func addRaftProtocolWebhooks(mgr manager.Manager) {
+	server := mgr.GetWebhookServer()
+	server.Register(defaultProtocolPath, &webhook.Admission{Handler: &protocolDefaulter{}})
+	server.Register(validateProtocolPath, &webhook.Admission{Handler: &protocolValidator{}})
+}
+
+// protocolDefaulter is a mutating admission handler that sets defaults on MultiRaftProtocols
+type protocolDefaulter struct {
+	decoder *admission.Decoder
+}
+
+// InjectDecoder injects the admission request decoder
+func (h *protocolDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	h.decoder = decoder
+	return nil
+}
+
+// Handle sets defaults on the requested protocol
+func (h *protocolDefaulter) Handle(ctx context.Context, request admission.Request) admission.Response {
+	protocol := &storagev2beta1.MultiRaftProtocol{}
+	if err := h.decoder.Decode(request, protocol); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	defaultProtocol(protocol)
+
+	bytes, err := json.Marshal(protocol)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(request.Object.Raw, bytes)
+}
+
+// protocolValidator is a validating admission handler for MultiRaftProtocols
+type protocolValidator struct {
+	decoder *admission.Decoder
+}
+
+// InjectDecoder injects the admission request decoder
+func (h *protocolValidator) InjectDecoder(decoder *admission.Decoder) error {
+	h.decoder = decoder
+	return nil
+}
+
+// Handle validates the requested protocol
+func (h *protocolValidator) Handle(ctx context.Context, request admission.Request) admission.Response {
+	protocol := &storagev2beta1.MultiRaftProtocol{}
+	if err := h.decoder.Decode(request, protocol); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Protocols being deleted are not validated to ensure finalizers can be removed
+	if protocol.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+
+	switch request.Operation {
+	case admissionv1beta1.Create:
+		if errs := validateProtocol(protocol); len(errs) > 0 {
+			return admission.Denied(errs.ToAggregate().Error())
+		}
+	case admissionv1beta1.Update:
+		old := &storagev2beta1.MultiRaftProtocol{}
+		if err := h.decoder.DecodeRaw(request.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if errs := validateProtocolUpdate(protocol, old); len(errs) > 0 {
+			return admission.Denied(errs.ToAggregate().Error())
+		}
+	}
+
+	response := admission.Allowed("")
+	warnings := getProtocolWarnings(protocol)
+	if len(warnings) > 0 {
+		for _, warning := range warnings {
+			log.Info("Admitting protocol with warning", "Name", protocol.Name, "Namespace", protocol.Namespace, "Warning", warning)
+		}
+		response.AuditAnnotations = map[string]string{
+			warningAuditAnnotation: fmt.Sprint(warnings),
+		}
+	}
+	return response
+}
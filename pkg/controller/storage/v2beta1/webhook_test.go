@@ -0,0 +1,197 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2beta1
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/atomix/atomix-raft-storage/pkg/apis"
+	storagev2beta1 "github.com/atomix/atomix-raft-storage/pkg/apis/storage/v2beta1"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"testing"
+)
+
+// newTestDecoder returns an admission request decoder for the storage API types
+func newTestDecoder(t *testing.T) *admission.Decoder {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, apis.AddToScheme(scheme))
+	decoder, err := admission.NewDecoder(scheme)
+	assert.NoError(t, err)
+	return decoder
+}
+
+// newTestAdmissionRequest returns an admission request for the given protocols
+func newTestAdmissionRequest(t *testing.T, operation admissionv1beta1.Operation, protocol, old *storagev2beta1.MultiRaftProtocol) admission.Request {
+	request := admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Operation: operation,
+		},
+	}
+	bytes, err := json.Marshal(protocol)
+	assert.NoError(t, err)
+	request.Object = runtime.RawExtension{Raw: bytes}
+	if old != nil {
+		bytes, err := json.Marshal(old)
+		assert.NoError(t, err)
+		request.OldObject = runtime.RawExtension{Raw: bytes}
+	}
+	return request
+}
+
+// defaultTestProtocol submits the given protocol to the defaulting webhook and returns the resulting patches by path
+func defaultTestProtocol(t *testing.T, protocol *storagev2beta1.MultiRaftProtocol) map[string]interface{} {
+	defaulter := &protocolDefaulter{}
+	assert.NoError(t, defaulter.InjectDecoder(newTestDecoder(t)))
+	response := defaulter.Handle(context.TODO(), newTestAdmissionRequest(t, admissionv1beta1.Create, protocol, nil))
+	assert.True(t, response.Allowed)
+	patches := make(map[string]interface{})
+	for _, patch := range response.Patches {
+		patches[patch.Path] = patch.Value
+	}
+	return patches
+}
+
+// admitTestProtocol submits the given protocol operation to the validating webhook
+func admitTestProtocol(t *testing.T, operation admissionv1beta1.Operation, protocol, old *storagev2beta1.MultiRaftProtocol) admission.Response {
+	validator := &protocolValidator{}
+	assert.NoError(t, validator.InjectDecoder(newTestDecoder(t)))
+	return validator.Handle(context.TODO(), newTestAdmissionRequest(t, operation, protocol, old))
+}
+
+// assertTestAdmission asserts the given response allows the request if no fields are given, otherwise that it
+// denies the request as invalid in each of the given fields
+func assertTestAdmission(t *testing.T, response admission.Response, fields []string) {
+	if len(fields) == 0 {
+		assert.True(t, response.Allowed, response.Result.Reason)
+		return
+	}
+	assert.False(t, response.Allowed)
+	assert.Equal(t, int32(http.StatusForbidden), response.Result.Code)
+	for _, field := range fields {
+		assert.Contains(t, string(response.Result.Reason), field+":")
+	}
+}
+
+func TestProtocolDefaulter(t *testing.T) {
+	protocol := &storagev2beta1.MultiRaftProtocol{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: storagev2beta1.SchemeGroupVersion.String(),
+			Kind:       "MultiRaftProtocol",
+		},
+		Spec: storagev2beta1.MultiRaftProtocolSpec{
+			Replicas: 3,
+		},
+	}
+	patches := defaultTestProtocol(t, protocol)
+	assert.Equal(t, float64(1), patches["/spec/clusters"])
+	assert.Equal(t, float64(1), patches["/spec/partitions"])
+	assert.Equal(t, string(storagev2beta1.RoundRobinPlacement), patches["/spec/placement"])
+	assert.NotContains(t, patches, "/spec/replicas")
+
+	request := admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Operation: admissionv1beta1.Create,
+			Object:    runtime.RawExtension{Raw: []byte("{")},
+		},
+	}
+	defaulter := &protocolDefaulter{}
+	assert.NoError(t, defaulter.InjectDecoder(newTestDecoder(t)))
+	response := defaulter.Handle(context.TODO(), request)
+	assert.False(t, response.Allowed)
+	assert.Equal(t, int32(http.StatusBadRequest), response.Result.Code)
+}
+
+func TestProtocolValidator(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name      string
+		operation admissionv1beta1.Operation
+		protocol  *storagev2beta1.MultiRaftProtocol
+		old       *storagev2beta1.MultiRaftProtocol
+		allowed   bool
+		warning   bool
+	}{
+		{
+			name:      "valid",
+			operation: admissionv1beta1.Create,
+			protocol:  newTestProtocol(1, 1, 3),
+			allowed:   true,
+		},
+		{
+			name:      "even replicas",
+			operation: admissionv1beta1.Create,
+			protocol:  newTestProtocol(1, 1, 4),
+			allowed:   true,
+			warning:   true,
+		},
+		{
+			name:      "invalid",
+			operation: admissionv1beta1.Create,
+			protocol:  newTestProtocol(1, 1, maxReplicas+1),
+		},
+		{
+			name:      "valid update",
+			operation: admissionv1beta1.Update,
+			protocol:  newTestProtocol(1, 2, 3),
+			old:       newTestProtocol(1, 1, 3),
+			allowed:   true,
+		},
+		{
+			name:      "update with warning",
+			operation: admissionv1beta1.Update,
+			protocol:  newTestProtocol(1, 1, 4),
+			old:       newTestProtocol(1, 1, 3),
+			allowed:   true,
+			warning:   true,
+		},
+		{
+			name:      "invalid update",
+			operation: admissionv1beta1.Update,
+			protocol:  newTestProtocol(1, 1, 3),
+			old:       newTestProtocol(1, 2, 3),
+		},
+		{
+			name:      "deleting",
+			operation: admissionv1beta1.Update,
+			protocol: func() *storagev2beta1.MultiRaftProtocol {
+				protocol := newTestProtocol(1, 1, 3)
+				protocol.DeletionTimestamp = &now
+				return protocol
+			}(),
+			old:     newTestProtocol(1, 2, 3),
+			allowed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := admitTestProtocol(t, test.operation, test.protocol, test.old)
+			assert.Equal(t, test.allowed, response.Allowed)
+			if !test.allowed {
+				assert.Equal(t, int32(http.StatusForbidden), response.Result.Code)
+			}
+			if test.warning {
+				assert.Contains(t, response.AuditAnnotations, warningAuditAnnotation)
+			} else {
+				assert.NotContains(t, response.AuditAnnotations, warningAuditAnnotation)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"github.com/atomix/atomix-go-framework/pkg/atomix/errors"
+	"github.com/lni/dragonboat/v3"
+	"time"
+)
+
+const adminTimeout = 10 * time.Second
+
+// NewAdminServer returns a new RaftAdmin server for the given protocol
+func NewAdminServer(protocol *Protocol) *AdminServer {
+	return &AdminServer{
+		protocol: protocol,
+	}
+}
+
+// AdminServer is a server for managing the Raft partitions on a node
+type AdminServer struct {
+	protocol *Protocol
+}
+
+// AddMember adds a member to a partition
+func (s *AdminServer) AddMember(ctx context.Context, request *AddMemberRequest) (*AddMemberResponse, error) {
+	node, err := s.protocol.getNodeHost()
+	if err != nil {
+		return nil, errors.Proto(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adminTimeout)
+	defer cancel()
+
+	// Membership changes may be retried by the controller, so skip members that have already been added
+	membership, err := node.SyncGetClusterMembership(ctx, request.Partition)
+	if err != nil {
+		return nil, errors.Proto(getAdminError(err))
+	}
+	if _, ok := membership.Removed[request.NodeID]; ok {
+		return nil, errors.Proto(errors.NewConflict("member %d was removed from partition %d", request.NodeID, request.Partition))
+	}
+	if _, ok := membership.Nodes[request.NodeID]; ok {
+		return &AddMemberResponse{}, nil
+	}
+	if _, ok := membership.Observers[request.NodeID]; ok && request.Learner {
+		return &AddMemberResponse{}, nil
+	}
+
+	log.Infof("Adding member %d at %s to partition %d", request.NodeID, request.Address, request.Partition)
+	if request.Learner {
+		err = node.SyncRequestAddObserver(ctx, request.Partition, request.NodeID, request.Address, 0)
+	} else {
+		err = node.SyncRequestAddNode(ctx, request.Partition, request.NodeID, request.Address, 0)
+	}
+	if err != nil {
+		log.Warnf("Failed to add member %d to partition %d: %s", request.NodeID, request.Partition, err)
+		return nil, errors.Proto(getAdminError(err))
+	}
+	s.protocol.setMemberID(request.Partition, request.NodeID, s.protocol.getReplicaForAddress(request.Address))
+	return &AddMemberResponse{}, nil
+}
+
+// RemoveMember removes a member from a partition
+func (s *AdminServer) RemoveMember(ctx context.Context, request *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	node, err := s.protocol.getNodeHost()
+	if err != nil {
+		return nil, errors.Proto(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adminTimeout)
+	defer cancel()
+
+	membership, err := node.SyncGetClusterMembership(ctx, request.Partition)
+	if err != nil {
+		return nil, errors.Proto(getAdminError(err))
+	}
+	_, isNode := membership.Nodes[request.NodeID]
+	_, isObserver := membership.Observers[request.NodeID]
+	if !isNode && !isObserver {
+		return &RemoveMemberResponse{}, nil
+	}
+
+	log.Infof("Removing member %d from partition %d", request.NodeID, request.Partition)
+	if err := node.SyncRequestDeleteNode(ctx, request.Partition, request.NodeID, 0); err != nil {
+		log.Warnf("Failed to remove member %d from partition %d: %s", request.NodeID, request.Partition, err)
+		return nil, errors.Proto(getAdminError(err))
+	}
+	return &RemoveMemberResponse{}, nil
+}
+
+// SyncMember waits for the local member of a partition to apply all committed entries
+func (s *AdminServer) SyncMember(ctx context.Context, request *SyncMemberRequest) (*SyncMemberResponse, error) {
+	node, err := s.protocol.getNodeHost()
+	if err != nil {
+		return nil, errors.Proto(err)
+	}
+
+	// A completed read index request guarantees the local state machine has applied
+	// all entries that were committed when the request was made
+	state, err := node.ReadIndex(request.Partition, adminTimeout)
+	if err != nil {
+		return nil, errors.Proto(getAdminError(err))
+	}
+	defer state.Release()
+
+	select {
+	case result := <-state.ResultC():
+		if !result.Completed() {
+			return nil, errors.Proto(errors.NewUnavailable("partition %d is not in sync", request.Partition))
+		}
+		return &SyncMemberResponse{}, nil
+	case <-ctx.Done():
+		return nil, errors.Proto(errors.NewTimeout(ctx.Err().Error()))
+	}
+}
+
+// TransferLeadership transfers leadership of a partition away from the local member
+func (s *AdminServer) TransferLeadership(ctx context.Context, request *TransferLeadershipRequest) (*TransferLeadershipResponse, error) {
+	node, err := s.protocol.getNodeHost()
+	if err != nil {
+		return nil, errors.Proto(err)
+	}
+
+	nodeID, ok := s.protocol.getNodeID(request.Partition)
+	if !ok {
+		return nil, errors.Proto(errors.NewNotFound("partition %d not found", request.Partition))
+	}
+
+	leaderID, ok, err := node.GetLeaderID(request.Partition)
+	if err != nil {
+		return nil, errors.Proto(getAdminError(err))
+	} else if ok && leaderID != nodeID {
+		return &TransferLeadershipResponse{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adminTimeout)
+	defer cancel()
+
+	targetID := request.NodeID
+	if targetID == 0 {
+		membership, err := node.SyncGetClusterMembership(ctx, request.Partition)
+		if err != nil {
+			return nil, errors.Proto(getAdminError(err))
+		}
+		for memberID := range membership.Nodes {
+			if memberID != nodeID && (targetID == 0 || memberID < targetID) {
+				targetID = memberID
+			}
+		}
+		if targetID == 0 {
+			return nil, errors.Proto(errors.NewConflict("no voting members to transfer leadership of partition %d", request.Partition))
+		}
+	}
+
+	log.Infof("Transferring leadership of partition %d to member %d", request.Partition, targetID)
+	if err := node.RequestLeaderTransfer(request.Partition, targetID); err != nil {
+		return nil, errors.Proto(getAdminError(err))
+	}
+
+	// Leader transfers are asynchronous, so wait for a new leader to be elected
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			leaderID, ok, err := node.GetLeaderID(request.Partition)
+			if err == nil && ok && leaderID != nodeID {
+				return &TransferLeadershipResponse{}, nil
+			}
+		case <-ctx.Done():
+			return nil, errors.Proto(errors.NewTimeout("failed to transfer leadership of partition %d", request.Partition))
+		}
+	}
+}
+
+// AcquireLeadership transfers leadership of a partition to the local member
+func (s *AdminServer) AcquireLeadership(ctx context.Context, request *AcquireLeadershipRequest) (*AcquireLeadershipResponse, error) {
+	node, err := s.protocol.getNodeHost()
+	if err != nil {
+		return nil, errors.Proto(err)
+	}
+
+	nodeID, ok := s.protocol.getNodeID(request.Partition)
+	if !ok {
+		return nil, errors.Proto(errors.NewNotFound("partition %d not found", request.Partition))
+	}
+
+	leaderID, ok, err := node.GetLeaderID(request.Partition)
+	if err != nil {
+		return nil, errors.Proto(getAdminError(err))
+	} else if !ok {
+		return nil, errors.Proto(errors.NewUnavailable("partition %d has no leader", request.Partition))
+	} else if leaderID == nodeID {
+		return &AcquireLeadershipResponse{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adminTimeout)
+	defer cancel()
+
+	// Followers forward leader transfer requests to the current leader
+	log.Infof("Transferring leadership of partition %d from member %d to member %d", request.Partition, leaderID, nodeID)
+	if err := node.RequestLeaderTransfer(request.Partition, nodeID); err != nil {
+		return nil, errors.Proto(getAdminError(err))
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			leaderID, ok, err := node.GetLeaderID(request.Partition)
+			if err == nil && ok && leaderID == nodeID {
+				return &AcquireLeadershipResponse{}, nil
+			}
+		case <-ctx.Done():
+			return nil, errors.Proto(errors.NewTimeout("failed to acquire leadership of partition %d", request.Partition))
+		}
+	}
+}
+
+// SnapshotMember takes a snapshot of the local member of a partition
+func (s *AdminServer) SnapshotMember(ctx context.Context, request *SnapshotMemberRequest) (*SnapshotMemberResponse, error) {
+	node, err := s.protocol.getNodeHost()
+	if err != nil {
+		return nil, errors.Proto(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adminTimeout)
+	defer cancel()
+
+	option := dragonboat.DefaultSnapshotOption
+	if request.CompactionOverhead > 0 {
+		option.CompactionOverhead = request.CompactionOverhead
+		option.OverrideCompactionOverhead = true
+	}
+
+	log.Infof("Taking snapshot of partition %d", request.Partition)
+	index, err := node.SyncRequestSnapshot(ctx, request.Partition, option)
+	if err != nil {
+		log.Warnf("Failed to take snapshot of partition %d: %s", request.Partition, err)
+		return nil, errors.Proto(getAdminError(err))
+	}
+	return &SnapshotMemberResponse{
+		Index: index,
+	}, nil
+}
+
+// CompactMember compacts the log of the local member of a partition up to its last snapshot
+func (s *AdminServer) CompactMember(ctx context.Context, request *CompactMemberRequest) (*CompactMemberResponse, error) {
+	node, err := s.protocol.getNodeHost()
+	if err != nil {
+		return nil, errors.Proto(err)
+	}
+
+	nodeID, ok := s.protocol.getNodeID(request.Partition)
+	if !ok {
+		return nil, errors.Proto(errors.NewNotFound("partition %d not found", request.Partition))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adminTimeout)
+	defer cancel()
+
+	log.Infof("Compacting log of partition %d", request.Partition)
+	if err := compactLog(ctx, node, request.Partition, nodeID, s.protocol.getStats(request.Partition)); err != nil {
+		log.Warnf("Failed to compact log of partition %d: %s", request.Partition, err)
+		return nil, errors.Proto(getAdminError(err))
+	}
+	return &CompactMemberResponse{}, nil
+}
+
+// RestartMember restarts the local member of a partition from its persisted state
+func (s *AdminServer) RestartMember(ctx context.Context, request *RestartMemberRequest) (*RestartMemberResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, adminTimeout)
+	defer cancel()
+
+	log.Infof("Restarting member of partition %d", request.Partition)
+	if err := s.protocol.restartPartition(ctx, request.Partition); err != nil {
+		log.Warnf("Failed to restart member of partition %d: %s", request.Partition, err)
+		return nil, errors.Proto(getAdminError(err))
+	}
+	return &RestartMemberResponse{}, nil
+}
+
+// StartMember starts a member of a partition on the local node
+func (s *AdminServer) StartMember(ctx context.Context, request *StartMemberRequest) (*StartMemberResponse, error) {
+	log.Infof("Starting member %d of partition %d", request.NodeID, request.Partition)
+	if err := s.protocol.startPartition(request.Partition, request.NodeID, map[uint64]string{}, request.Join, request.Learner); err != nil {
+		log.Warnf("Failed to start member %d of partition %d: %s", request.NodeID, request.Partition, err)
+		return nil, errors.Proto(getAdminError(err))
+	}
+	return &StartMemberResponse{}, nil
+}
+
+// StopMember stops the local member of a partition and removes its data
+func (s *AdminServer) StopMember(ctx context.Context, request *StopMemberRequest) (*StopMemberResponse, error) {
+	log.Infof("Stopping member of partition %d", request.Partition)
+	ctx, cancel := context.WithTimeout(ctx, adminTimeout)
+	defer cancel()
+	if err := s.protocol.stopPartition(ctx, request.Partition); err != nil {
+		log.Warnf("Failed to stop member of partition %d: %s", request.Partition, err)
+		return nil, errors.Proto(getAdminError(err))
+	}
+	return &StopMemberResponse{}, nil
+}
+
+// getAdminError converts the given dragonboat error to an Atomix error
+func getAdminError(err error) error {
+	if _, ok := err.(*errors.TypedError); ok {
+		return err
+	}
+	switch err {
+	case dragonboat.ErrClusterNotFound:
+		return errors.NewNotFound(err.Error())
+	case dragonboat.ErrTimeout:
+		return errors.NewTimeout(err.Error())
+	case dragonboat.ErrRejected, dragonboat.ErrInvalidOperation:
+		return errors.NewConflict(err.Error())
+	case dragonboat.ErrSystemBusy, dragonboat.ErrClusterNotReady, dragonboat.ErrClusterClosed:
+		return errors.NewUnavailable(err.Error())
+	}
+	return errors.NewInternal(err.Error())
+}
@@ -0,0 +1,46 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"github.com/atomix/atomix-go-framework/pkg/atomix/errors"
+	"github.com/lni/dragonboat/v3"
+	"time"
+)
+
+// snapshotCheckInterval is the interval at which the size of the entries applied since the last snapshot is checked
+const snapshotCheckInterval = time.Second
+
+// compactLog compacts the log of the local member of a partition up to its last snapshot, waiting for the
+// compaction to complete. Requests are not an error when there are no entries to reclaim.
+func compactLog(ctx context.Context, node *dragonboat.NodeHost, clusterID uint64, nodeID uint64, stats *memberStats) error {
+	stats.setCompactionRequested(true)
+	state, err := node.RequestCompaction(clusterID, nodeID)
+	if err == dragonboat.ErrRejected {
+		stats.setCompactionRequested(false)
+		return nil
+	} else if err != nil {
+		stats.setCompactionRequested(false)
+		return err
+	}
+
+	select {
+	case <-state.CompletedC():
+		return nil
+	case <-ctx.Done():
+		return errors.NewTimeout("failed to compact log of partition %d", clusterID)
+	}
+}
@@ -17,16 +17,15 @@ package config
 import "time"
 
 const (
-	defaultElectionTimeout   = 5 * time.Second
-	defaultHeartbeatInterval = 500 * time.Millisecond
-	defaultSnapshotInterval  = 1 * time.Minute
+	defaultElectionTimeout   = 2 * time.Second
+	defaultHeartbeatInterval = 200 * time.Millisecond
 	defaultSnapshotThreshold = 10000
 )
 
 // GetElectionTimeoutOrDefault returns the configured election timeout if set, otherwise the default election timeout
 func (c *ProtocolConfig) GetElectionTimeoutOrDefault() time.Duration {
 	timeout := c.GetElectionTimeout()
-	if timeout != nil {
+	if timeout != nil && *timeout > 0 {
 		return *timeout
 	}
 	return defaultElectionTimeout
@@ -35,19 +34,20 @@ func (c *ProtocolConfig) GetElectionTimeoutOrDefault() time.Duration {
 // GetHeartbeatIntervalOrDefault returns the configured heartbeat interval if set, otherwise the default heartbeat interval
 func (c *ProtocolConfig) GetHeartbeatIntervalOrDefault() time.Duration {
 	interval := c.GetHeartbeatInterval()
-	if interval != nil {
+	if interval != nil && *interval > 0 {
 		return *interval
 	}
 	return defaultHeartbeatInterval
 }
 
-// GetSnapshotIntervalOrDefault returns the configured snapshot interval if set, otherwise the default snapshot interval
+// GetSnapshotIntervalOrDefault returns the configured snapshot interval if set, otherwise zero if snapshots are
+// only taken once the snapshot threshold is reached
 func (c *ProtocolConfig) GetSnapshotIntervalOrDefault() time.Duration {
 	interval := c.GetSnapshotInterval()
-	if interval != nil {
+	if interval != nil && *interval > 0 {
 		return *interval
 	}
-	return defaultSnapshotInterval
+	return 0
 }
 
 // GetSnapshotThresholdOrDefault returns the configured snapshot threshold if set, otherwise the default snapshot threshold
@@ -58,3 +58,22 @@ func (c *ProtocolConfig) GetSnapshotThresholdOrDefault() uint64 {
 	}
 	return defaultSnapshotThreshold
 }
+
+// GetCompactionOverheadOrDefault returns the configured compaction overhead if set, otherwise a tenth of the snapshot threshold
+func (c *ProtocolConfig) GetCompactionOverheadOrDefault() uint64 {
+	overhead := c.GetCompactionOverhead()
+	if overhead > 0 {
+		return overhead
+	}
+	return c.GetSnapshotThresholdOrDefault() / 10
+}
+
+// GetCompactionIntervalOrDefault returns the interval at which logs are compacted when automatic compaction is
+// disabled, or zero if logs are only compacted on request
+func (c *ProtocolConfig) GetCompactionIntervalOrDefault() time.Duration {
+	interval := c.GetCompactionInterval()
+	if interval != nil && c.GetDisableAutoCompaction() {
+		return *interval
+	}
+	return 0
+}
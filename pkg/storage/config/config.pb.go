@@ -32,6 +32,22 @@ type ProtocolConfig struct {
 	HeartbeatInterval *time.Duration `protobuf:"bytes,2,opt,name=heartbeat_interval,json=heartbeatInterval,proto3,stdduration" json:"heartbeat_interval,omitempty"`
 	SnapshotInterval  *time.Duration `protobuf:"bytes,3,opt,name=snapshot_interval,json=snapshotInterval,proto3,stdduration" json:"snapshot_interval,omitempty"`
 	SnapshotThreshold uint64         `protobuf:"varint,4,opt,name=snapshot_threshold,json=snapshotThreshold,proto3" json:"snapshot_threshold,omitempty"`
+	// max_disk_usage is the percentage of the data volume's capacity above which writes are rejected.
+	// Writes are never rejected when unset.
+	MaxDiskUsage uint32 `protobuf:"varint,5,opt,name=max_disk_usage,json=maxDiskUsage,proto3" json:"max_disk_usage,omitempty"`
+	// compaction_overhead is the number of entries retained in the log when it's compacted.
+	// Defaults to a tenth of the snapshot threshold.
+	CompactionOverhead uint64 `protobuf:"varint,6,opt,name=compaction_overhead,json=compactionOverhead,proto3" json:"compaction_overhead,omitempty"`
+	// snapshot_threshold_bytes is the size in bytes of the entries applied since the last snapshot after
+	// which a snapshot is taken, in addition to the entry count threshold. Disabled when unset.
+	SnapshotThresholdBytes uint64 `protobuf:"varint,7,opt,name=snapshot_threshold_bytes,json=snapshotThresholdBytes,proto3" json:"snapshot_threshold_bytes,omitempty"`
+	// disable_auto_compaction disables the compaction of the log when a snapshot is taken
+	DisableAutoCompaction bool `protobuf:"varint,8,opt,name=disable_auto_compaction,json=disableAutoCompaction,proto3" json:"disable_auto_compaction,omitempty"`
+	// compaction_interval is the interval at which logs are compacted when automatic compaction is disabled
+	CompactionInterval *time.Duration `protobuf:"bytes,9,opt,name=compaction_interval,json=compactionInterval,proto3,stdduration" json:"compaction_interval,omitempty"`
+	// encryption_key_id is the identifier of the key with which log entries and snapshots are encrypted.
+	// Entries and snapshots are written unencrypted when unset.
+	EncryptionKeyId string `protobuf:"bytes,11,opt,name=encryption_key_id,json=encryptionKeyId,proto3" json:"encryption_key_id,omitempty"`
 }
 
 func (m *ProtocolConfig) Reset()         { *m = ProtocolConfig{} }
@@ -95,32 +111,270 @@ func (m *ProtocolConfig) GetSnapshotThreshold() uint64 {
 	return 0
 }
 
+func (m *ProtocolConfig) GetMaxDiskUsage() uint32 {
+	if m != nil {
+		return m.MaxDiskUsage
+	}
+	return 0
+}
+
+func (m *ProtocolConfig) GetCompactionOverhead() uint64 {
+	if m != nil {
+		return m.CompactionOverhead
+	}
+	return 0
+}
+
+func (m *ProtocolConfig) GetSnapshotThresholdBytes() uint64 {
+	if m != nil {
+		return m.SnapshotThresholdBytes
+	}
+	return 0
+}
+
+func (m *ProtocolConfig) GetDisableAutoCompaction() bool {
+	if m != nil {
+		return m.DisableAutoCompaction
+	}
+	return false
+}
+
+func (m *ProtocolConfig) GetCompactionInterval() *time.Duration {
+	if m != nil {
+		return m.CompactionInterval
+	}
+	return nil
+}
+
+func (m *ProtocolConfig) GetEncryptionKeyId() string {
+	if m != nil {
+		return m.EncryptionKeyId
+	}
+	return ""
+}
+
+// MembershipConfig is the Raft membership configuration for the partitions in a cluster
+type MembershipConfig struct {
+	Partitions []PartitionMembership `protobuf:"bytes,1,rep,name=partitions,proto3" json:"partitions"`
+}
+
+func (m *MembershipConfig) Reset()         { *m = MembershipConfig{} }
+func (m *MembershipConfig) String() string { return proto.CompactTextString(m) }
+func (*MembershipConfig) ProtoMessage()    {}
+func (*MembershipConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ac523a84bbf07b3d, []int{1}
+}
+func (m *MembershipConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MembershipConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MembershipConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MembershipConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembershipConfig.Merge(m, src)
+}
+func (m *MembershipConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *MembershipConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembershipConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MembershipConfig proto.InternalMessageInfo
+
+func (m *MembershipConfig) GetPartitions() []PartitionMembership {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+// PartitionMembership is the Raft membership of a single partition
+type PartitionMembership struct {
+	PartitionID uint32         `protobuf:"varint,1,opt,name=partition_id,json=partitionId,proto3" json:"partition_id,omitempty"`
+	Members     []MemberConfig `protobuf:"bytes,2,rep,name=members,proto3" json:"members"`
+}
+
+func (m *PartitionMembership) Reset()         { *m = PartitionMembership{} }
+func (m *PartitionMembership) String() string { return proto.CompactTextString(m) }
+func (*PartitionMembership) ProtoMessage()    {}
+func (*PartitionMembership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ac523a84bbf07b3d, []int{2}
+}
+func (m *PartitionMembership) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PartitionMembership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PartitionMembership.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PartitionMembership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionMembership.Merge(m, src)
+}
+func (m *PartitionMembership) XXX_Size() int {
+	return m.Size()
+}
+func (m *PartitionMembership) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionMembership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionMembership proto.InternalMessageInfo
+
+func (m *PartitionMembership) GetPartitionID() uint32 {
+	if m != nil {
+		return m.PartitionID
+	}
+	return 0
+}
+
+func (m *PartitionMembership) GetMembers() []MemberConfig {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+// MemberConfig is the configuration of a single Raft member
+type MemberConfig struct {
+	// replica_id is the identifier of the replica on which the member runs
+	ReplicaID string `protobuf:"bytes,1,opt,name=replica_id,json=replicaId,proto3" json:"replica_id,omitempty"`
+	// node_id is the Raft node identifier of the member
+	NodeID uint64 `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// join indicates whether the member joins the running partition rather than bootstrapping it
+	Join bool `protobuf:"varint,3,opt,name=join,proto3" json:"join,omitempty"`
+	// learner indicates whether the member is a non-voting learner
+	Learner bool `protobuf:"varint,4,opt,name=learner,proto3" json:"learner,omitempty"`
+}
+
+func (m *MemberConfig) Reset()         { *m = MemberConfig{} }
+func (m *MemberConfig) String() string { return proto.CompactTextString(m) }
+func (*MemberConfig) ProtoMessage()    {}
+func (*MemberConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ac523a84bbf07b3d, []int{3}
+}
+func (m *MemberConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MemberConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MemberConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberConfig.Merge(m, src)
+}
+func (m *MemberConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberConfig proto.InternalMessageInfo
+
+func (m *MemberConfig) GetReplicaID() string {
+	if m != nil {
+		return m.ReplicaID
+	}
+	return ""
+}
+
+func (m *MemberConfig) GetNodeID() uint64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *MemberConfig) GetJoin() bool {
+	if m != nil {
+		return m.Join
+	}
+	return false
+}
+
+func (m *MemberConfig) GetLearner() bool {
+	if m != nil {
+		return m.Learner
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ProtocolConfig)(nil), "atomix.raft.config.ProtocolConfig")
+	proto.RegisterType((*MembershipConfig)(nil), "atomix.raft.config.MembershipConfig")
+	proto.RegisterType((*PartitionMembership)(nil), "atomix.raft.config.PartitionMembership")
+	proto.RegisterType((*MemberConfig)(nil), "atomix.raft.config.MemberConfig")
 }
 
 func init() { proto.RegisterFile("storage/config/config.proto", fileDescriptor_ac523a84bbf07b3d) }
 
 var fileDescriptor_ac523a84bbf07b3d = []byte{
-	// 288 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x8d, 0x31, 0x4e, 0xc3, 0x30,
-	0x14, 0x86, 0xeb, 0x12, 0x31, 0x18, 0x09, 0x5a, 0x8b, 0x21, 0x14, 0xc9, 0x54, 0x88, 0xa1, 0x0b,
-	0x8e, 0x04, 0x37, 0x28, 0x2c, 0x20, 0x84, 0x50, 0xd5, 0xbd, 0x72, 0x52, 0xc7, 0x89, 0x94, 0xe6,
-	0x55, 0xce, 0x0b, 0xe2, 0x18, 0x8c, 0x1c, 0x01, 0x6e, 0xc0, 0x11, 0x18, 0x3b, 0xb2, 0x01, 0xc9,
-	0x25, 0x18, 0x51, 0xe2, 0x24, 0x62, 0xec, 0x94, 0x5f, 0x79, 0xfe, 0xbe, 0x8f, 0x1e, 0x67, 0x08,
-	0x46, 0x6a, 0xe5, 0x05, 0x90, 0x86, 0xb1, 0x6e, 0x3e, 0x62, 0x6d, 0x00, 0x81, 0x31, 0x89, 0xb0,
-	0x8a, 0x9f, 0x84, 0x91, 0x21, 0x0a, 0x7b, 0x19, 0x71, 0x0d, 0xa0, 0x13, 0xe5, 0xd5, 0x2f, 0xfc,
-	0x3c, 0xf4, 0x96, 0xb9, 0x91, 0x18, 0x43, 0x6a, 0x99, 0xd1, 0xa1, 0x06, 0x0d, 0xf5, 0xf4, 0xaa,
-	0x65, 0xff, 0x9e, 0xbe, 0xf5, 0xe9, 0xfe, 0x43, 0xb5, 0x02, 0x48, 0xae, 0x6a, 0x11, 0xbb, 0xa5,
-	0x03, 0x95, 0xa8, 0xa0, 0x42, 0x17, 0x18, 0xaf, 0x14, 0xe4, 0xe8, 0x92, 0x31, 0x99, 0xec, 0x5d,
-	0x1c, 0x09, 0xdb, 0x10, 0x6d, 0x43, 0x5c, 0x37, 0x8d, 0xa9, 0xf3, 0xf2, 0x75, 0x42, 0x66, 0x07,
-	0x2d, 0x38, 0xb7, 0x1c, 0xbb, 0xa7, 0x2c, 0x52, 0xd2, 0xa0, 0xaf, 0x24, 0x2e, 0xe2, 0x14, 0x95,
-	0x79, 0x94, 0x89, 0xdb, 0xdf, 0xce, 0x36, 0xec, 0xd0, 0x9b, 0x86, 0x64, 0x77, 0x74, 0x98, 0xa5,
-	0x72, 0x9d, 0x45, 0xf0, 0x4f, 0xb7, 0xb3, 0x9d, 0x6e, 0xd0, 0x92, 0x9d, 0xed, 0x9c, 0xb2, 0xce,
-	0x86, 0x91, 0x51, 0x59, 0x04, 0xc9, 0xd2, 0x75, 0xc6, 0x64, 0xe2, 0xcc, 0xba, 0xce, 0xbc, 0x3d,
-	0x4c, 0xcf, 0x7e, 0x7f, 0x38, 0x79, 0x2d, 0x38, 0x79, 0x2f, 0x38, 0xf9, 0x28, 0x38, 0xd9, 0x14,
-	0x9c, 0x7c, 0x17, 0x9c, 0x3c, 0x97, 0xbc, 0xb7, 0x29, 0x79, 0xef, 0xb3, 0xe4, 0x3d, 0x7f, 0xb7,
-	0xee, 0x5f, 0xfe, 0x05, 0x00, 0x00, 0xff, 0xff, 0x4c, 0x3f, 0xb7, 0x72, 0xc1, 0x01, 0x00, 0x00,
+	// 627 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcb, 0x6e, 0xd3, 0x4c,
+	0x14, 0xce, 0xb4, 0xfe, 0x73, 0x39, 0xe9, 0x25, 0x9d, 0xfe, 0xff, 0x8f, 0x29, 0x92, 0x63, 0x85,
+	0x4a, 0x44, 0x08, 0x1c, 0xa9, 0x48, 0x88, 0x25, 0xb8, 0xd9, 0xb8, 0xd0, 0x52, 0x59, 0x65, 0x6d,
+	0x4d, 0xe2, 0xa9, 0x33, 0xd4, 0xf6, 0x58, 0xe3, 0x49, 0xd5, 0x3c, 0x03, 0x0b, 0x58, 0xf2, 0x08,
+	0x3c, 0x02, 0x8f, 0xd0, 0x65, 0x97, 0xac, 0x02, 0xb8, 0xef, 0x80, 0x58, 0x22, 0x5f, 0x13, 0xd4,
+	0x2e, 0xba, 0xf2, 0xf1, 0x7c, 0x97, 0xf3, 0xcd, 0xd1, 0x19, 0x78, 0x10, 0x4b, 0x2e, 0x88, 0x47,
+	0x07, 0x63, 0x1e, 0x9e, 0x32, 0xaf, 0xf8, 0x18, 0x91, 0xe0, 0x92, 0x63, 0x4c, 0x24, 0x0f, 0xd8,
+	0x85, 0x21, 0xc8, 0xa9, 0x34, 0x72, 0x64, 0x47, 0xf3, 0x38, 0xf7, 0x7c, 0x3a, 0xc8, 0x18, 0xa3,
+	0xe9, 0xe9, 0xc0, 0x9d, 0x0a, 0x22, 0x19, 0x0f, 0x73, 0xcd, 0xce, 0xbf, 0x1e, 0xf7, 0x78, 0x56,
+	0x0e, 0xd2, 0x2a, 0x3f, 0xed, 0xfd, 0x52, 0x60, 0xe3, 0x38, 0xad, 0xc6, 0xdc, 0xdf, 0xcf, 0x8c,
+	0xf0, 0x01, 0x74, 0xa8, 0x4f, 0xc7, 0xa9, 0xd4, 0x91, 0x2c, 0xa0, 0x7c, 0x2a, 0x55, 0xa4, 0xa3,
+	0x7e, 0x7b, 0xef, 0xbe, 0x91, 0xf7, 0x30, 0xca, 0x1e, 0xc6, 0xb0, 0xe8, 0x61, 0x2a, 0x9f, 0xbf,
+	0x77, 0x91, 0xbd, 0x59, 0x0a, 0x4f, 0x72, 0x1d, 0x3e, 0x02, 0x3c, 0xa1, 0x44, 0xc8, 0x11, 0x25,
+	0xd2, 0x61, 0xa1, 0xa4, 0xe2, 0x9c, 0xf8, 0xea, 0xca, 0xdd, 0xdc, 0xb6, 0x2a, 0xa9, 0x55, 0x28,
+	0xf1, 0x1b, 0xd8, 0x8a, 0x43, 0x12, 0xc5, 0x13, 0xbe, 0x64, 0xb7, 0x7a, 0x37, 0xbb, 0x4e, 0xa9,
+	0xac, 0xdc, 0x9e, 0x02, 0xae, 0xdc, 0xe4, 0x44, 0xd0, 0x78, 0xc2, 0x7d, 0x57, 0x55, 0x74, 0xd4,
+	0x57, 0xec, 0xaa, 0xcf, 0x49, 0x09, 0xe0, 0x5d, 0xd8, 0x08, 0xc8, 0x85, 0xe3, 0xb2, 0xf8, 0xcc,
+	0x99, 0xc6, 0xc4, 0xa3, 0xea, 0x3f, 0x3a, 0xea, 0xaf, 0xdb, 0x6b, 0x01, 0xb9, 0x18, 0xb2, 0xf8,
+	0xec, 0x5d, 0x7a, 0x86, 0x07, 0xb0, 0x3d, 0xe6, 0x41, 0x44, 0xf2, 0x01, 0xf2, 0x73, 0x2a, 0x26,
+	0x94, 0xb8, 0x6a, 0x3d, 0x73, 0xc5, 0x0b, 0xe8, 0x6d, 0x81, 0xe0, 0x17, 0xa0, 0xde, 0x4c, 0xe1,
+	0x8c, 0x66, 0x92, 0xc6, 0x6a, 0x23, 0x53, 0xfd, 0x7f, 0x23, 0x8b, 0x99, 0xa2, 0xf8, 0x39, 0xdc,
+	0x73, 0x59, 0x4c, 0x46, 0x3e, 0x75, 0xc8, 0x54, 0x72, 0x67, 0x61, 0xae, 0x36, 0x75, 0xd4, 0x6f,
+	0xda, 0xff, 0x15, 0xf0, 0xab, 0xa9, 0xe4, 0xfb, 0x15, 0x88, 0x8f, 0xff, 0x8a, 0x58, 0xcd, 0xb1,
+	0x75, 0xb7, 0x39, 0x2e, 0xdd, 0xa1, 0x9a, 0xe4, 0x63, 0xd8, 0xa2, 0xe1, 0x58, 0xcc, 0xa2, 0xcc,
+	0xf1, 0x8c, 0xce, 0x1c, 0xe6, 0xaa, 0x6d, 0x1d, 0xf5, 0x5b, 0xf6, 0xe6, 0x02, 0x78, 0x4d, 0x67,
+	0x96, 0x7b, 0xa0, 0x34, 0xa1, 0xd3, 0xee, 0x11, 0xe8, 0x1c, 0xd2, 0x60, 0x44, 0x45, 0x3c, 0x61,
+	0x51, 0xb1, 0x79, 0x87, 0x00, 0x11, 0x11, 0x92, 0xa5, 0xdc, 0x58, 0x45, 0xfa, 0x6a, 0xbf, 0xbd,
+	0xf7, 0xc8, 0xb8, 0xb9, 0xeb, 0xc6, 0x71, 0xc9, 0x5a, 0x58, 0x98, 0xca, 0xe5, 0xbc, 0x5b, 0xb3,
+	0x97, 0x0c, 0x7a, 0x1f, 0x10, 0x6c, 0xdf, 0xc2, 0xc4, 0x7b, 0xb0, 0x56, 0xb1, 0xd2, 0x9c, 0xe9,
+	0x72, 0xaf, 0x9b, 0x9b, 0xc9, 0xbc, 0xdb, 0xae, 0xe8, 0xd6, 0xd0, 0x6e, 0x57, 0x24, 0xcb, 0xc5,
+	0x2f, 0xa1, 0x11, 0xe4, 0x0e, 0xea, 0x4a, 0x96, 0x4b, 0xbf, 0x2d, 0x57, 0xde, 0x24, 0xbf, 0x4d,
+	0x11, 0xa8, 0x94, 0xf5, 0x3e, 0x22, 0x58, 0x5b, 0xc6, 0xf1, 0x13, 0x00, 0x41, 0x23, 0x9f, 0x8d,
+	0x49, 0x19, 0xa2, 0x65, 0xae, 0x27, 0xf3, 0x6e, 0xcb, 0xce, 0x4f, 0xad, 0xa1, 0xdd, 0x2a, 0x08,
+	0x96, 0x8b, 0x1f, 0x42, 0x23, 0xe4, 0x2e, 0x4d, 0xa9, 0xe9, 0xf3, 0x51, 0x4c, 0x48, 0xe6, 0xdd,
+	0xfa, 0x11, 0x77, 0xa9, 0x35, 0xb4, 0xeb, 0x29, 0x64, 0xb9, 0x18, 0x83, 0xf2, 0x9e, 0xb3, 0x30,
+	0x7b, 0x11, 0x4d, 0x3b, 0xab, 0xb1, 0x0a, 0x0d, 0x9f, 0x12, 0x11, 0x52, 0x91, 0x6d, 0x76, 0xd3,
+	0x2e, 0x7f, 0xcd, 0xdd, 0xdf, 0x3f, 0x35, 0xf4, 0x25, 0xd1, 0xd0, 0xd7, 0x44, 0x43, 0x97, 0x89,
+	0x86, 0xae, 0x12, 0x0d, 0xfd, 0x48, 0x34, 0xf4, 0xe9, 0x5a, 0xab, 0x5d, 0x5d, 0x6b, 0xb5, 0x6f,
+	0xd7, 0x5a, 0x6d, 0x54, 0xcf, 0xf6, 0xe0, 0xd9, 0x9f, 0x01, 0x00, 0xac, 0xd8, 0xfc, 0x83, 0x91,
+	0x04, 0x00, 0x00,
 }
 
 func (this *ProtocolConfig) Equal(that interface{}) bool {
@@ -172,6 +426,124 @@ func (this *ProtocolConfig) Equal(that interface{}) bool {
 	if this.SnapshotThreshold != that1.SnapshotThreshold {
 		return false
 	}
+	if this.MaxDiskUsage != that1.MaxDiskUsage {
+		return false
+	}
+	if this.CompactionOverhead != that1.CompactionOverhead {
+		return false
+	}
+	if this.SnapshotThresholdBytes != that1.SnapshotThresholdBytes {
+		return false
+	}
+	if this.DisableAutoCompaction != that1.DisableAutoCompaction {
+		return false
+	}
+	if this.CompactionInterval != nil && that1.CompactionInterval != nil {
+		if *this.CompactionInterval != *that1.CompactionInterval {
+			return false
+		}
+	} else if this.CompactionInterval != nil {
+		return false
+	} else if that1.CompactionInterval != nil {
+		return false
+	}
+	if this.EncryptionKeyId != that1.EncryptionKeyId {
+		return false
+	}
+	return true
+}
+func (this *MembershipConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MembershipConfig)
+	if !ok {
+		that2, ok := that.(MembershipConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Partitions) != len(that1.Partitions) {
+		return false
+	}
+	for i := range this.Partitions {
+		if !this.Partitions[i].Equal(&that1.Partitions[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *PartitionMembership) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PartitionMembership)
+	if !ok {
+		that2, ok := that.(PartitionMembership)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.PartitionID != that1.PartitionID {
+		return false
+	}
+	if len(this.Members) != len(that1.Members) {
+		return false
+	}
+	for i := range this.Members {
+		if !this.Members[i].Equal(&that1.Members[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *MemberConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MemberConfig)
+	if !ok {
+		that2, ok := that.(MemberConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ReplicaID != that1.ReplicaID {
+		return false
+	}
+	if this.NodeID != that1.NodeID {
+		return false
+	}
+	if this.Join != that1.Join {
+		return false
+	}
+	if this.Learner != that1.Learner {
+		return false
+	}
 	return true
 }
 func (m *ProtocolConfig) Marshal() (dAtA []byte, err error) {
@@ -194,51 +566,227 @@ func (m *ProtocolConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if m.SnapshotThreshold != 0 {
-		i = encodeVarintConfig(dAtA, i, uint64(m.SnapshotThreshold))
+	if len(m.EncryptionKeyId) > 0 {
+		i -= len(m.EncryptionKeyId)
+		copy(dAtA[i:], m.EncryptionKeyId)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.EncryptionKeyId)))
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x5a
 	}
-	if m.SnapshotInterval != nil {
-		n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.SnapshotInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SnapshotInterval):])
+	if m.CompactionInterval != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.CompactionInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.CompactionInterval):])
 		if err1 != nil {
 			return 0, err1
 		}
 		i -= n1
 		i = encodeVarintConfig(dAtA, i, uint64(n1))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x4a
 	}
-	if m.HeartbeatInterval != nil {
-		n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.HeartbeatInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval):])
+	if m.DisableAutoCompaction {
+		i--
+		if m.DisableAutoCompaction {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.SnapshotThresholdBytes != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.SnapshotThresholdBytes))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.CompactionOverhead != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.CompactionOverhead))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.MaxDiskUsage != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxDiskUsage))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.SnapshotThreshold != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.SnapshotThreshold))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SnapshotInterval != nil {
+		n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.SnapshotInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SnapshotInterval):])
 		if err2 != nil {
 			return 0, err2
 		}
 		i -= n2
 		i = encodeVarintConfig(dAtA, i, uint64(n2))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x1a
 	}
-	if m.ElectionTimeout != nil {
-		n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ElectionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout):])
+	if m.HeartbeatInterval != nil {
+		n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.HeartbeatInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval):])
 		if err3 != nil {
 			return 0, err3
 		}
 		i -= n3
 		i = encodeVarintConfig(dAtA, i, uint64(n3))
 		i--
+		dAtA[i] = 0x12
+	}
+	if m.ElectionTimeout != nil {
+		n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ElectionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout):])
+		if err4 != nil {
+			return 0, err4
+		}
+		i -= n4
+		i = encodeVarintConfig(dAtA, i, uint64(n4))
+		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
-	offset -= sovConfig(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *MembershipConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MembershipConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MembershipConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Partitions) > 0 {
+		for iNdEx := len(m.Partitions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Partitions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PartitionMembership) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PartitionMembership) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PartitionMembership) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.PartitionID != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.PartitionID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Learner {
+		i--
+		if m.Learner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Join {
+		i--
+		if m.Join {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.NodeID != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.NodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ReplicaID) > 0 {
+		i -= len(m.ReplicaID)
+		copy(dAtA[i:], m.ReplicaID)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.ReplicaID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
+	offset -= sovConfig(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
 	}
 	dAtA[offset] = uint8(v)
 	return base
@@ -255,6 +803,56 @@ func NewPopulatedProtocolConfig(r randyConfig, easy bool) *ProtocolConfig {
 		this.SnapshotInterval = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
 	}
 	this.SnapshotThreshold = uint64(uint64(r.Uint32()))
+	this.MaxDiskUsage = uint32(r.Uint32())
+	this.CompactionOverhead = uint64(uint64(r.Uint32()))
+	this.SnapshotThresholdBytes = uint64(uint64(r.Uint32()))
+	this.DisableAutoCompaction = bool(bool(r.Intn(2) == 0))
+	if r.Intn(5) != 0 {
+		this.CompactionInterval = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.EncryptionKeyId = string(randStringConfig(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedMembershipConfig(r randyConfig, easy bool) *MembershipConfig {
+	this := &MembershipConfig{}
+	if r.Intn(5) != 0 {
+		v1 := r.Intn(5)
+		this.Partitions = make([]PartitionMembership, v1)
+		for i := 0; i < v1; i++ {
+			v2 := NewPopulatedPartitionMembership(r, easy)
+			this.Partitions[i] = *v2
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPartitionMembership(r randyConfig, easy bool) *PartitionMembership {
+	this := &PartitionMembership{}
+	this.PartitionID = uint32(r.Uint32())
+	if r.Intn(5) != 0 {
+		v3 := r.Intn(5)
+		this.Members = make([]MemberConfig, v3)
+		for i := 0; i < v3; i++ {
+			v4 := NewPopulatedMemberConfig(r, easy)
+			this.Members[i] = *v4
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedMemberConfig(r randyConfig, easy bool) *MemberConfig {
+	this := &MemberConfig{}
+	this.ReplicaID = string(randStringConfig(r))
+	this.NodeID = uint64(uint64(r.Uint32()))
+	this.Join = bool(bool(r.Intn(2) == 0))
+	this.Learner = bool(bool(r.Intn(2) == 0))
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -279,9 +877,9 @@ func randUTF8RuneConfig(r randyConfig) rune {
 	return rune(ru + 61)
 }
 func randStringConfig(r randyConfig) string {
-	v1 := r.Intn(100)
-	tmps := make([]rune, v1)
-	for i := 0; i < v1; i++ {
+	v5 := r.Intn(100)
+	tmps := make([]rune, v5)
+	for i := 0; i < v5; i++ {
 		tmps[i] = randUTF8RuneConfig(r)
 	}
 	return string(tmps)
@@ -303,11 +901,11 @@ func randFieldConfig(dAtA []byte, r randyConfig, fieldNumber int, wire int) []by
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
-		v2 := r.Int63()
+		v6 := r.Int63()
 		if r.Intn(2) == 0 {
-			v2 *= -1
+			v6 *= -1
 		}
-		dAtA = encodeVarintPopulateConfig(dAtA, uint64(v2))
+		dAtA = encodeVarintPopulateConfig(dAtA, uint64(v6))
 	case 1:
 		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -318,51 +916,451 @@ func randFieldConfig(dAtA []byte, r randyConfig, fieldNumber int, wire int) []by
 		for j := 0; j < ll; j++ {
 			dAtA = append(dAtA, byte(r.Intn(256)))
 		}
-	default:
-		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	}
-	return dAtA
-}
-func encodeVarintPopulateConfig(dAtA []byte, v uint64) []byte {
-	for v >= 1<<7 {
-		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
-		v >>= 7
-	}
-	dAtA = append(dAtA, uint8(v))
-	return dAtA
-}
-func (m *ProtocolConfig) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ElectionTimeout != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout)
-		n += 1 + l + sovConfig(uint64(l))
-	}
-	if m.HeartbeatInterval != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval)
-		n += 1 + l + sovConfig(uint64(l))
-	}
-	if m.SnapshotInterval != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SnapshotInterval)
-		n += 1 + l + sovConfig(uint64(l))
-	}
-	if m.SnapshotThreshold != 0 {
-		n += 1 + sovConfig(uint64(m.SnapshotThreshold))
+	default:
+		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateConfig(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *ProtocolConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ElectionTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.HeartbeatInterval != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.SnapshotInterval != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SnapshotInterval)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.SnapshotThreshold != 0 {
+		n += 1 + sovConfig(uint64(m.SnapshotThreshold))
+	}
+	if m.MaxDiskUsage != 0 {
+		n += 1 + sovConfig(uint64(m.MaxDiskUsage))
+	}
+	if m.CompactionOverhead != 0 {
+		n += 1 + sovConfig(uint64(m.CompactionOverhead))
+	}
+	if m.SnapshotThresholdBytes != 0 {
+		n += 1 + sovConfig(uint64(m.SnapshotThresholdBytes))
+	}
+	if m.DisableAutoCompaction {
+		n += 2
+	}
+	if m.CompactionInterval != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.CompactionInterval)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.EncryptionKeyId)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
+func (m *MembershipConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Partitions) > 0 {
+		for _, e := range m.Partitions {
+			l = e.Size()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PartitionMembership) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PartitionID != 0 {
+		n += 1 + sovConfig(uint64(m.PartitionID))
+	}
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MemberConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ReplicaID)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.NodeID != 0 {
+		n += 1 + sovConfig(uint64(m.NodeID))
+	}
+	if m.Join {
+		n += 2
+	}
+	if m.Learner {
+		n += 2
+	}
+	return n
+}
+
+func sovConfig(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozConfig(x uint64) (n int) {
+	return sovConfig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProtocolConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProtocolConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ElectionTimeout == nil {
+				m.ElectionTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.ElectionTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HeartbeatInterval == nil {
+				m.HeartbeatInterval = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.HeartbeatInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SnapshotInterval == nil {
+				m.SnapshotInterval = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.SnapshotInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotThreshold", wireType)
+			}
+			m.SnapshotThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotThreshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDiskUsage", wireType)
+			}
+			m.MaxDiskUsage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxDiskUsage |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactionOverhead", wireType)
+			}
+			m.CompactionOverhead = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompactionOverhead |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotThresholdBytes", wireType)
+			}
+			m.SnapshotThresholdBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotThresholdBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableAutoCompaction", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DisableAutoCompaction = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactionInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CompactionInterval == nil {
+				m.CompactionInterval = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.CompactionInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKeyId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKeyId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovConfig(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozConfig(x uint64) (n int) {
-	return sovConfig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
+func (m *MembershipConfig) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -385,15 +1383,15 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProtocolConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: MembershipConfig: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProtocolConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MembershipConfig: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ElectionTimeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Partitions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -420,16 +1418,86 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ElectionTimeout == nil {
-				m.ElectionTimeout = new(time.Duration)
-			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.ElectionTimeout, dAtA[iNdEx:postIndex]); err != nil {
+			m.Partitions = append(m.Partitions, PartitionMembership{})
+			if err := m.Partitions[len(m.Partitions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PartitionMembership) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PartitionMembership: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PartitionMembership: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionID", wireType)
+			}
+			m.PartitionID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PartitionID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatInterval", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -456,18 +1524,69 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.HeartbeatInterval == nil {
-				m.HeartbeatInterval = new(time.Duration)
-			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.HeartbeatInterval, dAtA[iNdEx:postIndex]); err != nil {
+			m.Members = append(m.Members, MemberConfig{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MemberConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotInterval", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfig
@@ -477,33 +1596,68 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthConfig
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthConfig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SnapshotInterval == nil {
-				m.SnapshotInterval = new(time.Duration)
+			m.ReplicaID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
 			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.SnapshotInterval, dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.NodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Join", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Join = bool(v != 0)
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotThreshold", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Learner", wireType)
 			}
-			m.SnapshotThreshold = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfig
@@ -513,11 +1667,12 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SnapshotThreshold |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Learner = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -24,13 +24,48 @@ func TestAdditionalConfigFunctions(t *testing.T) {
 	config := &ProtocolConfig{}
 	assert.Equal(t, defaultElectionTimeout, config.GetElectionTimeoutOrDefault())
 	assert.Equal(t, defaultHeartbeatInterval, config.GetHeartbeatIntervalOrDefault())
+	assert.Equal(t, 2*time.Second, config.GetElectionTimeoutOrDefault())
+	assert.Equal(t, 200*time.Millisecond, config.GetHeartbeatIntervalOrDefault())
+	assert.Equal(t, time.Duration(0), config.GetSnapshotIntervalOrDefault())
 
 	electionTimeout := 30 * time.Second
 	heartbeatInterval := 1 * time.Second
+	snapshotInterval := 1 * time.Minute
 	config = &ProtocolConfig{
 		ElectionTimeout:   &electionTimeout,
 		HeartbeatInterval: &heartbeatInterval,
+		SnapshotInterval:  &snapshotInterval,
 	}
 	assert.Equal(t, electionTimeout, config.GetElectionTimeoutOrDefault())
 	assert.Equal(t, heartbeatInterval, config.GetHeartbeatIntervalOrDefault())
+	assert.Equal(t, snapshotInterval, config.GetSnapshotIntervalOrDefault())
+
+	zero := time.Duration(0)
+	config = &ProtocolConfig{
+		ElectionTimeout:   &zero,
+		HeartbeatInterval: &zero,
+		SnapshotInterval:  &zero,
+	}
+	assert.Equal(t, defaultElectionTimeout, config.GetElectionTimeoutOrDefault())
+	assert.Equal(t, defaultHeartbeatInterval, config.GetHeartbeatIntervalOrDefault())
+	assert.Equal(t, time.Duration(0), config.GetSnapshotIntervalOrDefault())
+}
+
+func TestCompactionConfigFunctions(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.Equal(t, uint64(defaultSnapshotThreshold/10), config.GetCompactionOverheadOrDefault())
+	assert.Equal(t, time.Duration(0), config.GetCompactionIntervalOrDefault())
+
+	compactionInterval := 5 * time.Minute
+	config = &ProtocolConfig{
+		SnapshotThreshold:  5000,
+		CompactionInterval: &compactionInterval,
+	}
+	assert.Equal(t, uint64(500), config.GetCompactionOverheadOrDefault())
+	assert.Equal(t, time.Duration(0), config.GetCompactionIntervalOrDefault())
+
+	config.CompactionOverhead = 2000
+	config.DisableAutoCompaction = true
+	assert.Equal(t, uint64(2000), config.GetCompactionOverheadOrDefault())
+	assert.Equal(t, compactionInterval, config.GetCompactionIntervalOrDefault())
 }
@@ -0,0 +1,147 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/atomix/atomix-go-framework/pkg/atomix/errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// diskCheckInterval is the minimum interval at which the usage of the data volume is checked before writes
+const diskCheckInterval = time.Second
+
+// snapshotPartDirPrefix is the prefix of the directories in which dragonboat stores the snapshots of a node
+const snapshotPartDirPrefix = "snapshot-part-"
+
+// diskUsage is the usage of the data volume
+type diskUsage struct {
+	capacity  uint64
+	available uint64
+}
+
+// usedPercent returns the percentage of the volume's capacity in use
+func (u diskUsage) usedPercent() uint32 {
+	if u.capacity == 0 || u.available >= u.capacity {
+		return 0
+	}
+	return uint32((u.capacity - u.available) * 100 / u.capacity)
+}
+
+// diskMonitor caches the usage of the data volume so writes can be checked against the usage limit
+// without querying the file system for every write
+type diskMonitor struct {
+	limit     uint32
+	usage     diskUsage
+	checkTime time.Time
+	mu        sync.Mutex
+}
+
+// checkWrite returns an error if the usage of the data volume exceeds the configured limit
+func (m *diskMonitor) checkWrite() error {
+	if m.limit == 0 {
+		return nil
+	}
+	usage, err := m.getUsage()
+	if err != nil {
+		log.Warnf("Failed to read data volume usage: %s", err)
+		return nil
+	}
+	if used := usage.usedPercent(); used >= m.limit {
+		return errors.NewUnavailable("write rejected: data volume is %d%% full, exceeding the limit of %d%%", used, m.limit)
+	}
+	return nil
+}
+
+// isRejectingWrites returns whether writes are rejected because the usage of the data volume exceeds the limit
+func (m *diskMonitor) isRejectingWrites() bool {
+	return m.checkWrite() != nil
+}
+
+// getUsage returns the usage of the data volume, checking the file system at most once per check interval
+func (m *diskMonitor) getUsage() (diskUsage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Since(m.checkTime) < diskCheckInterval {
+		return m.usage, nil
+	}
+	usage, err := getVolumeUsage(dataDir)
+	if err != nil {
+		return diskUsage{}, err
+	}
+	m.usage = usage
+	m.checkTime = time.Now()
+	return usage, nil
+}
+
+// getVolumeUsage returns the usage of the volume containing the given directory
+func getVolumeUsage(dir string) (diskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return diskUsage{}, err
+	}
+	return diskUsage{
+		capacity:  uint64(stat.Blocks) * uint64(stat.Bsize),
+		available: uint64(stat.Bavail) * uint64(stat.Bsize),
+	}, nil
+}
+
+// getDataDirSizes returns the size in bytes of the write-ahead log and of the snapshots stored in the data directory.
+// Snapshots are stored under snapshot-part-* directories, and all other files belong to the write-ahead log.
+func getDataDirSizes(dir string) (uint64, uint64, error) {
+	var walSize, snapshotSize uint64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Files may be removed by compaction while the directory is walked
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if strings.Contains(path, string(filepath.Separator)+snapshotPartDirPrefix) {
+			snapshotSize += uint64(info.Size())
+		} else {
+			walSize += uint64(info.Size())
+		}
+		return nil
+	})
+	return walSize, snapshotSize, err
+}
+
+// getDiskUsageEvent returns the usage of the node's data volume
+func (p *Protocol) getDiskUsageEvent() (*DiskUsageEvent, error) {
+	walSize, snapshotSize, err := getDataDirSizes(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := getVolumeUsage(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	return &DiskUsageEvent{
+		WalSize:        walSize,
+		SnapshotSize:   snapshotSize,
+		Capacity:       usage.capacity,
+		Available:      usage.available,
+		WritesRejected: p.disk.isRejectingWrites(),
+	}, nil
+}
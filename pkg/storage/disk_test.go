@@ -0,0 +1,94 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/atomix/atomix-go-framework/pkg/atomix/errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetDataDirSizes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "atomix-raft-data")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Files under snapshot-part-* directories are snapshots and all other files belong to the write-ahead log
+	snapshotDir := filepath.Join(dir, "00000001", "snapshot-part-1", "snapshot-0000000000000010")
+	assert.NoError(t, os.MkdirAll(snapshotDir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, "snapshot.gbsnap"), make([]byte, 300), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "00000001", "logdb.wal"), make([]byte, 100), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "LOCK"), make([]byte, 20), 0644))
+
+	walSize, snapshotSize, err := getDataDirSizes(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(120), walSize)
+	assert.Equal(t, uint64(300), snapshotSize)
+}
+
+func TestCheckWrite(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     uint32
+		available uint64
+		rejected  bool
+	}{
+		{
+			name:      "no limit",
+			available: 1,
+		},
+		{
+			name:      "below limit",
+			limit:     95,
+			available: 10,
+		},
+		{
+			name:      "at limit",
+			limit:     95,
+			available: 5,
+			rejected:  true,
+		},
+		{
+			name:      "above limit",
+			limit:     90,
+			available: 1,
+			rejected:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The usage is cached so the file system isn't checked before the check interval elapses
+			monitor := &diskMonitor{
+				limit: test.limit,
+				usage: diskUsage{
+					capacity:  100,
+					available: test.available,
+				},
+				checkTime: time.Now(),
+			}
+			err := monitor.checkWrite()
+			assert.Equal(t, test.rejected, err != nil)
+			assert.Equal(t, test.rejected, monitor.isRejectingWrites())
+			if test.rejected {
+				assert.True(t, errors.IsUnavailable(err))
+			}
+		})
+	}
+}
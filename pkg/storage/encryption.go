@@ -0,0 +1,355 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// encryptionKeyDir is the directory in which encryption keys are mounted, with one file per key named by the key ID
+const encryptionKeyDir = "/etc/atomix-raft/keys"
+
+// snapshotChunkSize is the maximum size of the plaintext sealed in each frame of an encrypted snapshot
+const snapshotChunkSize = 64 * 1024
+
+// keyMountTimeout is the time to wait for a key used by another member to be mounted. The kubelet updates
+// Secret volumes periodically, so a key added to the Secret may be mounted in other pods before this one.
+const keyMountTimeout = 2 * time.Minute
+
+// keyMountRetryInterval is the interval at which a key that has not been mounted is read
+const keyMountRetryInterval = time.Second
+
+// lastFrameFlag is set in the length prefix of the last frame of an encrypted snapshot
+const lastFrameFlag = 1 << 31
+
+// encryptedSnapshotMagic is the header identifying an encrypted snapshot.
+// Snapshots without the header are read unencrypted.
+var encryptedSnapshotMagic = []byte("ATXENC01")
+
+// newKeyring returns a new keyring reading keys from the given directory
+func newKeyring(dir string, activeKeyID string) *keyring {
+	return &keyring{
+		dir:             dir,
+		activeKeyID:     activeKeyID,
+		keys:            make(map[string]cipher.AEAD),
+		mountTimeout:    keyMountTimeout,
+		mountRetryDelay: keyMountRetryInterval,
+	}
+}
+
+// keyring holds the keys with which log entries and snapshots are encrypted.
+// Keys are loaded when first used, so keys added to the mounted Secret after the node is started
+// can be used to decrypt entries written by members that have already rotated to them.
+type keyring struct {
+	dir             string
+	activeKeyID     string
+	keys            map[string]cipher.AEAD
+	mountTimeout    time.Duration
+	mountRetryDelay time.Duration
+	mu              sync.RWMutex
+}
+
+// isEnabled returns whether new entries and snapshots are encrypted
+func (k *keyring) isEnabled() bool {
+	return k.activeKeyID != ""
+}
+
+// check verifies the active key can be loaded
+func (k *keyring) check() error {
+	if !k.isEnabled() {
+		return nil
+	}
+	_, err := k.getKey(k.activeKeyID)
+	return err
+}
+
+// getKey returns the cipher for the given key ID, loading the key from the key directory if necessary
+func (k *keyring) getKey(keyID string) (cipher.AEAD, error) {
+	k.mu.RLock()
+	key, ok := k.keys[keyID]
+	k.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	// Secret volumes contain hidden files and directories used to update keys atomically
+	if keyID == "" || strings.HasPrefix(keyID, ".") || strings.ContainsRune(keyID, filepath.Separator) {
+		return nil, fmt.Errorf("invalid encryption key ID '%s'", keyID)
+	}
+	bytes, err := ioutil.ReadFile(filepath.Join(k.dir, keyID))
+	if os.IsNotExist(err) {
+		return nil, &keyNotFoundError{keyID: keyID}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read encryption key '%s': %s", keyID, err)
+	}
+	block, err := aes.NewCipher(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key '%s': %s", keyID, err)
+	}
+	key, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	k.keys[keyID] = key
+	k.mu.Unlock()
+	return key, nil
+}
+
+// waitForKey returns the cipher for the given key, waiting for the key to be mounted if it's not found
+func (k *keyring) waitForKey(keyID string) (cipher.AEAD, error) {
+	deadline := time.Now().Add(k.mountTimeout)
+	for {
+		key, err := k.getKey(keyID)
+		if _, ok := err.(*keyNotFoundError); !ok || time.Now().After(deadline) {
+			return key, err
+		}
+		log.Warnf("Waiting for encryption key '%s' to be mounted", keyID)
+		time.Sleep(k.mountRetryDelay)
+	}
+}
+
+// keyNotFoundError is returned when a key has not been mounted in the key directory
+type keyNotFoundError struct {
+	keyID string
+}
+
+func (e *keyNotFoundError) Error() string {
+	return fmt.Sprintf("encryption key '%s' not found", e.keyID)
+}
+
+// encryptEntry encrypts the value of the given entry with the active key, storing the key ID with the entry.
+// The random nonce is prepended to the ciphertext and the key ID is authenticated with the value.
+func (k *keyring) encryptEntry(entry *Entry) error {
+	if !k.isEnabled() {
+		return nil
+	}
+	key, err := k.getKey(k.activeKeyID)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, key.NonceSize(), key.NonceSize()+len(entry.Value)+key.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	entry.Value = key.Seal(nonce, nonce, entry.Value, []byte(k.activeKeyID))
+	entry.KeyID = k.activeKeyID
+	return nil
+}
+
+// decryptEntry decrypts the value of the given entry with the key identified by the entry.
+// Entries written without a key ID are left unchanged. Entries written by members that have
+// rotated to a key not yet mounted on this node wait for the key to be mounted.
+func (k *keyring) decryptEntry(entry *Entry) error {
+	if entry.KeyID == "" {
+		return nil
+	}
+	key, err := k.waitForKey(entry.KeyID)
+	if err != nil {
+		return err
+	}
+	if len(entry.Value) < key.NonceSize() {
+		return fmt.Errorf("encrypted entry is too short")
+	}
+	nonce, ciphertext := entry.Value[:key.NonceSize()], entry.Value[key.NonceSize():]
+	value, err := key.Open(nil, nonce, ciphertext, []byte(entry.KeyID))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt entry with key '%s': %s", entry.KeyID, err)
+	}
+	entry.Value = value
+	entry.KeyID = ""
+	return nil
+}
+
+// newSnapshotWriter returns a writer encrypting a snapshot with the active key.
+// The writer must be closed to write the final frame of the snapshot.
+func (k *keyring) newSnapshotWriter(writer io.Writer) (io.WriteCloser, error) {
+	key, err := k.getKey(k.activeKeyID)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 0, len(encryptedSnapshotMagic)+2+len(k.activeKeyID))
+	header = append(header, encryptedSnapshotMagic...)
+	header = append(header, byte(len(k.activeKeyID)>>8), byte(len(k.activeKeyID)))
+	header = append(header, k.activeKeyID...)
+	if _, err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return &snapshotWriter{
+		writer: writer,
+		keyID:  k.activeKeyID,
+		key:    key,
+		buf:    make([]byte, 0, snapshotChunkSize),
+	}, nil
+}
+
+// newSnapshotReader returns a reader decrypting a snapshot with the key identified by its header.
+// Snapshots written without encryption are returned unchanged.
+func (k *keyring) newSnapshotReader(reader io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(reader)
+	magic, _ := buffered.Peek(len(encryptedSnapshotMagic))
+	if !bytes.Equal(magic, encryptedSnapshotMagic) {
+		return buffered, nil
+	}
+	if _, err := buffered.Discard(len(encryptedSnapshotMagic)); err != nil {
+		return nil, err
+	}
+
+	length := make([]byte, 2)
+	if _, err := io.ReadFull(buffered, length); err != nil {
+		return nil, err
+	}
+	keyID := make([]byte, binary.BigEndian.Uint16(length))
+	if _, err := io.ReadFull(buffered, keyID); err != nil {
+		return nil, err
+	}
+	key, err := k.waitForKey(string(keyID))
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotReader{
+		reader: buffered,
+		keyID:  string(keyID),
+		key:    key,
+	}, nil
+}
+
+// getFrameData returns the additional data authenticated with a snapshot frame. Frames are bound to their
+// position in the snapshot and the last frame is marked so reordered or truncated snapshots are detected.
+func getFrameData(keyID string, index uint64, last bool) []byte {
+	data := make([]byte, len(keyID)+9)
+	copy(data, keyID)
+	binary.BigEndian.PutUint64(data[len(keyID):], index)
+	if last {
+		data[len(data)-1] = 1
+	}
+	return data
+}
+
+// snapshotWriter encrypts a snapshot as a sequence of length-prefixed frames
+type snapshotWriter struct {
+	writer io.Writer
+	keyID  string
+	key    cipher.AEAD
+	buf    []byte
+	index  uint64
+}
+
+func (w *snapshotWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if len(w.buf) == snapshotChunkSize {
+			if err := w.writeFrame(false); err != nil {
+				return 0, err
+			}
+		}
+		i := copy(w.buf[len(w.buf):snapshotChunkSize], p)
+		w.buf = w.buf[:len(w.buf)+i]
+		p = p[i:]
+	}
+	return n, nil
+}
+
+// Close writes the last frame of the snapshot
+func (w *snapshotWriter) Close() error {
+	return w.writeFrame(true)
+}
+
+// writeFrame seals the buffered plaintext and writes it as a frame
+func (w *snapshotWriter) writeFrame(last bool) error {
+	frame := make([]byte, 4+w.key.NonceSize(), 4+w.key.NonceSize()+len(w.buf)+w.key.Overhead())
+	nonce := frame[4:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	frame = w.key.Seal(frame, nonce, w.buf, getFrameData(w.keyID, w.index, last))
+	length := uint32(len(frame) - 4)
+	if last {
+		length |= lastFrameFlag
+	}
+	binary.BigEndian.PutUint32(frame[:4], length)
+	if _, err := w.writer.Write(frame); err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+	w.index++
+	return nil
+}
+
+// snapshotReader decrypts a snapshot written by a snapshotWriter
+type snapshotReader struct {
+	reader io.Reader
+	keyID  string
+	key    cipher.AEAD
+	buf    []byte
+	index  uint64
+	done   bool
+}
+
+func (r *snapshotReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readFrame(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// readFrame reads and opens the next frame of the snapshot
+func (r *snapshotReader) readFrame() error {
+	length := make([]byte, 4)
+	if _, err := io.ReadFull(r.reader, length); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	last := binary.BigEndian.Uint32(length)&lastFrameFlag != 0
+	frame := make([]byte, binary.BigEndian.Uint32(length)&^lastFrameFlag)
+	if _, err := io.ReadFull(r.reader, frame); err != nil {
+		return err
+	}
+	if len(frame) < r.key.NonceSize() {
+		return fmt.Errorf("encrypted snapshot frame is too short")
+	}
+	nonce, ciphertext := frame[:r.key.NonceSize()], frame[r.key.NonceSize():]
+	plaintext, err := r.key.Open(nil, nonce, ciphertext, getFrameData(r.keyID, r.index, last))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt snapshot with key '%s': %s", r.keyID, err)
+	}
+	r.buf = plaintext
+	r.index++
+	r.done = last
+	return nil
+}
@@ -0,0 +1,329 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestDir returns a temporary directory for test keyrings
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "atomix-raft-keys")
+	assert.NoError(t, err)
+	return dir
+}
+
+// newTestKeyring returns a keyring reading the given keys from a new directory under root
+func newTestKeyring(t *testing.T, root string, activeKeyID string, keys map[string][]byte) *keyring {
+	dir, err := ioutil.TempDir(root, "keys")
+	assert.NoError(t, err)
+	for keyID, key := range keys {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, keyID), key, 0600))
+	}
+	k := newKeyring(dir, activeKeyID)
+	k.mountTimeout = 0
+	return k
+}
+
+// newTestKey returns a random AES key of the given size
+func newTestKey(t *testing.T, size int) []byte {
+	key := make([]byte, size)
+	_, err := io.ReadFull(rand.Reader, key)
+	assert.NoError(t, err)
+	return key
+}
+
+func TestEncryptEntry(t *testing.T) {
+	root := newTestDir(t)
+	defer os.RemoveAll(root)
+
+	key1 := newTestKey(t, 32)
+	key2 := newTestKey(t, 16)
+
+	tests := []struct {
+		name      string
+		writer    *keyring
+		reader    *keyring
+		tamper    bool
+		encrypted bool
+		err       bool
+	}{
+		{
+			name:      "round trip",
+			writer:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			encrypted: true,
+		},
+		{
+			name:   "encryption disabled",
+			writer: newTestKeyring(t, root, "", nil),
+			reader: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+		},
+		{
+			name:      "rotated key",
+			writer:    newTestKeyring(t, root, "key-2", map[string][]byte{"key-1": key1, "key-2": key2}),
+			reader:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1, "key-2": key2}),
+			encrypted: true,
+		},
+		{
+			name:      "decrypt only",
+			writer:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader:    newTestKeyring(t, root, "", map[string][]byte{"key-1": key1}),
+			encrypted: true,
+		},
+		{
+			name:      "wrong key",
+			writer:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": newTestKey(t, 32)}),
+			encrypted: true,
+			err:       true,
+		},
+		{
+			name:      "unknown key ID",
+			writer:    newTestKeyring(t, root, "key-2", map[string][]byte{"key-2": key2}),
+			reader:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			encrypted: true,
+			err:       true,
+		},
+		{
+			name:      "tampered value",
+			writer:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader:    newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			tamper:    true,
+			encrypted: true,
+			err:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value := []byte("Hello world!")
+			entry := &Entry{Value: append([]byte{}, value...)}
+			assert.NoError(t, test.writer.encryptEntry(entry))
+			assert.Equal(t, test.encrypted, entry.KeyID != "")
+			if test.encrypted {
+				assert.Equal(t, test.writer.activeKeyID, entry.KeyID)
+				assert.NotContains(t, string(entry.Value), string(value))
+			}
+			if test.tamper {
+				entry.Value[len(entry.Value)-1] ^= 1
+			}
+
+			err := test.reader.decryptEntry(entry)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, value, entry.Value)
+			assert.Equal(t, "", entry.KeyID)
+		})
+	}
+}
+
+func TestEncryptedEntriesUseUniqueNonces(t *testing.T) {
+	root := newTestDir(t)
+	defer os.RemoveAll(root)
+
+	keys := newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": newTestKey(t, 32)})
+	entry1 := &Entry{Value: []byte("foo")}
+	entry2 := &Entry{Value: []byte("foo")}
+	assert.NoError(t, keys.encryptEntry(entry1))
+	assert.NoError(t, keys.encryptEntry(entry2))
+	assert.NotEqual(t, entry1.Value, entry2.Value)
+}
+
+func TestInvalidKeys(t *testing.T) {
+	root := newTestDir(t)
+	defer os.RemoveAll(root)
+
+	keys := newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": []byte("too short")})
+	assert.Error(t, keys.check())
+
+	keys = newTestKeyring(t, root, "..data", nil)
+	assert.Error(t, keys.check())
+
+	keys = newTestKeyring(t, root, "missing", nil)
+	err := keys.check()
+	assert.Error(t, err)
+	assert.IsType(t, &keyNotFoundError{}, err)
+
+	assert.NoError(t, newTestKeyring(t, root, "", nil).check())
+}
+
+func TestWaitForKey(t *testing.T) {
+	root := newTestDir(t)
+	defer os.RemoveAll(root)
+
+	keys := newTestKeyring(t, root, "", nil)
+	keys.mountTimeout = 10 * time.Second
+	keys.mountRetryDelay = 10 * time.Millisecond
+
+	key := newTestKey(t, 32)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(keys.dir, "key-1"), key, 0600))
+	}()
+
+	writer := newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key})
+	entry := &Entry{Value: []byte("Hello world!")}
+	assert.NoError(t, writer.encryptEntry(entry))
+	assert.NoError(t, keys.decryptEntry(entry))
+	assert.Equal(t, []byte("Hello world!"), entry.Value)
+}
+
+func TestEncryptSnapshot(t *testing.T) {
+	root := newTestDir(t)
+	defer os.RemoveAll(root)
+
+	key1 := newTestKey(t, 32)
+	key2 := newTestKey(t, 24)
+
+	tests := []struct {
+		name     string
+		size     int
+		writer   *keyring
+		reader   *keyring
+		truncate int
+		err      bool
+	}{
+		{
+			name:   "empty snapshot",
+			writer: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+		},
+		{
+			name:   "single frame",
+			size:   1024,
+			writer: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+		},
+		{
+			name:   "full frame",
+			size:   snapshotChunkSize,
+			writer: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+		},
+		{
+			name:   "multiple frames",
+			size:   3*snapshotChunkSize + 17,
+			writer: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+		},
+		{
+			name:   "rotated key",
+			size:   snapshotChunkSize + 1,
+			writer: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader: newTestKeyring(t, root, "key-2", map[string][]byte{"key-1": key1, "key-2": key2}),
+		},
+		{
+			name:     "truncated last frame",
+			size:     3*snapshotChunkSize + 17,
+			writer:   newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader:   newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			truncate: 1,
+			err:      true,
+		},
+		{
+			name:     "missing last frame",
+			size:     2 * snapshotChunkSize,
+			writer:   newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader:   newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			truncate: 4 + 12 + 16,
+			err:      true,
+		},
+		{
+			name:   "wrong key",
+			size:   1024,
+			writer: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			reader: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": newTestKey(t, 32)}),
+			err:    true,
+		},
+		{
+			name:   "unknown key ID",
+			size:   1024,
+			writer: newTestKeyring(t, root, "key-2", map[string][]byte{"key-2": key2}),
+			reader: newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": key1}),
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := make([]byte, test.size)
+			_, err := io.ReadFull(rand.Reader, snapshot)
+			assert.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			writer, err := test.writer.newSnapshotWriter(buf)
+			assert.NoError(t, err)
+			// Write in uneven pieces to exercise frame buffering
+			for i := 0; i < len(snapshot); i += 1000 {
+				end := i + 1000
+				if end > len(snapshot) {
+					end = len(snapshot)
+				}
+				_, err := writer.Write(snapshot[i:end])
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, writer.Close())
+
+			encrypted := buf.Bytes()
+			assert.True(t, bytes.HasPrefix(encrypted, encryptedSnapshotMagic))
+			if test.size > 0 {
+				assert.False(t, bytes.Contains(encrypted, snapshot[:test.size/2]))
+			}
+			encrypted = encrypted[:len(encrypted)-test.truncate]
+
+			reader, err := test.reader.newSnapshotReader(bytes.NewReader(encrypted))
+			if err == nil {
+				var decrypted []byte
+				decrypted, err = ioutil.ReadAll(reader)
+				if err == nil {
+					assert.Equal(t, len(snapshot), len(decrypted))
+					assert.True(t, bytes.Equal(snapshot, decrypted))
+				}
+			}
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReadUnencryptedSnapshot(t *testing.T) {
+	root := newTestDir(t)
+	defer os.RemoveAll(root)
+
+	keys := newTestKeyring(t, root, "key-1", map[string][]byte{"key-1": newTestKey(t, 32)})
+	for _, snapshot := range [][]byte{{}, []byte("ATX"), []byte("unencrypted snapshot")} {
+		reader, err := keys.newSnapshotReader(bytes.NewReader(snapshot))
+		assert.NoError(t, err)
+		decrypted, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, len(snapshot), len(decrypted))
+		assert.True(t, bytes.Equal(snapshot, decrypted))
+	}
+}
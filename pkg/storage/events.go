@@ -62,6 +62,7 @@ func (e *raftEventListener) publish(event RaftEvent) {
 }
 
 func (e *raftEventListener) LeaderUpdated(info raftio.LeaderInfo) {
+	e.protocol.getStats(info.ClusterID).setTerm(info.Term)
 	e.publish(RaftEvent{
 		Timestamp: time.Now(),
 		Event: &RaftEvent_LeaderUpdated{
@@ -71,7 +72,7 @@ func (e *raftEventListener) LeaderUpdated(info raftio.LeaderInfo) {
 						Partition: info.ClusterID,
 					},
 					Term:   info.Term,
-					Leader: e.protocol.getMemberID(info.LeaderID),
+					Leader: e.protocol.getMemberID(info.ClusterID, info.LeaderID),
 				},
 			},
 		},
@@ -100,6 +101,7 @@ func (e *raftEventListener) NodeReady(info raftio.NodeInfo) {
 }
 
 func (e *raftEventListener) MembershipChanged(info raftio.NodeInfo) {
+	go e.protocol.updateMemberIDs(info.ClusterID)
 	e.publish(RaftEvent{
 		Timestamp: time.Now(),
 		Event: &RaftEvent_MembershipChanged{
@@ -151,7 +153,7 @@ func (e *raftEventListener) SendSnapshotStarted(info raftio.SnapshotInfo) {
 					},
 					Index: info.Index,
 				},
-				To: e.protocol.getMemberID(info.NodeID),
+				To: e.protocol.getMemberID(info.ClusterID, info.NodeID),
 			},
 		},
 	})
@@ -168,7 +170,7 @@ func (e *raftEventListener) SendSnapshotCompleted(info raftio.SnapshotInfo) {
 					},
 					Index: info.Index,
 				},
-				To: e.protocol.getMemberID(info.NodeID),
+				To: e.protocol.getMemberID(info.ClusterID, info.NodeID),
 			},
 		},
 	})
@@ -185,7 +187,7 @@ func (e *raftEventListener) SendSnapshotAborted(info raftio.SnapshotInfo) {
 					},
 					Index: info.Index,
 				},
-				To: e.protocol.getMemberID(info.NodeID),
+				To: e.protocol.getMemberID(info.ClusterID, info.NodeID),
 			},
 		},
 	})
@@ -202,13 +204,14 @@ func (e *raftEventListener) SnapshotReceived(info raftio.SnapshotInfo) {
 					},
 					Index: info.Index,
 				},
-				From: e.protocol.getMemberID(info.From),
+				From: e.protocol.getMemberID(info.ClusterID, info.From),
 			},
 		},
 	})
 }
 
 func (e *raftEventListener) SnapshotRecovered(info raftio.SnapshotInfo) {
+	e.protocol.getStats(info.ClusterID).setRecoveredIndex(info.Index)
 	e.publish(RaftEvent{
 		Timestamp: time.Now(),
 		Event: &RaftEvent_SnapshotRecovered{
@@ -225,6 +228,7 @@ func (e *raftEventListener) SnapshotRecovered(info raftio.SnapshotInfo) {
 }
 
 func (e *raftEventListener) SnapshotCreated(info raftio.SnapshotInfo) {
+	e.protocol.getStats(info.ClusterID).snapshotCreated()
 	e.publish(RaftEvent{
 		Timestamp: time.Now(),
 		Event: &RaftEvent_SnapshotCreated{
@@ -251,12 +255,14 @@ func (e *raftEventListener) SnapshotCompacted(info raftio.SnapshotInfo) {
 					},
 					Index: info.Index,
 				},
+				RetainedSnapshots: retainedSnapshots,
 			},
 		},
 	})
 }
 
 func (e *raftEventListener) LogCompacted(info raftio.EntryInfo) {
+	requested := e.protocol.getStats(info.ClusterID).logCompacted(info.Index)
 	e.publish(RaftEvent{
 		Timestamp: time.Now(),
 		Event: &RaftEvent_LogCompacted{
@@ -267,6 +273,8 @@ func (e *raftEventListener) LogCompacted(info raftio.EntryInfo) {
 					},
 					Index: info.Index,
 				},
+				CompactionOverhead: e.protocol.config.GetCompactionOverheadOrDefault(),
+				Requested:          requested,
 			},
 		},
 	})
@@ -16,6 +16,7 @@ package storage
 
 import (
 	"github.com/atomix/atomix-go-framework/pkg/atomix/cluster"
+	"github.com/atomix/atomix-go-framework/pkg/atomix/errors"
 	protocol "github.com/atomix/atomix-go-framework/pkg/atomix/storage/protocol/rsm"
 	"github.com/atomix/atomix-go-framework/pkg/atomix/stream"
 	"github.com/gogo/protobuf/proto"
@@ -25,11 +26,13 @@ import (
 )
 
 // newStateMachine returns a new primitive state machine
-func newStateMachine(cluster cluster.Cluster, partitionID protocol.PartitionID, registry *protocol.Registry, streams *streamManager) *StateMachine {
+func newStateMachine(cluster cluster.Cluster, partitionID protocol.PartitionID, registry *protocol.Registry, streams *streamManager, stats *memberStats, keys *keyring) *StateMachine {
 	return &StateMachine{
 		partition: partitionID,
 		state:     protocol.NewManager(cluster, registry),
 		streams:   streams,
+		stats:     stats,
+		keys:      keys,
 	}
 }
 
@@ -38,6 +41,8 @@ type StateMachine struct {
 	partition protocol.PartitionID
 	state     *protocol.Manager
 	streams   *streamManager
+	stats     *memberStats
+	keys      *keyring
 	mu        sync.Mutex
 }
 
@@ -51,8 +56,18 @@ func (s *StateMachine) Update(bytes []byte) (statemachine.Result, error) {
 		return statemachine.Result{}, err
 	}
 
+	// Errors returned by the state machine are fatal in dragonboat, so a command that can't be decrypted is
+	// failed rather than crashing the node each time it replays the entry
 	stream := s.streams.getStream(tsEntry.StreamID)
+	if err := s.keys.decryptEntry(tsEntry); err != nil {
+		log.Errorf("Failed to apply command to partition %d: %s", s.partition, err)
+		stream.Error(errors.NewInternal("failed to decrypt command: %s", err))
+		stream.Close()
+		s.stats.entryApplied(len(bytes))
+		return statemachine.Result{}, nil
+	}
 	s.state.Command(tsEntry.Value, stream)
+	s.stats.entryApplied(len(bytes))
 	return statemachine.Result{}, nil
 }
 
@@ -67,12 +82,30 @@ func (s *StateMachine) Lookup(value interface{}) (interface{}, error) {
 
 // SaveSnapshot saves a snapshot of the state machine state
 func (s *StateMachine) SaveSnapshot(writer io.Writer, files statemachine.ISnapshotFileCollection, done <-chan struct{}) error {
-	return s.state.Snapshot(writer)
+	if !s.keys.isEnabled() {
+		return s.state.Snapshot(writer)
+	}
+	encrypter, err := s.keys.newSnapshotWriter(writer)
+	if err != nil {
+		return err
+	}
+	if err := s.state.Snapshot(encrypter); err != nil {
+		return err
+	}
+	return encrypter.Close()
 }
 
 // RecoverFromSnapshot recovers the state machine state from a snapshot
 func (s *StateMachine) RecoverFromSnapshot(reader io.Reader, files []statemachine.SnapshotFile, done <-chan struct{}) error {
-	return s.state.Install(reader)
+	decrypter, err := s.keys.newSnapshotReader(reader)
+	if err != nil {
+		return err
+	}
+	if err := s.state.Install(decrypter); err != nil {
+		return err
+	}
+	s.stats.snapshotRecovered()
+	return nil
 }
 
 // Close closes the state machine
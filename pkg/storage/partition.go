@@ -26,12 +26,12 @@ import (
 const clientTimeout = 30 * time.Second
 
 // newPartition returns a new Raft consensus partition client
-func newPartition(clusterID uint64, nodeID uint64, node *dragonboat.NodeHost, members map[uint64]string, streams *streamManager) *Partition {
+func newPartition(clusterID uint64, nodeID uint64, node *dragonboat.NodeHost, protocol *Protocol, streams *streamManager) *Partition {
 	return &Partition{
 		clusterID: clusterID,
 		nodeID:    nodeID,
 		node:      node,
-		members:   members,
+		protocol:  protocol,
 		streams:   streams,
 	}
 }
@@ -41,7 +41,7 @@ type Partition struct {
 	clusterID uint64
 	nodeID    uint64
 	node      *dragonboat.NodeHost
-	members   map[uint64]string
+	protocol  *Protocol
 	streams   *streamManager
 }
 
@@ -65,17 +65,24 @@ func (c *Partition) Leader() string {
 	if !ok || err != nil {
 		return ""
 	}
-	return c.members[leader]
+	return c.protocol.getMemberID(c.clusterID, leader)
 }
 
 // SyncCommand executes a state machine command on the partition
 func (c *Partition) SyncCommand(ctx context.Context, input []byte, stream streams.WriteStream) error {
+	// Writes are rejected before the data volume is exhausted, since dragonboat can't recover from a full disk
+	if err := c.protocol.disk.checkWrite(); err != nil {
+		return err
+	}
 	streamID, stream := c.streams.addStream(stream)
 	defer c.streams.removeStream(streamID)
 	entry := &Entry{
 		Value:    input,
 		StreamID: streamID,
 	}
+	if err := c.protocol.keys.encryptEntry(entry); err != nil {
+		return err
+	}
 	bytes, err := proto.Marshal(entry)
 	if err != nil {
 		return err
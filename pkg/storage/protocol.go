@@ -26,20 +26,37 @@ import (
 	raftconfig "github.com/lni/dragonboat/v3/config"
 	"github.com/lni/dragonboat/v3/statemachine"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 const dataDir = "/var/lib/atomix/data"
-const rttMillisecond = 200
+
+// restartRetryInterval is the interval at which a restarted member is started until the stopped member is unloaded
+const restartRetryInterval = 100 * time.Millisecond
+
+// minElectionRTT is the minimum election timeout in heartbeat intervals accepted by dragonboat
+const minElectionRTT = 3
+
+// retainedSnapshots is the number of snapshots dragonboat retains on disk for each partition
+const retainedSnapshots = 3
 
 var log = logging.GetLogger("atomix", "raft")
 
 // NewProtocol returns a new Raft Protocol instance
-func NewProtocol(config config.ProtocolConfig) *Protocol {
+func NewProtocol(config config.ProtocolConfig, membership *config.MembershipConfig) *Protocol {
 	protocol := &Protocol{
-		config:  config,
-		clients: make(map[protocol.PartitionID]*Partition),
-		servers: make(map[protocol.PartitionID]*Server),
+		config:     config,
+		membership: membership,
+		clients:    make(map[protocol.PartitionID]*Partition),
+		servers:    make(map[protocol.PartitionID]*Server),
+		memberIDs:  make(map[uint64]map[uint64]string),
+		stats:      make(map[uint64]*memberStats),
+		disk: &diskMonitor{
+			limit: config.GetMaxDiskUsage(),
+		},
+		keys: newKeyring(encryptionKeyDir, config.GetEncryptionKeyId()),
 	}
 	protocol.listener = &raftEventListener{
 		protocol:  protocol,
@@ -51,94 +68,128 @@ func NewProtocol(config config.ProtocolConfig) *Protocol {
 // Protocol is an implementation of the Client interface providing the Raft consensus protocol
 type Protocol struct {
 	protocol.Protocol
-	config          config.ProtocolConfig
-	mu              sync.RWMutex
-	replicas        []*cluster.Replica
-	clients         map[protocol.PartitionID]*Partition
-	servers         map[protocol.PartitionID]*Server
-	memberIDs       map[uint64]string
-	nodeIDs         map[string]uint64
-	memberAddresses map[uint64]string
-	listener        *raftEventListener
+	config     config.ProtocolConfig
+	membership *config.MembershipConfig
+	mu         sync.RWMutex
+	node       *dragonboat.NodeHost
+	fsmFactory func(uint64, uint64) statemachine.IStateMachine
+	replicas   []*cluster.Replica
+	clients    map[protocol.PartitionID]*Partition
+	servers    map[protocol.PartitionID]*Server
+	memberIDs  map[uint64]map[uint64]string
+	stats      map[uint64]*memberStats
+	disk       *diskMonitor
+	keys       *keyring
+	listener   *raftEventListener
+	cancel     context.CancelFunc
 }
 
 func (p *Protocol) watch(ctx context.Context, ch chan<- RaftEvent) {
 	p.listener.listen(ctx, ch)
 }
 
-func (p *Protocol) getMemberIDs() map[uint64]string {
+// getNodeHost returns the NodeHost if the protocol has been started
+func (p *Protocol) getNodeHost() (*dragonboat.NodeHost, error) {
 	p.mu.RLock()
-	memberIDs := p.memberIDs
-	p.mu.RUnlock()
-	if memberIDs != nil {
-		return memberIDs
+	defer p.mu.RUnlock()
+	if p.node == nil {
+		return nil, errors.NewUnavailable("raft node not started")
 	}
+	return p.node, nil
+}
 
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	if p.memberIDs != nil {
-		return p.memberIDs
+// getMembers returns the configured members of the given partition
+func (p *Protocol) getMembers(partitionID uint64) []config.MemberConfig {
+	if p.membership == nil {
+		members := make([]config.MemberConfig, 0, len(p.replicas))
+		for i, replica := range p.replicas {
+			members = append(members, config.MemberConfig{
+				ReplicaID: string(replica.ID),
+				NodeID:    uint64(i + 1),
+			})
+		}
+		return members
 	}
-
-	p.memberIDs = make(map[uint64]string)
-	for i, replica := range p.replicas {
-		p.memberIDs[uint64(i+1)] = string(replica.ID)
+	for _, partition := range p.membership.Partitions {
+		if uint64(partition.PartitionID) == partitionID {
+			return partition.Members
+		}
 	}
-	return p.memberIDs
-}
-
-func (p *Protocol) getMemberID(id uint64) string {
-	return p.getMemberIDs()[id]
+	return nil
 }
 
-func (p *Protocol) getNodeIDs() map[string]uint64 {
+// getMemberID returns the replica ID of the given Raft node in the given partition
+func (p *Protocol) getMemberID(partitionID uint64, nodeID uint64) string {
 	p.mu.RLock()
-	nodeIDs := p.nodeIDs
-	p.mu.RUnlock()
-	if nodeIDs != nil {
-		return nodeIDs
-	}
+	defer p.mu.RUnlock()
+	return p.memberIDs[partitionID][nodeID]
+}
 
+// setMemberID sets the replica ID of the given Raft node in the given partition
+func (p *Protocol) setMemberID(partitionID uint64, nodeID uint64, memberID string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	if p.nodeIDs != nil {
-		return p.nodeIDs
-	}
-
-	p.nodeIDs = make(map[string]uint64)
-	for i, replica := range p.replicas {
-		p.nodeIDs[string(replica.ID)] = uint64(i + 1)
+	memberIDs, ok := p.memberIDs[partitionID]
+	if !ok {
+		memberIDs = make(map[uint64]string)
+		p.memberIDs[partitionID] = memberIDs
 	}
-	return p.nodeIDs
-}
-
-func (p *Protocol) getNodeID(id string) uint64 {
-	return p.getNodeIDs()[id]
+	memberIDs[nodeID] = memberID
 }
 
-func (p *Protocol) getAddresses() map[uint64]string {
+// getNodeID returns the Raft node ID of the local member of the given partition
+func (p *Protocol) getNodeID(partitionID uint64) (uint64, bool) {
 	p.mu.RLock()
-	memberAddresses := p.memberAddresses
-	p.mu.RUnlock()
-	if memberAddresses != nil {
-		return memberAddresses
+	defer p.mu.RUnlock()
+	server, ok := p.servers[protocol.PartitionID(partitionID)]
+	if !ok {
+		return 0, false
 	}
+	return server.config.NodeID, true
+}
 
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	if p.memberAddresses != nil {
-		return p.memberAddresses
+// getAddress returns the Raft address of the given replica
+func (p *Protocol) getAddress(replicaID string) string {
+	for _, replica := range p.replicas {
+		if string(replica.ID) == replicaID {
+			return fmt.Sprintf("%s:%d", replica.Host, replica.GetPort("raft"))
+		}
 	}
+	return ""
+}
 
-	p.memberAddresses = make(map[uint64]string)
-	for i, replica := range p.replicas {
-		p.memberAddresses[uint64(i+1)] = fmt.Sprintf("%s:%d", replica.Host, replica.GetPort("raft"))
+// getReplicaForAddress returns the ID of the replica listening on the given Raft address
+func (p *Protocol) getReplicaForAddress(address string) string {
+	for _, replica := range p.replicas {
+		if fmt.Sprintf("%s:%d", replica.Host, replica.GetPort("raft")) == address {
+			return string(replica.ID)
+		}
 	}
-	return p.memberAddresses
+	// Members added after the node was started are not in the replica set, but their
+	// pod names can be derived from the leading label of their DNS addresses
+	host := strings.Split(address, ":")[0]
+	return strings.Split(host, ".")[0]
 }
 
-func (p *Protocol) getAddress(id uint64) string {
-	return p.getAddresses()[id]
+// updateMemberIDs updates the known replica IDs from the current membership of the given partition
+func (p *Protocol) updateMemberIDs(partitionID uint64) {
+	node, err := p.getNodeHost()
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clientTimeout)
+	defer cancel()
+	membership, err := node.SyncGetClusterMembership(ctx, partitionID)
+	if err != nil {
+		log.Warnf("Failed to read membership for partition %d: %s", partitionID, err)
+		return
+	}
+	for nodeID, address := range membership.Nodes {
+		p.setMemberID(partitionID, nodeID, p.getReplicaForAddress(address))
+	}
+	for nodeID, address := range membership.Observers {
+		p.setMemberID(partitionID, nodeID, p.getReplicaForAddress(address))
+	}
 }
 
 // Start starts the Raft protocol
@@ -148,6 +199,11 @@ func (p *Protocol) Start(c cluster.Cluster, registry *protocol.Registry) error {
 		return errors.NewInternal("local member not configured")
 	}
 
+	// Fail before starting partitions if entries can't be encrypted with the configured key
+	if err := p.keys.check(); err != nil {
+		return err
+	}
+
 	address := fmt.Sprintf("%s:%d", member.Host, member.GetPort("raft"))
 
 	replicas := make([]*cluster.Replica, 0, len(c.Replicas()))
@@ -162,10 +218,6 @@ func (p *Protocol) Start(c cluster.Cluster, registry *protocol.Registry) error {
 	p.replicas = replicas
 	p.mu.Unlock()
 
-	memberIDs := p.getMemberIDs()
-	memberAddresses := p.getAddresses()
-	nodeID := p.getNodeID(string(member.ID))
-
 	// Create a listener to wait for a leader to be elected
 	eventCh := make(chan RaftEvent)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -175,7 +227,7 @@ func (p *Protocol) Start(c cluster.Cluster, registry *protocol.Registry) error {
 	nodeConfig := raftconfig.NodeHostConfig{
 		WALDir:              dataDir,
 		NodeHostDir:         dataDir,
-		RTTMillisecond:      rttMillisecond,
+		RTTMillisecond:      uint64(p.config.GetHeartbeatIntervalOrDefault() / time.Millisecond),
 		RaftAddress:         address,
 		RaftEventListener:   p.listener,
 		SystemEventListener: p.listener,
@@ -188,43 +240,68 @@ func (p *Protocol) Start(c cluster.Cluster, registry *protocol.Registry) error {
 
 	fsmFactory := func(clusterID, nodeID uint64) statemachine.IStateMachine {
 		streams := newStreamManager()
-		fsm := newStateMachine(c, protocol.PartitionID(clusterID), registry, streams)
-		client := newPartition(clusterID, nodeID, node, memberIDs, streams)
+		fsm := newStateMachine(c, protocol.PartitionID(clusterID), registry, streams, p.getStats(clusterID), p.keys)
+		client := newPartition(clusterID, nodeID, node, p, streams)
 		p.mu.Lock()
 		p.clients[protocol.PartitionID(clusterID)] = client
 		p.mu.Unlock()
 		return fsm
 	}
 
+	p.mu.Lock()
+	p.node = node
+	p.fsmFactory = fsmFactory
+	p.mu.Unlock()
+
 	for _, partition := range c.Partitions() {
-		config := raftconfig.Config{
-			NodeID:             nodeID,
-			ClusterID:          uint64(partition.ID()),
-			ElectionRTT:        10,
-			HeartbeatRTT:       1,
-			CheckQuorum:        true,
-			SnapshotEntries:    p.config.GetSnapshotThresholdOrDefault(),
-			CompactionOverhead: p.config.GetSnapshotThresholdOrDefault() / 10,
+		clusterID := uint64(partition.ID())
+
+		var localMember *config.MemberConfig
+		initialMembers := make(map[uint64]string)
+		for _, m := range p.getMembers(clusterID) {
+			p.setMemberID(clusterID, m.NodeID, m.ReplicaID)
+			if m.ReplicaID == string(member.ID) {
+				local := m
+				localMember = &local
+			}
+			if !m.Join {
+				initialMembers[m.NodeID] = p.getAddress(m.ReplicaID)
+			}
+		}
+
+		// Skip partitions of which the local replica is not a member
+		if localMember == nil {
+			log.Infof("Replica %s is not a member of partition %d", member.ID, clusterID)
+			continue
+		}
+
+		// Members joining a running partition must be started without initial members
+		if localMember.Join {
+			initialMembers = make(map[uint64]string)
 		}
 
-		server := newServer(uint64(partition.ID()), memberAddresses, node, config, fsmFactory)
-		if err := server.Start(); err != nil {
+		if err := p.startPartition(clusterID, localMember.NodeID, initialMembers, localMember.Join, localMember.Learner); err != nil {
 			return err
 		}
-		p.mu.Lock()
-		p.servers[protocol.PartitionID(partition.ID())] = server
-		p.mu.Unlock()
 	}
 
+	p.mu.RLock()
+	numPartitions := len(p.servers)
+	p.mu.RUnlock()
+
+	// Replicas that are not a member of any partition have no leaders to wait for
 	startedCh := make(chan struct{})
+	if numPartitions == 0 {
+		close(startedCh)
+	}
 	go func() {
 		startedPartitions := make(map[uint64]bool)
-		started := false
+		started := numPartitions == 0
 		for event := range eventCh {
 			if leader, ok := event.Event.(*RaftEvent_LeaderUpdated); ok &&
 				leader.LeaderUpdated.Term > 0 && leader.LeaderUpdated.Leader != "" {
 				startedPartitions[leader.LeaderUpdated.Partition] = true
-				if !started && len(startedPartitions) == len(p.servers) {
+				if !started && len(startedPartitions) == numPartitions {
 					close(startedCh)
 					started = true
 				}
@@ -232,6 +309,145 @@ func (p *Protocol) Start(c cluster.Cluster, registry *protocol.Registry) error {
 		}
 	}()
 	<-startedCh
+
+	statsCtx, statsCancel := context.WithCancel(context.Background())
+	p.mu.Lock()
+	p.cancel = statsCancel
+	p.mu.Unlock()
+	go p.publishStats(statsCtx)
+	return nil
+}
+
+// startPartition starts the local member of the given partition
+func (p *Protocol) startPartition(clusterID uint64, nodeID uint64, initialMembers map[uint64]string, join bool, learner bool) error {
+	node, err := p.getNodeHost()
+	if err != nil {
+		return err
+	}
+
+	p.mu.RLock()
+	_, ok := p.servers[protocol.PartitionID(clusterID)]
+	fsmFactory := p.fsmFactory
+	p.mu.RUnlock()
+	if ok {
+		return nil
+	}
+
+	config := raftconfig.Config{
+		NodeID:                 nodeID,
+		ClusterID:              clusterID,
+		ElectionRTT:            p.getElectionRTT(),
+		HeartbeatRTT:           1,
+		CheckQuorum:            true,
+		IsObserver:             learner,
+		SnapshotEntries:        p.config.GetSnapshotThresholdOrDefault(),
+		CompactionOverhead:     p.config.GetCompactionOverheadOrDefault(),
+		DisableAutoCompactions: p.config.GetDisableAutoCompaction(),
+	}
+
+	options := serverOptions{
+		snapshotInterval:       p.config.GetSnapshotIntervalOrDefault(),
+		snapshotThresholdBytes: p.config.GetSnapshotThresholdBytes(),
+		compactionInterval:     p.config.GetCompactionIntervalOrDefault(),
+	}
+	server := newServer(clusterID, initialMembers, join, node, config, fsmFactory, p.getStats(clusterID), options)
+	if err := server.Start(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.servers[protocol.PartitionID(clusterID)] = server
+	p.mu.Unlock()
+	return nil
+}
+
+// stopPartition stops the local member of the given partition and removes its data
+func (p *Protocol) stopPartition(ctx context.Context, clusterID uint64) error {
+	node, err := p.getNodeHost()
+	if err != nil {
+		return err
+	}
+
+	p.mu.RLock()
+	server, ok := p.servers[protocol.PartitionID(clusterID)]
+	p.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	// The member was already stopped if a previous attempt failed to remove its data
+	if err := server.Stop(); err != nil && err != dragonboat.ErrClusterNotFound {
+		return err
+	}
+
+	// Data can only be removed once the member has been unloaded from the node. The server is
+	// only removed from the protocol once its data is removed so failed removals can be retried.
+	if err := node.SyncRemoveData(ctx, clusterID, server.config.NodeID); err != nil {
+		log.Warnf("Failed to remove data for partition %d: %s", clusterID, err)
+		return err
+	}
+
+	p.mu.Lock()
+	delete(p.servers, protocol.PartitionID(clusterID))
+	delete(p.clients, protocol.PartitionID(clusterID))
+	delete(p.stats, clusterID)
+	p.mu.Unlock()
+	return nil
+}
+
+// restartPartition stops the local member of the given partition and restarts it from its persisted state.
+// The lock is released while the member is stopped and started, since dragonboat creates the member's state
+// machine while starting it and the state machine factory takes the lock. The server is removed from the
+// protocol while it's restarting so concurrent restarts and stops of the member fail.
+func (p *Protocol) restartPartition(ctx context.Context, clusterID uint64) error {
+	node, err := p.getNodeHost()
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	server, ok := p.servers[protocol.PartitionID(clusterID)]
+	delete(p.servers, protocol.PartitionID(clusterID))
+	fsmFactory := p.fsmFactory
+	p.mu.Unlock()
+	if !ok {
+		return errors.NewNotFound("partition %d not found", clusterID)
+	}
+
+	// The stopped server is registered again if the member can't be restarted, so a retry converges
+	register := func() {
+		p.mu.Lock()
+		p.servers[protocol.PartitionID(clusterID)] = server
+		p.mu.Unlock()
+	}
+
+	// The member was already stopped if a previous attempt failed to restart it
+	if err := server.Stop(); err != nil && err != dragonboat.ErrClusterNotFound {
+		register()
+		return err
+	}
+
+	// Members with persisted state rejoin their partition without initial members. The stopped member
+	// may not have been unloaded from the node yet, so starting it is retried until it's unloaded.
+	restarted := newServer(clusterID, map[uint64]string{}, false, node, server.config, fsmFactory, server.stats, server.options)
+	for {
+		err := restarted.Start()
+		if err == nil {
+			break
+		} else if err != dragonboat.ErrClusterAlreadyExist {
+			register()
+			return err
+		}
+		select {
+		case <-time.After(restartRetryInterval):
+		case <-ctx.Done():
+			register()
+			return errors.NewTimeout("timed out waiting for partition %d to stop", clusterID)
+		}
+	}
+
+	p.mu.Lock()
+	p.servers[protocol.PartitionID(clusterID)] = restarted
+	p.mu.Unlock()
 	return nil
 }
 
@@ -239,27 +455,58 @@ func (p *Protocol) Start(c cluster.Cluster, registry *protocol.Registry) error {
 func (p *Protocol) Partition(partitionID protocol.PartitionID) protocol.Partition {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	return p.clients[partitionID]
+	partition, ok := p.clients[partitionID]
+	if !ok {
+		return nil
+	}
+	return partition
 }
 
 // Partitions returns all partition clients
 func (p *Protocol) Partitions() []protocol.Partition {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	partitions := make([]protocol.Partition, len(p.clients))
-	for i := 0; i < len(p.clients); i++ {
-		partitions[i] = p.clients[protocol.PartitionID(i+1)]
+	// Partition IDs are not contiguous when partitions are spread across clusters
+	partitionIDs := make([]protocol.PartitionID, 0, len(p.clients))
+	for partitionID := range p.clients {
+		partitionIDs = append(partitionIDs, partitionID)
+	}
+	sort.Slice(partitionIDs, func(i, j int) bool {
+		return partitionIDs[i] < partitionIDs[j]
+	})
+	partitions := make([]protocol.Partition, len(partitionIDs))
+	for i, partitionID := range partitionIDs {
+		partitions[i] = p.clients[partitionID]
 	}
 	return partitions
 }
 
 // Stop stops the Raft protocol
 func (p *Protocol) Stop() error {
-	var returnErr error
+	p.mu.RLock()
+	cancel := p.cancel
+	servers := make([]*Server, 0, len(p.servers))
 	for _, server := range p.servers {
+		servers = append(servers, server)
+	}
+	p.mu.RUnlock()
+	if cancel != nil {
+		cancel()
+	}
+	var returnErr error
+	for _, server := range servers {
 		if err := server.Stop(); err != nil {
 			returnErr = err
 		}
 	}
 	return returnErr
 }
+
+// getElectionRTT returns the election timeout as a multiple of the heartbeat interval
+func (p *Protocol) getElectionRTT() uint64 {
+	electionRTT := uint64(p.config.GetElectionTimeoutOrDefault() / p.config.GetHeartbeatIntervalOrDefault())
+	if electionRTT < minElectionRTT {
+		return minElectionRTT
+	}
+	return electionRTT
+}
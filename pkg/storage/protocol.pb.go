@@ -71,12 +71,42 @@ func (EventType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_5ddcb70539c8e6f6, []int{0}
 }
 
+type MemberStatsEvent_Role int32
+
+const (
+	MemberStatsEvent_FOLLOWER  MemberStatsEvent_Role = 0
+	MemberStatsEvent_CANDIDATE MemberStatsEvent_Role = 1
+	MemberStatsEvent_LEADER    MemberStatsEvent_Role = 2
+)
+
+var MemberStatsEvent_Role_name = map[int32]string{
+	0: "FOLLOWER",
+	1: "CANDIDATE",
+	2: "LEADER",
+}
+
+var MemberStatsEvent_Role_value = map[string]int32{
+	"FOLLOWER":  0,
+	"CANDIDATE": 1,
+	"LEADER":    2,
+}
+
+func (x MemberStatsEvent_Role) String() string {
+	return proto.EnumName(MemberStatsEvent_Role_name, int32(x))
+}
+
+func (MemberStatsEvent_Role) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{39, 0}
+}
+
 // Entry is a Raft log entry
 type Entry struct {
 	// value is the value of the entry
 	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
 	// stream_id is the entry stream identifier
 	StreamID streamID `protobuf:"varint,2,opt,name=stream_id,json=streamId,proto3,casttype=streamID" json:"stream_id,omitempty"`
+	// key_id is the identifier of the key with which the value is encrypted, or empty if the value is not encrypted
+	KeyID string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
 }
 
 func (m *Entry) Reset()         { *m = Entry{} }
@@ -126,21 +156,32 @@ func (m *Entry) GetStreamID() streamID {
 	return 0
 }
 
-type SubscribeRequest struct {
+func (m *Entry) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
 }
 
-func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
-func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
-func (*SubscribeRequest) ProtoMessage()    {}
-func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+type AddMemberRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	NodeID    uint64 `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Address   string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Learner   bool   `protobuf:"varint,4,opt,name=learner,proto3" json:"learner,omitempty"`
+}
+
+func (m *AddMemberRequest) Reset()         { *m = AddMemberRequest{} }
+func (m *AddMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*AddMemberRequest) ProtoMessage()    {}
+func (*AddMemberRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5ddcb70539c8e6f6, []int{1}
 }
-func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+func (m *AddMemberRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AddMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AddMemberRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -150,50 +191,61 @@ func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+func (m *AddMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddMemberRequest.Merge(m, src)
 }
-func (m *SubscribeRequest) XXX_Size() int {
+func (m *AddMemberRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SubscribeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+func (m *AddMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddMemberRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+var xxx_messageInfo_AddMemberRequest proto.InternalMessageInfo
 
-type RaftEvent struct {
-	Timestamp time.Time `protobuf:"bytes,1,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
-	// Types that are valid to be assigned to Event:
-	//	*RaftEvent_MemberReady
-	//	*RaftEvent_LeaderUpdated
-	//	*RaftEvent_MembershipChanged
-	//	*RaftEvent_SendSnapshotStarted
-	//	*RaftEvent_SendSnapshotCompleted
-	//	*RaftEvent_SendSnapshotAborted
-	//	*RaftEvent_SnapshotReceived
-	//	*RaftEvent_SnapshotRecovered
-	//	*RaftEvent_SnapshotCreated
-	//	*RaftEvent_SnapshotCompacted
-	//	*RaftEvent_LogCompacted
-	//	*RaftEvent_LogdbCompacted
-	//	*RaftEvent_ConnectionEstablished
-	//	*RaftEvent_ConnectionFailed
-	Event isRaftEvent_Event `protobuf_oneof:"event"`
+func (m *AddMemberRequest) GetPartition() uint64 {
+	if m != nil {
+		return m.Partition
+	}
+	return 0
 }
 
-func (m *RaftEvent) Reset()         { *m = RaftEvent{} }
-func (m *RaftEvent) String() string { return proto.CompactTextString(m) }
-func (*RaftEvent) ProtoMessage()    {}
-func (*RaftEvent) Descriptor() ([]byte, []int) {
+func (m *AddMemberRequest) GetNodeID() uint64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *AddMemberRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AddMemberRequest) GetLearner() bool {
+	if m != nil {
+		return m.Learner
+	}
+	return false
+}
+
+type AddMemberResponse struct {
+}
+
+func (m *AddMemberResponse) Reset()         { *m = AddMemberResponse{} }
+func (m *AddMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*AddMemberResponse) ProtoMessage()    {}
+func (*AddMemberResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5ddcb70539c8e6f6, []int{2}
 }
-func (m *RaftEvent) XXX_Unmarshal(b []byte) error {
+func (m *AddMemberResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RaftEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AddMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RaftEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AddMemberResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -203,230 +255,204 @@ func (m *RaftEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *RaftEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RaftEvent.Merge(m, src)
+func (m *AddMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddMemberResponse.Merge(m, src)
 }
-func (m *RaftEvent) XXX_Size() int {
+func (m *AddMemberResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *RaftEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_RaftEvent.DiscardUnknown(m)
+func (m *AddMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddMemberResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RaftEvent proto.InternalMessageInfo
+var xxx_messageInfo_AddMemberResponse proto.InternalMessageInfo
 
-type isRaftEvent_Event interface {
-	isRaftEvent_Event()
-	MarshalTo([]byte) (int, error)
-	Size() int
+type RemoveMemberRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	NodeID    uint64 `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 }
 
-type RaftEvent_MemberReady struct {
-	MemberReady *MemberReadyEvent `protobuf:"bytes,2,opt,name=member_ready,json=memberReady,proto3,oneof" json:"member_ready,omitempty"`
-}
-type RaftEvent_LeaderUpdated struct {
-	LeaderUpdated *LeaderUpdatedEvent `protobuf:"bytes,3,opt,name=leader_updated,json=leaderUpdated,proto3,oneof" json:"leader_updated,omitempty"`
-}
-type RaftEvent_MembershipChanged struct {
-	MembershipChanged *MembershipChangedEvent `protobuf:"bytes,4,opt,name=membership_changed,json=membershipChanged,proto3,oneof" json:"membership_changed,omitempty"`
-}
-type RaftEvent_SendSnapshotStarted struct {
-	SendSnapshotStarted *SendSnapshotStartedEvent `protobuf:"bytes,5,opt,name=send_snapshot_started,json=sendSnapshotStarted,proto3,oneof" json:"send_snapshot_started,omitempty"`
-}
-type RaftEvent_SendSnapshotCompleted struct {
-	SendSnapshotCompleted *SendSnapshotCompletedEvent `protobuf:"bytes,6,opt,name=send_snapshot_completed,json=sendSnapshotCompleted,proto3,oneof" json:"send_snapshot_completed,omitempty"`
-}
-type RaftEvent_SendSnapshotAborted struct {
-	SendSnapshotAborted *SendSnapshotAbortedEvent `protobuf:"bytes,7,opt,name=send_snapshot_aborted,json=sendSnapshotAborted,proto3,oneof" json:"send_snapshot_aborted,omitempty"`
-}
-type RaftEvent_SnapshotReceived struct {
-	SnapshotReceived *SnapshotReceivedEvent `protobuf:"bytes,8,opt,name=snapshot_received,json=snapshotReceived,proto3,oneof" json:"snapshot_received,omitempty"`
-}
-type RaftEvent_SnapshotRecovered struct {
-	SnapshotRecovered *SnapshotRecoveredEvent `protobuf:"bytes,9,opt,name=snapshot_recovered,json=snapshotRecovered,proto3,oneof" json:"snapshot_recovered,omitempty"`
-}
-type RaftEvent_SnapshotCreated struct {
-	SnapshotCreated *SnapshotCreatedEvent `protobuf:"bytes,10,opt,name=snapshot_created,json=snapshotCreated,proto3,oneof" json:"snapshot_created,omitempty"`
+func (m *RemoveMemberRequest) Reset()         { *m = RemoveMemberRequest{} }
+func (m *RemoveMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveMemberRequest) ProtoMessage()    {}
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{3}
 }
-type RaftEvent_SnapshotCompacted struct {
-	SnapshotCompacted *SnapshotCompactedEvent `protobuf:"bytes,11,opt,name=snapshot_compacted,json=snapshotCompacted,proto3,oneof" json:"snapshot_compacted,omitempty"`
+func (m *RemoveMemberRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-type RaftEvent_LogCompacted struct {
-	LogCompacted *LogCompactedEvent `protobuf:"bytes,12,opt,name=log_compacted,json=logCompacted,proto3,oneof" json:"log_compacted,omitempty"`
+func (m *RemoveMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoveMemberRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-type RaftEvent_LogdbCompacted struct {
-	LogdbCompacted *LogDBCompactedEvent `protobuf:"bytes,13,opt,name=logdb_compacted,json=logdbCompacted,proto3,oneof" json:"logdb_compacted,omitempty"`
+func (m *RemoveMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveMemberRequest.Merge(m, src)
 }
-type RaftEvent_ConnectionEstablished struct {
-	ConnectionEstablished *ConnectionEstablishedEvent `protobuf:"bytes,14,opt,name=connection_established,json=connectionEstablished,proto3,oneof" json:"connection_established,omitempty"`
+func (m *RemoveMemberRequest) XXX_Size() int {
+	return m.Size()
 }
-type RaftEvent_ConnectionFailed struct {
-	ConnectionFailed *ConnectionFailedEvent `protobuf:"bytes,15,opt,name=connection_failed,json=connectionFailed,proto3,oneof" json:"connection_failed,omitempty"`
+func (m *RemoveMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveMemberRequest.DiscardUnknown(m)
 }
 
-func (*RaftEvent_MemberReady) isRaftEvent_Event()           {}
-func (*RaftEvent_LeaderUpdated) isRaftEvent_Event()         {}
-func (*RaftEvent_MembershipChanged) isRaftEvent_Event()     {}
-func (*RaftEvent_SendSnapshotStarted) isRaftEvent_Event()   {}
-func (*RaftEvent_SendSnapshotCompleted) isRaftEvent_Event() {}
-func (*RaftEvent_SendSnapshotAborted) isRaftEvent_Event()   {}
-func (*RaftEvent_SnapshotReceived) isRaftEvent_Event()      {}
-func (*RaftEvent_SnapshotRecovered) isRaftEvent_Event()     {}
-func (*RaftEvent_SnapshotCreated) isRaftEvent_Event()       {}
-func (*RaftEvent_SnapshotCompacted) isRaftEvent_Event()     {}
-func (*RaftEvent_LogCompacted) isRaftEvent_Event()          {}
-func (*RaftEvent_LogdbCompacted) isRaftEvent_Event()        {}
-func (*RaftEvent_ConnectionEstablished) isRaftEvent_Event() {}
-func (*RaftEvent_ConnectionFailed) isRaftEvent_Event()      {}
+var xxx_messageInfo_RemoveMemberRequest proto.InternalMessageInfo
 
-func (m *RaftEvent) GetEvent() isRaftEvent_Event {
+func (m *RemoveMemberRequest) GetPartition() uint64 {
 	if m != nil {
-		return m.Event
+		return m.Partition
 	}
-	return nil
+	return 0
 }
 
-func (m *RaftEvent) GetTimestamp() time.Time {
+func (m *RemoveMemberRequest) GetNodeID() uint64 {
 	if m != nil {
-		return m.Timestamp
+		return m.NodeID
 	}
-	return time.Time{}
+	return 0
 }
 
-func (m *RaftEvent) GetMemberReady() *MemberReadyEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_MemberReady); ok {
-		return x.MemberReady
-	}
-	return nil
+type RemoveMemberResponse struct {
 }
 
-func (m *RaftEvent) GetLeaderUpdated() *LeaderUpdatedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_LeaderUpdated); ok {
-		return x.LeaderUpdated
-	}
-	return nil
+func (m *RemoveMemberResponse) Reset()         { *m = RemoveMemberResponse{} }
+func (m *RemoveMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveMemberResponse) ProtoMessage()    {}
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{4}
 }
-
-func (m *RaftEvent) GetMembershipChanged() *MembershipChangedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_MembershipChanged); ok {
-		return x.MembershipChanged
-	}
-	return nil
+func (m *RemoveMemberResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *RaftEvent) GetSendSnapshotStarted() *SendSnapshotStartedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_SendSnapshotStarted); ok {
-		return x.SendSnapshotStarted
+func (m *RemoveMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoveMemberResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
 }
-
-func (m *RaftEvent) GetSendSnapshotCompleted() *SendSnapshotCompletedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_SendSnapshotCompleted); ok {
-		return x.SendSnapshotCompleted
-	}
-	return nil
+func (m *RemoveMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveMemberResponse.Merge(m, src)
 }
-
-func (m *RaftEvent) GetSendSnapshotAborted() *SendSnapshotAbortedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_SendSnapshotAborted); ok {
-		return x.SendSnapshotAborted
-	}
-	return nil
+func (m *RemoveMemberResponse) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *RaftEvent) GetSnapshotReceived() *SnapshotReceivedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_SnapshotReceived); ok {
-		return x.SnapshotReceived
-	}
-	return nil
+func (m *RemoveMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveMemberResponse.DiscardUnknown(m)
 }
 
-func (m *RaftEvent) GetSnapshotRecovered() *SnapshotRecoveredEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_SnapshotRecovered); ok {
-		return x.SnapshotRecovered
-	}
-	return nil
-}
+var xxx_messageInfo_RemoveMemberResponse proto.InternalMessageInfo
 
-func (m *RaftEvent) GetSnapshotCreated() *SnapshotCreatedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_SnapshotCreated); ok {
-		return x.SnapshotCreated
-	}
-	return nil
+type SyncMemberRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
 }
 
-func (m *RaftEvent) GetSnapshotCompacted() *SnapshotCompactedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_SnapshotCompacted); ok {
-		return x.SnapshotCompacted
+func (m *SyncMemberRequest) Reset()         { *m = SyncMemberRequest{} }
+func (m *SyncMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncMemberRequest) ProtoMessage()    {}
+func (*SyncMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{5}
+}
+func (m *SyncMemberRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SyncMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SyncMemberRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *SyncMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncMemberRequest.Merge(m, src)
+}
+func (m *SyncMemberRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SyncMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncMemberRequest.DiscardUnknown(m)
 }
 
-func (m *RaftEvent) GetLogCompacted() *LogCompactedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_LogCompacted); ok {
-		return x.LogCompacted
+var xxx_messageInfo_SyncMemberRequest proto.InternalMessageInfo
+
+func (m *SyncMemberRequest) GetPartition() uint64 {
+	if m != nil {
+		return m.Partition
 	}
-	return nil
+	return 0
 }
 
-func (m *RaftEvent) GetLogdbCompacted() *LogDBCompactedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_LogdbCompacted); ok {
-		return x.LogdbCompacted
-	}
-	return nil
+type SyncMemberResponse struct {
 }
 
-func (m *RaftEvent) GetConnectionEstablished() *ConnectionEstablishedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_ConnectionEstablished); ok {
-		return x.ConnectionEstablished
-	}
-	return nil
+func (m *SyncMemberResponse) Reset()         { *m = SyncMemberResponse{} }
+func (m *SyncMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*SyncMemberResponse) ProtoMessage()    {}
+func (*SyncMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{6}
 }
-
-func (m *RaftEvent) GetConnectionFailed() *ConnectionFailedEvent {
-	if x, ok := m.GetEvent().(*RaftEvent_ConnectionFailed); ok {
-		return x.ConnectionFailed
-	}
-	return nil
+func (m *SyncMemberResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*RaftEvent) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*RaftEvent_MemberReady)(nil),
-		(*RaftEvent_LeaderUpdated)(nil),
-		(*RaftEvent_MembershipChanged)(nil),
-		(*RaftEvent_SendSnapshotStarted)(nil),
-		(*RaftEvent_SendSnapshotCompleted)(nil),
-		(*RaftEvent_SendSnapshotAborted)(nil),
-		(*RaftEvent_SnapshotReceived)(nil),
-		(*RaftEvent_SnapshotRecovered)(nil),
-		(*RaftEvent_SnapshotCreated)(nil),
-		(*RaftEvent_SnapshotCompacted)(nil),
-		(*RaftEvent_LogCompacted)(nil),
-		(*RaftEvent_LogdbCompacted)(nil),
-		(*RaftEvent_ConnectionEstablished)(nil),
-		(*RaftEvent_ConnectionFailed)(nil),
+func (m *SyncMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SyncMemberResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
 }
+func (m *SyncMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncMemberResponse.Merge(m, src)
+}
+func (m *SyncMemberResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SyncMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncMemberResponse.DiscardUnknown(m)
+}
 
-type PartitionEvent struct {
+var xxx_messageInfo_SyncMemberResponse proto.InternalMessageInfo
+
+type TransferLeadershipRequest struct {
 	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	// node_id is the node to which to transfer leadership, or 0 for any voting member
+	NodeID uint64 `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 }
 
-func (m *PartitionEvent) Reset()         { *m = PartitionEvent{} }
-func (m *PartitionEvent) String() string { return proto.CompactTextString(m) }
-func (*PartitionEvent) ProtoMessage()    {}
-func (*PartitionEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{3}
+func (m *TransferLeadershipRequest) Reset()         { *m = TransferLeadershipRequest{} }
+func (m *TransferLeadershipRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipRequest) ProtoMessage()    {}
+func (*TransferLeadershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{7}
 }
-func (m *PartitionEvent) XXX_Unmarshal(b []byte) error {
+func (m *TransferLeadershipRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PartitionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TransferLeadershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PartitionEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TransferLeadershipRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -436,41 +462,47 @@ func (m *PartitionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *PartitionEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PartitionEvent.Merge(m, src)
+func (m *TransferLeadershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipRequest.Merge(m, src)
 }
-func (m *PartitionEvent) XXX_Size() int {
+func (m *TransferLeadershipRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PartitionEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_PartitionEvent.DiscardUnknown(m)
+func (m *TransferLeadershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PartitionEvent proto.InternalMessageInfo
+var xxx_messageInfo_TransferLeadershipRequest proto.InternalMessageInfo
 
-func (m *PartitionEvent) GetPartition() uint64 {
+func (m *TransferLeadershipRequest) GetPartition() uint64 {
 	if m != nil {
 		return m.Partition
 	}
 	return 0
 }
 
-type MemberReadyEvent struct {
-	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
+func (m *TransferLeadershipRequest) GetNodeID() uint64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
 }
 
-func (m *MemberReadyEvent) Reset()         { *m = MemberReadyEvent{} }
-func (m *MemberReadyEvent) String() string { return proto.CompactTextString(m) }
-func (*MemberReadyEvent) ProtoMessage()    {}
-func (*MemberReadyEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{4}
+type TransferLeadershipResponse struct {
 }
-func (m *MemberReadyEvent) XXX_Unmarshal(b []byte) error {
+
+func (m *TransferLeadershipResponse) Reset()         { *m = TransferLeadershipResponse{} }
+func (m *TransferLeadershipResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipResponse) ProtoMessage()    {}
+func (*TransferLeadershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{8}
+}
+func (m *TransferLeadershipResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MemberReadyEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TransferLeadershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MemberReadyEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TransferLeadershipResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -480,34 +512,34 @@ func (m *MemberReadyEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *MemberReadyEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MemberReadyEvent.Merge(m, src)
+func (m *TransferLeadershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipResponse.Merge(m, src)
 }
-func (m *MemberReadyEvent) XXX_Size() int {
+func (m *TransferLeadershipResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *MemberReadyEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_MemberReadyEvent.DiscardUnknown(m)
+func (m *TransferLeadershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MemberReadyEvent proto.InternalMessageInfo
+var xxx_messageInfo_TransferLeadershipResponse proto.InternalMessageInfo
 
-type MembershipChangedEvent struct {
-	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
+type AcquireLeadershipRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
 }
 
-func (m *MembershipChangedEvent) Reset()         { *m = MembershipChangedEvent{} }
-func (m *MembershipChangedEvent) String() string { return proto.CompactTextString(m) }
-func (*MembershipChangedEvent) ProtoMessage()    {}
-func (*MembershipChangedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{5}
+func (m *AcquireLeadershipRequest) Reset()         { *m = AcquireLeadershipRequest{} }
+func (m *AcquireLeadershipRequest) String() string { return proto.CompactTextString(m) }
+func (*AcquireLeadershipRequest) ProtoMessage()    {}
+func (*AcquireLeadershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{9}
 }
-func (m *MembershipChangedEvent) XXX_Unmarshal(b []byte) error {
+func (m *AcquireLeadershipRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MembershipChangedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AcquireLeadershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MembershipChangedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AcquireLeadershipRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -517,36 +549,40 @@ func (m *MembershipChangedEvent) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *MembershipChangedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MembershipChangedEvent.Merge(m, src)
+func (m *AcquireLeadershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireLeadershipRequest.Merge(m, src)
 }
-func (m *MembershipChangedEvent) XXX_Size() int {
+func (m *AcquireLeadershipRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MembershipChangedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_MembershipChangedEvent.DiscardUnknown(m)
+func (m *AcquireLeadershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireLeadershipRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MembershipChangedEvent proto.InternalMessageInfo
+var xxx_messageInfo_AcquireLeadershipRequest proto.InternalMessageInfo
 
-type LeaderEvent struct {
-	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
-	Term           uint64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
-	Leader         string `protobuf:"bytes,3,opt,name=leader,proto3" json:"leader,omitempty"`
+func (m *AcquireLeadershipRequest) GetPartition() uint64 {
+	if m != nil {
+		return m.Partition
+	}
+	return 0
 }
 
-func (m *LeaderEvent) Reset()         { *m = LeaderEvent{} }
-func (m *LeaderEvent) String() string { return proto.CompactTextString(m) }
-func (*LeaderEvent) ProtoMessage()    {}
-func (*LeaderEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{6}
+type AcquireLeadershipResponse struct {
 }
-func (m *LeaderEvent) XXX_Unmarshal(b []byte) error {
+
+func (m *AcquireLeadershipResponse) Reset()         { *m = AcquireLeadershipResponse{} }
+func (m *AcquireLeadershipResponse) String() string { return proto.CompactTextString(m) }
+func (*AcquireLeadershipResponse) ProtoMessage()    {}
+func (*AcquireLeadershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{10}
+}
+func (m *AcquireLeadershipResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LeaderEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AcquireLeadershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LeaderEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AcquireLeadershipResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -556,48 +592,36 @@ func (m *LeaderEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *LeaderEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LeaderEvent.Merge(m, src)
+func (m *AcquireLeadershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireLeadershipResponse.Merge(m, src)
 }
-func (m *LeaderEvent) XXX_Size() int {
+func (m *AcquireLeadershipResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *LeaderEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LeaderEvent.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_LeaderEvent proto.InternalMessageInfo
-
-func (m *LeaderEvent) GetTerm() uint64 {
-	if m != nil {
-		return m.Term
-	}
-	return 0
+func (m *AcquireLeadershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireLeadershipResponse.DiscardUnknown(m)
 }
 
-func (m *LeaderEvent) GetLeader() string {
-	if m != nil {
-		return m.Leader
-	}
-	return ""
-}
+var xxx_messageInfo_AcquireLeadershipResponse proto.InternalMessageInfo
 
-type LeaderUpdatedEvent struct {
-	LeaderEvent `protobuf:"bytes,1,opt,name=leader,proto3,embedded=leader" json:"leader"`
+type SnapshotMemberRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	// compaction_overhead is the number of entries to retain in the log after the snapshot, or 0 for the default
+	CompactionOverhead uint64 `protobuf:"varint,2,opt,name=compaction_overhead,json=compactionOverhead,proto3" json:"compaction_overhead,omitempty"`
 }
 
-func (m *LeaderUpdatedEvent) Reset()         { *m = LeaderUpdatedEvent{} }
-func (m *LeaderUpdatedEvent) String() string { return proto.CompactTextString(m) }
-func (*LeaderUpdatedEvent) ProtoMessage()    {}
-func (*LeaderUpdatedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{7}
+func (m *SnapshotMemberRequest) Reset()         { *m = SnapshotMemberRequest{} }
+func (m *SnapshotMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotMemberRequest) ProtoMessage()    {}
+func (*SnapshotMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{11}
 }
-func (m *LeaderUpdatedEvent) XXX_Unmarshal(b []byte) error {
+func (m *SnapshotMemberRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LeaderUpdatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SnapshotMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LeaderUpdatedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SnapshotMemberRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -607,35 +631,48 @@ func (m *LeaderUpdatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LeaderUpdatedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LeaderUpdatedEvent.Merge(m, src)
+func (m *SnapshotMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotMemberRequest.Merge(m, src)
 }
-func (m *LeaderUpdatedEvent) XXX_Size() int {
+func (m *SnapshotMemberRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LeaderUpdatedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LeaderUpdatedEvent.DiscardUnknown(m)
+func (m *SnapshotMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotMemberRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LeaderUpdatedEvent proto.InternalMessageInfo
+var xxx_messageInfo_SnapshotMemberRequest proto.InternalMessageInfo
 
-type SnapshotEvent struct {
-	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
-	Index          uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+func (m *SnapshotMemberRequest) GetPartition() uint64 {
+	if m != nil {
+		return m.Partition
+	}
+	return 0
 }
 
-func (m *SnapshotEvent) Reset()         { *m = SnapshotEvent{} }
-func (m *SnapshotEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotEvent) ProtoMessage()    {}
-func (*SnapshotEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{8}
+func (m *SnapshotMemberRequest) GetCompactionOverhead() uint64 {
+	if m != nil {
+		return m.CompactionOverhead
+	}
+	return 0
 }
-func (m *SnapshotEvent) XXX_Unmarshal(b []byte) error {
+
+type SnapshotMemberResponse struct {
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (m *SnapshotMemberResponse) Reset()         { *m = SnapshotMemberResponse{} }
+func (m *SnapshotMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotMemberResponse) ProtoMessage()    {}
+func (*SnapshotMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{12}
+}
+func (m *SnapshotMemberResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SnapshotMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SnapshotMemberResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -645,42 +682,41 @@ func (m *SnapshotEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *SnapshotEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotEvent.Merge(m, src)
+func (m *SnapshotMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotMemberResponse.Merge(m, src)
 }
-func (m *SnapshotEvent) XXX_Size() int {
+func (m *SnapshotMemberResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotEvent.DiscardUnknown(m)
+func (m *SnapshotMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotMemberResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotEvent proto.InternalMessageInfo
+var xxx_messageInfo_SnapshotMemberResponse proto.InternalMessageInfo
 
-func (m *SnapshotEvent) GetIndex() uint64 {
+func (m *SnapshotMemberResponse) GetIndex() uint64 {
 	if m != nil {
 		return m.Index
 	}
 	return 0
 }
 
-type SendSnapshotStartedEvent struct {
-	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
-	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+type CompactMemberRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
 }
 
-func (m *SendSnapshotStartedEvent) Reset()         { *m = SendSnapshotStartedEvent{} }
-func (m *SendSnapshotStartedEvent) String() string { return proto.CompactTextString(m) }
-func (*SendSnapshotStartedEvent) ProtoMessage()    {}
-func (*SendSnapshotStartedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{9}
+func (m *CompactMemberRequest) Reset()         { *m = CompactMemberRequest{} }
+func (m *CompactMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*CompactMemberRequest) ProtoMessage()    {}
+func (*CompactMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{13}
 }
-func (m *SendSnapshotStartedEvent) XXX_Unmarshal(b []byte) error {
+func (m *CompactMemberRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SendSnapshotStartedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CompactMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SendSnapshotStartedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CompactMemberRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -690,42 +726,40 @@ func (m *SendSnapshotStartedEvent) XXX_Marshal(b []byte, deterministic bool) ([]
 		return b[:n], nil
 	}
 }
-func (m *SendSnapshotStartedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendSnapshotStartedEvent.Merge(m, src)
+func (m *CompactMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactMemberRequest.Merge(m, src)
 }
-func (m *SendSnapshotStartedEvent) XXX_Size() int {
+func (m *CompactMemberRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SendSnapshotStartedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendSnapshotStartedEvent.DiscardUnknown(m)
+func (m *CompactMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactMemberRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SendSnapshotStartedEvent proto.InternalMessageInfo
+var xxx_messageInfo_CompactMemberRequest proto.InternalMessageInfo
 
-func (m *SendSnapshotStartedEvent) GetTo() string {
+func (m *CompactMemberRequest) GetPartition() uint64 {
 	if m != nil {
-		return m.To
+		return m.Partition
 	}
-	return ""
+	return 0
 }
 
-type SendSnapshotCompletedEvent struct {
-	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
-	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+type CompactMemberResponse struct {
 }
 
-func (m *SendSnapshotCompletedEvent) Reset()         { *m = SendSnapshotCompletedEvent{} }
-func (m *SendSnapshotCompletedEvent) String() string { return proto.CompactTextString(m) }
-func (*SendSnapshotCompletedEvent) ProtoMessage()    {}
-func (*SendSnapshotCompletedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{10}
+func (m *CompactMemberResponse) Reset()         { *m = CompactMemberResponse{} }
+func (m *CompactMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*CompactMemberResponse) ProtoMessage()    {}
+func (*CompactMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{14}
 }
-func (m *SendSnapshotCompletedEvent) XXX_Unmarshal(b []byte) error {
+func (m *CompactMemberResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SendSnapshotCompletedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CompactMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SendSnapshotCompletedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CompactMemberResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -735,42 +769,34 @@ func (m *SendSnapshotCompletedEvent) XXX_Marshal(b []byte, deterministic bool) (
 		return b[:n], nil
 	}
 }
-func (m *SendSnapshotCompletedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendSnapshotCompletedEvent.Merge(m, src)
+func (m *CompactMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactMemberResponse.Merge(m, src)
 }
-func (m *SendSnapshotCompletedEvent) XXX_Size() int {
+func (m *CompactMemberResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *SendSnapshotCompletedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendSnapshotCompletedEvent.DiscardUnknown(m)
+func (m *CompactMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactMemberResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SendSnapshotCompletedEvent proto.InternalMessageInfo
-
-func (m *SendSnapshotCompletedEvent) GetTo() string {
-	if m != nil {
-		return m.To
-	}
-	return ""
-}
+var xxx_messageInfo_CompactMemberResponse proto.InternalMessageInfo
 
-type SendSnapshotAbortedEvent struct {
-	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
-	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+type RestartMemberRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
 }
 
-func (m *SendSnapshotAbortedEvent) Reset()         { *m = SendSnapshotAbortedEvent{} }
-func (m *SendSnapshotAbortedEvent) String() string { return proto.CompactTextString(m) }
-func (*SendSnapshotAbortedEvent) ProtoMessage()    {}
-func (*SendSnapshotAbortedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{11}
+func (m *RestartMemberRequest) Reset()         { *m = RestartMemberRequest{} }
+func (m *RestartMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*RestartMemberRequest) ProtoMessage()    {}
+func (*RestartMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{15}
 }
-func (m *SendSnapshotAbortedEvent) XXX_Unmarshal(b []byte) error {
+func (m *RestartMemberRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SendSnapshotAbortedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RestartMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SendSnapshotAbortedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RestartMemberRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -780,42 +806,40 @@ func (m *SendSnapshotAbortedEvent) XXX_Marshal(b []byte, deterministic bool) ([]
 		return b[:n], nil
 	}
 }
-func (m *SendSnapshotAbortedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendSnapshotAbortedEvent.Merge(m, src)
+func (m *RestartMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartMemberRequest.Merge(m, src)
 }
-func (m *SendSnapshotAbortedEvent) XXX_Size() int {
+func (m *RestartMemberRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SendSnapshotAbortedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendSnapshotAbortedEvent.DiscardUnknown(m)
+func (m *RestartMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartMemberRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SendSnapshotAbortedEvent proto.InternalMessageInfo
+var xxx_messageInfo_RestartMemberRequest proto.InternalMessageInfo
 
-func (m *SendSnapshotAbortedEvent) GetTo() string {
+func (m *RestartMemberRequest) GetPartition() uint64 {
 	if m != nil {
-		return m.To
+		return m.Partition
 	}
-	return ""
+	return 0
 }
 
-type SnapshotReceivedEvent struct {
-	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
-	From          string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+type RestartMemberResponse struct {
 }
 
-func (m *SnapshotReceivedEvent) Reset()         { *m = SnapshotReceivedEvent{} }
-func (m *SnapshotReceivedEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotReceivedEvent) ProtoMessage()    {}
-func (*SnapshotReceivedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{12}
+func (m *RestartMemberResponse) Reset()         { *m = RestartMemberResponse{} }
+func (m *RestartMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*RestartMemberResponse) ProtoMessage()    {}
+func (*RestartMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{16}
 }
-func (m *SnapshotReceivedEvent) XXX_Unmarshal(b []byte) error {
+func (m *RestartMemberResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotReceivedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RestartMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotReceivedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RestartMemberResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -825,41 +849,37 @@ func (m *SnapshotReceivedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *SnapshotReceivedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotReceivedEvent.Merge(m, src)
+func (m *RestartMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartMemberResponse.Merge(m, src)
 }
-func (m *SnapshotReceivedEvent) XXX_Size() int {
+func (m *RestartMemberResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotReceivedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotReceivedEvent.DiscardUnknown(m)
+func (m *RestartMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartMemberResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotReceivedEvent proto.InternalMessageInfo
-
-func (m *SnapshotReceivedEvent) GetFrom() string {
-	if m != nil {
-		return m.From
-	}
-	return ""
-}
+var xxx_messageInfo_RestartMemberResponse proto.InternalMessageInfo
 
-type SnapshotRecoveredEvent struct {
-	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
+type StartMemberRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	NodeID    uint64 `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Join      bool   `protobuf:"varint,3,opt,name=join,proto3" json:"join,omitempty"`
+	Learner   bool   `protobuf:"varint,4,opt,name=learner,proto3" json:"learner,omitempty"`
 }
 
-func (m *SnapshotRecoveredEvent) Reset()         { *m = SnapshotRecoveredEvent{} }
-func (m *SnapshotRecoveredEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotRecoveredEvent) ProtoMessage()    {}
-func (*SnapshotRecoveredEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{13}
+func (m *StartMemberRequest) Reset()         { *m = StartMemberRequest{} }
+func (m *StartMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*StartMemberRequest) ProtoMessage()    {}
+func (*StartMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{17}
 }
-func (m *SnapshotRecoveredEvent) XXX_Unmarshal(b []byte) error {
+func (m *StartMemberRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotRecoveredEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StartMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotRecoveredEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StartMemberRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -869,71 +889,61 @@ func (m *SnapshotRecoveredEvent) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *SnapshotRecoveredEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotRecoveredEvent.Merge(m, src)
+func (m *StartMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartMemberRequest.Merge(m, src)
 }
-func (m *SnapshotRecoveredEvent) XXX_Size() int {
+func (m *StartMemberRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotRecoveredEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotRecoveredEvent.DiscardUnknown(m)
+func (m *StartMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartMemberRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotRecoveredEvent proto.InternalMessageInfo
+var xxx_messageInfo_StartMemberRequest proto.InternalMessageInfo
 
-type SnapshotCreatedEvent struct {
-	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
+func (m *StartMemberRequest) GetPartition() uint64 {
+	if m != nil {
+		return m.Partition
+	}
+	return 0
 }
 
-func (m *SnapshotCreatedEvent) Reset()         { *m = SnapshotCreatedEvent{} }
-func (m *SnapshotCreatedEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotCreatedEvent) ProtoMessage()    {}
-func (*SnapshotCreatedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{14}
-}
-func (m *SnapshotCreatedEvent) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *SnapshotCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_SnapshotCreatedEvent.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *StartMemberRequest) GetNodeID() uint64 {
+	if m != nil {
+		return m.NodeID
 	}
+	return 0
 }
-func (m *SnapshotCreatedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotCreatedEvent.Merge(m, src)
-}
-func (m *SnapshotCreatedEvent) XXX_Size() int {
-	return m.Size()
-}
-func (m *SnapshotCreatedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotCreatedEvent.DiscardUnknown(m)
+
+func (m *StartMemberRequest) GetJoin() bool {
+	if m != nil {
+		return m.Join
+	}
+	return false
 }
 
-var xxx_messageInfo_SnapshotCreatedEvent proto.InternalMessageInfo
+func (m *StartMemberRequest) GetLearner() bool {
+	if m != nil {
+		return m.Learner
+	}
+	return false
+}
 
-type SnapshotCompactedEvent struct {
-	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
+type StartMemberResponse struct {
 }
 
-func (m *SnapshotCompactedEvent) Reset()         { *m = SnapshotCompactedEvent{} }
-func (m *SnapshotCompactedEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotCompactedEvent) ProtoMessage()    {}
-func (*SnapshotCompactedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{15}
+func (m *StartMemberResponse) Reset()         { *m = StartMemberResponse{} }
+func (m *StartMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*StartMemberResponse) ProtoMessage()    {}
+func (*StartMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{18}
 }
-func (m *SnapshotCompactedEvent) XXX_Unmarshal(b []byte) error {
+func (m *StartMemberResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StartMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotCompactedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StartMemberResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -943,35 +953,34 @@ func (m *SnapshotCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *SnapshotCompactedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotCompactedEvent.Merge(m, src)
+func (m *StartMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartMemberResponse.Merge(m, src)
 }
-func (m *SnapshotCompactedEvent) XXX_Size() int {
+func (m *StartMemberResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotCompactedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotCompactedEvent.DiscardUnknown(m)
+func (m *StartMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartMemberResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotCompactedEvent proto.InternalMessageInfo
+var xxx_messageInfo_StartMemberResponse proto.InternalMessageInfo
 
-type LogEvent struct {
-	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
-	Index          uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+type StopMemberRequest struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
 }
 
-func (m *LogEvent) Reset()         { *m = LogEvent{} }
-func (m *LogEvent) String() string { return proto.CompactTextString(m) }
-func (*LogEvent) ProtoMessage()    {}
-func (*LogEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{16}
+func (m *StopMemberRequest) Reset()         { *m = StopMemberRequest{} }
+func (m *StopMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*StopMemberRequest) ProtoMessage()    {}
+func (*StopMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{19}
 }
-func (m *LogEvent) XXX_Unmarshal(b []byte) error {
+func (m *StopMemberRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LogEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StopMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LogEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StopMemberRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -981,41 +990,40 @@ func (m *LogEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *LogEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogEvent.Merge(m, src)
+func (m *StopMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopMemberRequest.Merge(m, src)
 }
-func (m *LogEvent) XXX_Size() int {
+func (m *StopMemberRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LogEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogEvent.DiscardUnknown(m)
+func (m *StopMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopMemberRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogEvent proto.InternalMessageInfo
+var xxx_messageInfo_StopMemberRequest proto.InternalMessageInfo
 
-func (m *LogEvent) GetIndex() uint64 {
+func (m *StopMemberRequest) GetPartition() uint64 {
 	if m != nil {
-		return m.Index
+		return m.Partition
 	}
 	return 0
 }
 
-type LogCompactedEvent struct {
-	LogEvent `protobuf:"bytes,1,opt,name=log,proto3,embedded=log" json:"log"`
+type StopMemberResponse struct {
 }
 
-func (m *LogCompactedEvent) Reset()         { *m = LogCompactedEvent{} }
-func (m *LogCompactedEvent) String() string { return proto.CompactTextString(m) }
-func (*LogCompactedEvent) ProtoMessage()    {}
-func (*LogCompactedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{17}
+func (m *StopMemberResponse) Reset()         { *m = StopMemberResponse{} }
+func (m *StopMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*StopMemberResponse) ProtoMessage()    {}
+func (*StopMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{20}
 }
-func (m *LogCompactedEvent) XXX_Unmarshal(b []byte) error {
+func (m *StopMemberResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LogCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StopMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LogCompactedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StopMemberResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1025,34 +1033,33 @@ func (m *LogCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *LogCompactedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogCompactedEvent.Merge(m, src)
+func (m *StopMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopMemberResponse.Merge(m, src)
 }
-func (m *LogCompactedEvent) XXX_Size() int {
+func (m *StopMemberResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *LogCompactedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogCompactedEvent.DiscardUnknown(m)
+func (m *StopMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopMemberResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogCompactedEvent proto.InternalMessageInfo
+var xxx_messageInfo_StopMemberResponse proto.InternalMessageInfo
 
-type LogDBCompactedEvent struct {
-	LogEvent `protobuf:"bytes,1,opt,name=log,proto3,embedded=log" json:"log"`
+type SubscribeRequest struct {
 }
 
-func (m *LogDBCompactedEvent) Reset()         { *m = LogDBCompactedEvent{} }
-func (m *LogDBCompactedEvent) String() string { return proto.CompactTextString(m) }
-func (*LogDBCompactedEvent) ProtoMessage()    {}
-func (*LogDBCompactedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{18}
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{21}
 }
-func (m *LogDBCompactedEvent) XXX_Unmarshal(b []byte) error {
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LogDBCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LogDBCompactedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1062,35 +1069,52 @@ func (m *LogDBCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LogDBCompactedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogDBCompactedEvent.Merge(m, src)
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
 }
-func (m *LogDBCompactedEvent) XXX_Size() int {
+func (m *SubscribeRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LogDBCompactedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogDBCompactedEvent.DiscardUnknown(m)
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogDBCompactedEvent proto.InternalMessageInfo
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
 
-type ConnectionEvent struct {
-	Address  string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Snapshot bool   `protobuf:"varint,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+type RaftEvent struct {
+	Timestamp time.Time `protobuf:"bytes,1,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	// Types that are valid to be assigned to Event:
+	//	*RaftEvent_MemberReady
+	//	*RaftEvent_LeaderUpdated
+	//	*RaftEvent_MembershipChanged
+	//	*RaftEvent_SendSnapshotStarted
+	//	*RaftEvent_SendSnapshotCompleted
+	//	*RaftEvent_SendSnapshotAborted
+	//	*RaftEvent_SnapshotReceived
+	//	*RaftEvent_SnapshotRecovered
+	//	*RaftEvent_SnapshotCreated
+	//	*RaftEvent_SnapshotCompacted
+	//	*RaftEvent_LogCompacted
+	//	*RaftEvent_LogdbCompacted
+	//	*RaftEvent_ConnectionEstablished
+	//	*RaftEvent_ConnectionFailed
+	//	*RaftEvent_MemberStats
+	//	*RaftEvent_DiskUsage
+	Event isRaftEvent_Event `protobuf_oneof:"event"`
 }
 
-func (m *ConnectionEvent) Reset()         { *m = ConnectionEvent{} }
-func (m *ConnectionEvent) String() string { return proto.CompactTextString(m) }
-func (*ConnectionEvent) ProtoMessage()    {}
-func (*ConnectionEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{19}
+func (m *RaftEvent) Reset()         { *m = RaftEvent{} }
+func (m *RaftEvent) String() string { return proto.CompactTextString(m) }
+func (*RaftEvent) ProtoMessage()    {}
+func (*RaftEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{22}
 }
-func (m *ConnectionEvent) XXX_Unmarshal(b []byte) error {
+func (m *RaftEvent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ConnectionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RaftEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ConnectionEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RaftEvent.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1100,1853 +1124,6069 @@ func (m *ConnectionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *ConnectionEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectionEvent.Merge(m, src)
+func (m *RaftEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftEvent.Merge(m, src)
 }
-func (m *ConnectionEvent) XXX_Size() int {
+func (m *RaftEvent) XXX_Size() int {
 	return m.Size()
 }
-func (m *ConnectionEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectionEvent.DiscardUnknown(m)
+func (m *RaftEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnectionEvent proto.InternalMessageInfo
+var xxx_messageInfo_RaftEvent proto.InternalMessageInfo
 
-func (m *ConnectionEvent) GetAddress() string {
-	if m != nil {
-		return m.Address
-	}
-	return ""
+type isRaftEvent_Event interface {
+	isRaftEvent_Event()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func (m *ConnectionEvent) GetSnapshot() bool {
-	if m != nil {
-		return m.Snapshot
-	}
-	return false
+type RaftEvent_MemberReady struct {
+	MemberReady *MemberReadyEvent `protobuf:"bytes,2,opt,name=member_ready,json=memberReady,proto3,oneof" json:"member_ready,omitempty"`
 }
-
-type ConnectionEstablishedEvent struct {
-	ConnectionEvent `protobuf:"bytes,1,opt,name=connection,proto3,embedded=connection" json:"connection"`
+type RaftEvent_LeaderUpdated struct {
+	LeaderUpdated *LeaderUpdatedEvent `protobuf:"bytes,3,opt,name=leader_updated,json=leaderUpdated,proto3,oneof" json:"leader_updated,omitempty"`
 }
-
-func (m *ConnectionEstablishedEvent) Reset()         { *m = ConnectionEstablishedEvent{} }
-func (m *ConnectionEstablishedEvent) String() string { return proto.CompactTextString(m) }
-func (*ConnectionEstablishedEvent) ProtoMessage()    {}
-func (*ConnectionEstablishedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{20}
+type RaftEvent_MembershipChanged struct {
+	MembershipChanged *MembershipChangedEvent `protobuf:"bytes,4,opt,name=membership_changed,json=membershipChanged,proto3,oneof" json:"membership_changed,omitempty"`
 }
-func (m *ConnectionEstablishedEvent) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+type RaftEvent_SendSnapshotStarted struct {
+	SendSnapshotStarted *SendSnapshotStartedEvent `protobuf:"bytes,5,opt,name=send_snapshot_started,json=sendSnapshotStarted,proto3,oneof" json:"send_snapshot_started,omitempty"`
 }
-func (m *ConnectionEstablishedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ConnectionEstablishedEvent.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
+type RaftEvent_SendSnapshotCompleted struct {
+	SendSnapshotCompleted *SendSnapshotCompletedEvent `protobuf:"bytes,6,opt,name=send_snapshot_completed,json=sendSnapshotCompleted,proto3,oneof" json:"send_snapshot_completed,omitempty"`
 }
-func (m *ConnectionEstablishedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectionEstablishedEvent.Merge(m, src)
+type RaftEvent_SendSnapshotAborted struct {
+	SendSnapshotAborted *SendSnapshotAbortedEvent `protobuf:"bytes,7,opt,name=send_snapshot_aborted,json=sendSnapshotAborted,proto3,oneof" json:"send_snapshot_aborted,omitempty"`
 }
-func (m *ConnectionEstablishedEvent) XXX_Size() int {
-	return m.Size()
+type RaftEvent_SnapshotReceived struct {
+	SnapshotReceived *SnapshotReceivedEvent `protobuf:"bytes,8,opt,name=snapshot_received,json=snapshotReceived,proto3,oneof" json:"snapshot_received,omitempty"`
 }
-func (m *ConnectionEstablishedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectionEstablishedEvent.DiscardUnknown(m)
+type RaftEvent_SnapshotRecovered struct {
+	SnapshotRecovered *SnapshotRecoveredEvent `protobuf:"bytes,9,opt,name=snapshot_recovered,json=snapshotRecovered,proto3,oneof" json:"snapshot_recovered,omitempty"`
 }
-
-var xxx_messageInfo_ConnectionEstablishedEvent proto.InternalMessageInfo
-
-type ConnectionFailedEvent struct {
-	ConnectionEvent `protobuf:"bytes,1,opt,name=connection,proto3,embedded=connection" json:"connection"`
+type RaftEvent_SnapshotCreated struct {
+	SnapshotCreated *SnapshotCreatedEvent `protobuf:"bytes,10,opt,name=snapshot_created,json=snapshotCreated,proto3,oneof" json:"snapshot_created,omitempty"`
 }
-
-func (m *ConnectionFailedEvent) Reset()         { *m = ConnectionFailedEvent{} }
-func (m *ConnectionFailedEvent) String() string { return proto.CompactTextString(m) }
-func (*ConnectionFailedEvent) ProtoMessage()    {}
-func (*ConnectionFailedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ddcb70539c8e6f6, []int{21}
+type RaftEvent_SnapshotCompacted struct {
+	SnapshotCompacted *SnapshotCompactedEvent `protobuf:"bytes,11,opt,name=snapshot_compacted,json=snapshotCompacted,proto3,oneof" json:"snapshot_compacted,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+type RaftEvent_LogCompacted struct {
+	LogCompacted *LogCompactedEvent `protobuf:"bytes,12,opt,name=log_compacted,json=logCompacted,proto3,oneof" json:"log_compacted,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ConnectionFailedEvent.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
+type RaftEvent_LogdbCompacted struct {
+	LogdbCompacted *LogDBCompactedEvent `protobuf:"bytes,13,opt,name=logdb_compacted,json=logdbCompacted,proto3,oneof" json:"logdb_compacted,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectionFailedEvent.Merge(m, src)
+type RaftEvent_ConnectionEstablished struct {
+	ConnectionEstablished *ConnectionEstablishedEvent `protobuf:"bytes,14,opt,name=connection_established,json=connectionEstablished,proto3,oneof" json:"connection_established,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_Size() int {
-	return m.Size()
+type RaftEvent_ConnectionFailed struct {
+	ConnectionFailed *ConnectionFailedEvent `protobuf:"bytes,15,opt,name=connection_failed,json=connectionFailed,proto3,oneof" json:"connection_failed,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectionFailedEvent.DiscardUnknown(m)
+type RaftEvent_MemberStats struct {
+	MemberStats *MemberStatsEvent `protobuf:"bytes,16,opt,name=member_stats,json=memberStats,proto3,oneof" json:"member_stats,omitempty"`
 }
-
-var xxx_messageInfo_ConnectionFailedEvent proto.InternalMessageInfo
-
-func init() {
-	proto.RegisterEnum("atomix.raft.EventType", EventType_name, EventType_value)
-	proto.RegisterType((*Entry)(nil), "atomix.raft.Entry")
-	proto.RegisterType((*SubscribeRequest)(nil), "atomix.raft.SubscribeRequest")
-	proto.RegisterType((*RaftEvent)(nil), "atomix.raft.RaftEvent")
-	proto.RegisterType((*PartitionEvent)(nil), "atomix.raft.PartitionEvent")
-	proto.RegisterType((*MemberReadyEvent)(nil), "atomix.raft.MemberReadyEvent")
-	proto.RegisterType((*MembershipChangedEvent)(nil), "atomix.raft.MembershipChangedEvent")
-	proto.RegisterType((*LeaderEvent)(nil), "atomix.raft.LeaderEvent")
-	proto.RegisterType((*LeaderUpdatedEvent)(nil), "atomix.raft.LeaderUpdatedEvent")
-	proto.RegisterType((*SnapshotEvent)(nil), "atomix.raft.SnapshotEvent")
-	proto.RegisterType((*SendSnapshotStartedEvent)(nil), "atomix.raft.SendSnapshotStartedEvent")
-	proto.RegisterType((*SendSnapshotCompletedEvent)(nil), "atomix.raft.SendSnapshotCompletedEvent")
-	proto.RegisterType((*SendSnapshotAbortedEvent)(nil), "atomix.raft.SendSnapshotAbortedEvent")
-	proto.RegisterType((*SnapshotReceivedEvent)(nil), "atomix.raft.SnapshotReceivedEvent")
-	proto.RegisterType((*SnapshotRecoveredEvent)(nil), "atomix.raft.SnapshotRecoveredEvent")
-	proto.RegisterType((*SnapshotCreatedEvent)(nil), "atomix.raft.SnapshotCreatedEvent")
-	proto.RegisterType((*SnapshotCompactedEvent)(nil), "atomix.raft.SnapshotCompactedEvent")
-	proto.RegisterType((*LogEvent)(nil), "atomix.raft.LogEvent")
-	proto.RegisterType((*LogCompactedEvent)(nil), "atomix.raft.LogCompactedEvent")
-	proto.RegisterType((*LogDBCompactedEvent)(nil), "atomix.raft.LogDBCompactedEvent")
-	proto.RegisterType((*ConnectionEvent)(nil), "atomix.raft.ConnectionEvent")
-	proto.RegisterType((*ConnectionEstablishedEvent)(nil), "atomix.raft.ConnectionEstablishedEvent")
-	proto.RegisterType((*ConnectionFailedEvent)(nil), "atomix.raft.ConnectionFailedEvent")
+type RaftEvent_DiskUsage struct {
+	DiskUsage *DiskUsageEvent `protobuf:"bytes,17,opt,name=disk_usage,json=diskUsage,proto3,oneof" json:"disk_usage,omitempty"`
 }
 
-func init() { proto.RegisterFile("storage/protocol.proto", fileDescriptor_5ddcb70539c8e6f6) }
+func (*RaftEvent_MemberReady) isRaftEvent_Event()           {}
+func (*RaftEvent_LeaderUpdated) isRaftEvent_Event()         {}
+func (*RaftEvent_MembershipChanged) isRaftEvent_Event()     {}
+func (*RaftEvent_SendSnapshotStarted) isRaftEvent_Event()   {}
+func (*RaftEvent_SendSnapshotCompleted) isRaftEvent_Event() {}
+func (*RaftEvent_SendSnapshotAborted) isRaftEvent_Event()   {}
+func (*RaftEvent_SnapshotReceived) isRaftEvent_Event()      {}
+func (*RaftEvent_SnapshotRecovered) isRaftEvent_Event()     {}
+func (*RaftEvent_SnapshotCreated) isRaftEvent_Event()       {}
+func (*RaftEvent_SnapshotCompacted) isRaftEvent_Event()     {}
+func (*RaftEvent_LogCompacted) isRaftEvent_Event()          {}
+func (*RaftEvent_LogdbCompacted) isRaftEvent_Event()        {}
+func (*RaftEvent_ConnectionEstablished) isRaftEvent_Event() {}
+func (*RaftEvent_ConnectionFailed) isRaftEvent_Event()      {}
+func (*RaftEvent_MemberStats) isRaftEvent_Event()           {}
+func (*RaftEvent_DiskUsage) isRaftEvent_Event()             {}
 
-var fileDescriptor_5ddcb70539c8e6f6 = []byte{
-	// 1081 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x97, 0xcd, 0x72, 0xe3, 0x44,
-	0x10, 0xc7, 0xad, 0xc4, 0x4e, 0xac, 0x76, 0x3e, 0x9c, 0x49, 0x6c, 0x54, 0x66, 0xb1, 0x83, 0x28,
-	0x8a, 0x2d, 0x0e, 0x0e, 0x84, 0xe2, 0xc2, 0x89, 0x58, 0x56, 0x92, 0xad, 0xcd, 0xda, 0x41, 0xf6,
-	0xee, 0x52, 0x50, 0x94, 0x91, 0xa5, 0xb1, 0x22, 0x4a, 0xd2, 0x18, 0x8d, 0x9c, 0xda, 0x5c, 0x78,
-	0x86, 0x7d, 0x01, 0xde, 0x67, 0x8f, 0x39, 0x72, 0x0a, 0x54, 0xf2, 0x04, 0x5c, 0x39, 0x51, 0x1a,
-	0x7d, 0x58, 0x92, 0x65, 0xa8, 0x02, 0xe7, 0x36, 0xea, 0xfe, 0xcf, 0xaf, 0x7b, 0x34, 0x33, 0xdd,
-	0x35, 0x50, 0xa7, 0x1e, 0x71, 0x55, 0x03, 0x1f, 0x4d, 0x5d, 0xe2, 0x11, 0x8d, 0x58, 0x6d, 0x36,
-	0x40, 0x15, 0xd5, 0x23, 0xb6, 0xf9, 0xa6, 0xed, 0xaa, 0x13, 0xaf, 0xd1, 0x32, 0x08, 0x31, 0xac,
-	0x50, 0x33, 0x9e, 0x4d, 0x8e, 0x3c, 0xd3, 0xc6, 0xd4, 0x53, 0xed, 0x69, 0xa0, 0x6e, 0x1c, 0x18,
-	0xc4, 0x20, 0x6c, 0x78, 0xe4, 0x8f, 0x02, 0xab, 0x38, 0x84, 0x92, 0xec, 0x78, 0xee, 0x0d, 0x3a,
-	0x80, 0xd2, 0xb5, 0x6a, 0xcd, 0xb0, 0xc0, 0x1d, 0x72, 0x4f, 0xb7, 0x94, 0xe0, 0x03, 0x7d, 0x09,
-	0x3c, 0xf5, 0x5c, 0xac, 0xda, 0x23, 0x53, 0x17, 0xd6, 0x0e, 0xb9, 0xa7, 0xc5, 0x8e, 0x70, 0x7f,
-	0xd7, 0x2a, 0x0f, 0x98, 0xf1, 0x59, 0xf7, 0xaf, 0xbb, 0x56, 0x99, 0x86, 0x63, 0x25, 0x1a, 0xe9,
-	0x22, 0x82, 0xea, 0x60, 0x36, 0xa6, 0x9a, 0x6b, 0x8e, 0xb1, 0x82, 0x7f, 0x9e, 0x61, 0xea, 0x89,
-	0x7f, 0xf2, 0xc0, 0x2b, 0xea, 0xc4, 0x93, 0xaf, 0xb1, 0xe3, 0xa1, 0x0e, 0xf0, 0x71, 0x82, 0x2c,
-	0x64, 0xe5, 0xb8, 0xd1, 0x0e, 0x96, 0xd0, 0x8e, 0x96, 0xd0, 0x1e, 0x46, 0x8a, 0x4e, 0xf9, 0xdd,
-	0x5d, 0xab, 0xf0, 0xf6, 0xf7, 0x16, 0xa7, 0xcc, 0xa7, 0xa1, 0x0e, 0x6c, 0xd9, 0xd8, 0x1e, 0x63,
-	0x77, 0xe4, 0x62, 0x55, 0xbf, 0x61, 0xf9, 0x55, 0x8e, 0x3f, 0x68, 0x27, 0x7e, 0x4b, 0xfb, 0x05,
-	0x13, 0x28, 0xbe, 0x9f, 0x05, 0x3e, 0x2f, 0x28, 0x15, 0x7b, 0x6e, 0x43, 0xe7, 0xb0, 0x63, 0x61,
-	0x55, 0xc7, 0xee, 0x68, 0x36, 0xd5, 0x55, 0x0f, 0xeb, 0xc2, 0x3a, 0xa3, 0xb4, 0x52, 0x94, 0x0b,
-	0x26, 0x79, 0x19, 0x28, 0x22, 0xce, 0xb6, 0x95, 0xb4, 0xa2, 0x21, 0xa0, 0x00, 0x4c, 0xaf, 0xcc,
-	0xe9, 0x48, 0xbb, 0x52, 0x1d, 0x03, 0xeb, 0x42, 0x91, 0xd1, 0x3e, 0xca, 0xc9, 0xc9, 0x97, 0x49,
-	0x81, 0x2a, 0x22, 0xee, 0xd9, 0x59, 0x0f, 0xfa, 0x1e, 0x6a, 0x14, 0x3b, 0xfa, 0x88, 0x3a, 0xea,
-	0x94, 0x5e, 0x11, 0x6f, 0x44, 0x3d, 0xd5, 0xf5, 0xd3, 0x2c, 0x31, 0xf0, 0xc7, 0x29, 0xf0, 0x00,
-	0x3b, 0xfa, 0x20, 0x14, 0x0e, 0x02, 0x5d, 0x84, 0xde, 0xa7, 0x8b, 0x3e, 0xa4, 0xc2, 0x7b, 0x69,
-	0xb8, 0x46, 0xec, 0xa9, 0x85, 0x7d, 0xfc, 0x06, 0xc3, 0x7f, 0xb2, 0x14, 0x2f, 0x45, 0xca, 0x28,
-	0x40, 0x8d, 0xe6, 0x79, 0x17, 0xf3, 0x57, 0xc7, 0x84, 0xe5, 0xbf, 0xf9, 0x2f, 0xf9, 0x9f, 0x04,
-	0xba, 0xdc, 0xfc, 0x43, 0x1f, 0xfa, 0x06, 0xf6, 0x62, 0xae, 0x8b, 0x35, 0x6c, 0x5e, 0x63, 0x5d,
-	0x28, 0x33, 0xb0, 0x98, 0x06, 0x87, 0x2a, 0x25, 0x14, 0x45, 0xd4, 0x2a, 0xcd, 0x38, 0xfc, 0x5d,
-	0x4c, 0x22, 0xc9, 0x35, 0x76, 0xb1, 0x2e, 0xf0, 0x39, 0xbb, 0x98, 0x60, 0x06, 0xaa, 0x78, 0x17,
-	0x69, 0xd6, 0x83, 0x7a, 0x50, 0x9d, 0xff, 0x63, 0x17, 0xb3, 0x73, 0x06, 0x8c, 0xf9, 0x61, 0x2e,
-	0x53, 0x0a, 0x34, 0x11, 0x71, 0x97, 0xa6, 0xed, 0xa9, 0x2c, 0xfd, 0x3d, 0x53, 0x35, 0x9f, 0x58,
-	0xf9, 0x87, 0x2c, 0xa5, 0x48, 0xb5, 0x90, 0x65, 0xec, 0x41, 0x32, 0x6c, 0x5b, 0xc4, 0x48, 0x00,
-	0xb7, 0x18, 0xb0, 0x99, 0xbe, 0x0a, 0xc4, 0x58, 0x60, 0x6d, 0x59, 0x09, 0x23, 0x7a, 0x0e, 0xbb,
-	0x16, 0x31, 0xf4, 0x71, 0x02, 0xb4, 0xcd, 0x40, 0x87, 0x59, 0x50, 0xb7, 0xb3, 0x80, 0xda, 0x61,
-	0x53, 0xe7, 0xb0, 0x1f, 0xa1, 0xae, 0x11, 0xc7, 0xc1, 0x9a, 0x67, 0x12, 0x67, 0xe4, 0x5f, 0xfc,
-	0xb1, 0x65, 0xd2, 0x2b, 0xac, 0x0b, 0x3b, 0x39, 0x27, 0x54, 0x8a, 0xa5, 0xf2, 0x5c, 0x19, 0x9f,
-	0x50, 0x2d, 0xcf, 0xeb, 0x1f, 0xa2, 0x44, 0x84, 0x89, 0x6a, 0x5a, 0x58, 0x17, 0x76, 0x73, 0x0e,
-	0xd1, 0x1c, 0x7e, 0xca, 0x44, 0xf1, 0x21, 0xd2, 0x32, 0x8e, 0xce, 0x26, 0x94, 0xb0, 0xef, 0x14,
-	0xdb, 0xb0, 0x73, 0xa9, 0xba, 0x9e, 0xc9, 0x62, 0xb2, 0xba, 0xf7, 0x04, 0xf8, 0x69, 0x64, 0x61,
-	0x75, 0xaf, 0xa8, 0xcc, 0x0d, 0xe2, 0x6b, 0xa8, 0x66, 0x0b, 0x16, 0x92, 0xb2, 0x33, 0x2a, 0xc7,
-	0xef, 0xa7, 0xf2, 0x4a, 0x47, 0x08, 0x4a, 0xe5, 0xed, 0x9d, 0x5f, 0x2a, 0xe7, 0xe0, 0x1f, 0xa0,
-	0x9e, 0x5f, 0x75, 0x56, 0x83, 0xff, 0x05, 0x2a, 0x41, 0x89, 0x5c, 0x1d, 0x13, 0x21, 0x28, 0x7a,
-	0xd8, 0xb5, 0x83, 0xae, 0xa3, 0xb0, 0x31, 0xaa, 0xc3, 0x46, 0x50, 0x74, 0x59, 0x95, 0xe6, 0x95,
-	0xf0, 0x4b, 0xbc, 0x04, 0xb4, 0x58, 0xa2, 0xd1, 0x57, 0xb1, 0x3a, 0xc8, 0x41, 0xc8, 0xa9, 0xe9,
-	0xd9, 0x04, 0x22, 0xe2, 0x4f, 0xb0, 0x1d, 0x5d, 0x9d, 0x15, 0xae, 0xe9, 0x00, 0x4a, 0xa6, 0xa3,
-	0xe3, 0x37, 0xe1, 0xa2, 0x82, 0x0f, 0xd1, 0x02, 0x61, 0x59, 0xe5, 0x46, 0x5f, 0x43, 0x39, 0xba,
-	0xa8, 0x71, 0x9b, 0xcc, 0xbb, 0xdf, 0xd9, 0xa0, 0xf1, 0x2c, 0xb4, 0x03, 0x6b, 0x1e, 0x61, 0x01,
-	0x79, 0x65, 0xcd, 0x23, 0xa2, 0x03, 0x8d, 0xe5, 0x85, 0xfc, 0x11, 0xe2, 0x65, 0x56, 0x97, 0xac,
-	0xeb, 0x8f, 0x10, 0xcd, 0x86, 0x5a, 0x6e, 0xb1, 0x5f, 0x41, 0x28, 0x04, 0xc5, 0x89, 0x4b, 0xec,
-	0x30, 0x18, 0x1b, 0x8b, 0xdf, 0x41, 0x3d, 0xbf, 0x0f, 0xfc, 0xff, 0x78, 0xe2, 0xb7, 0x70, 0x90,
-	0xd7, 0x0f, 0x56, 0x40, 0x4e, 0x64, 0x9d, 0x2e, 0xc0, 0x2b, 0x60, 0x63, 0x28, 0x5f, 0x10, 0xe3,
-	0xd1, 0xef, 0xcc, 0x29, 0xec, 0x2d, 0x74, 0x22, 0xf4, 0x39, 0xac, 0x5b, 0xc4, 0x08, 0x23, 0xd5,
-	0xb2, 0xdd, 0x26, 0x1b, 0xc3, 0xd7, 0x8a, 0xe7, 0xb0, 0x9f, 0xd3, 0x88, 0xfe, 0x0b, 0xe9, 0x0c,
-	0x76, 0x13, 0xed, 0x87, 0x51, 0x04, 0xd8, 0x54, 0x75, 0xdd, 0xc5, 0x94, 0x32, 0x12, 0xaf, 0x44,
-	0x9f, 0xa8, 0x91, 0xf8, 0xcf, 0xfe, 0xba, 0xca, 0x89, 0x3f, 0xa8, 0x43, 0x63, 0x79, 0x1f, 0x43,
-	0xa7, 0x00, 0xf3, 0x7e, 0x13, 0x26, 0xf8, 0x64, 0x59, 0x13, 0xcc, 0xe4, 0x99, 0x98, 0x29, 0x8e,
-	0xa0, 0x96, 0xdb, 0xd0, 0x56, 0x15, 0xe0, 0xd3, 0x5f, 0x39, 0xe0, 0x99, 0x7f, 0x78, 0x33, 0xc5,
-	0xa8, 0x02, 0x9b, 0x2f, 0x7b, 0xcf, 0x7b, 0xfd, 0xd7, 0xbd, 0x6a, 0x01, 0xd5, 0x60, 0x6f, 0xd0,
-	0x3b, 0xb9, 0x1c, 0x9c, 0xf7, 0x87, 0x23, 0x45, 0x96, 0xe4, 0x67, 0xaf, 0xe4, 0x6e, 0x95, 0x43,
-	0x75, 0x40, 0x49, 0x73, 0xff, 0x95, 0xac, 0xc8, 0xdd, 0xea, 0x1a, 0x3a, 0x80, 0x6a, 0x6c, 0x97,
-	0x14, 0xf9, 0x64, 0x28, 0x77, 0xab, 0xeb, 0x29, 0xb5, 0xd4, 0x7f, 0x71, 0x79, 0x22, 0xf9, 0xf6,
-	0x22, 0xda, 0x83, 0xed, 0x8b, 0xfe, 0x59, 0xc2, 0x54, 0x42, 0xfb, 0xb0, 0x7b, 0xd1, 0x3f, 0xeb,
-	0x76, 0x12, 0xc6, 0x8d, 0x63, 0x05, 0x20, 0x7e, 0x8e, 0x50, 0xd4, 0x05, 0x3e, 0x7e, 0xb1, 0xa0,
-	0xf4, 0x13, 0x22, 0xfb, 0x92, 0x69, 0xd4, 0x53, 0xee, 0x18, 0xf2, 0x19, 0xd7, 0x11, 0xde, 0xdd,
-	0x37, 0xb9, 0xdb, 0xfb, 0x26, 0xf7, 0xc7, 0x7d, 0x93, 0x7b, 0xfb, 0xd0, 0x2c, 0xdc, 0x3e, 0x34,
-	0x0b, 0xbf, 0x3d, 0x34, 0x0b, 0xe3, 0x0d, 0xf6, 0xa8, 0xf9, 0xe2, 0xef, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x7f, 0x98, 0x9d, 0xa0, 0xcc, 0x0d, 0x00, 0x00,
+func (m *RaftEvent) GetEvent() isRaftEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// RaftEventsClient is the client API for RaftEvents service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type RaftEventsClient interface {
-	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (RaftEvents_SubscribeClient, error)
+func (m *RaftEvent) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
 }
 
-type raftEventsClient struct {
-	cc *grpc.ClientConn
+func (m *RaftEvent) GetMemberReady() *MemberReadyEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_MemberReady); ok {
+		return x.MemberReady
+	}
+	return nil
 }
 
-func NewRaftEventsClient(cc *grpc.ClientConn) RaftEventsClient {
-	return &raftEventsClient{cc}
+func (m *RaftEvent) GetLeaderUpdated() *LeaderUpdatedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_LeaderUpdated); ok {
+		return x.LeaderUpdated
+	}
+	return nil
 }
 
-func (c *raftEventsClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (RaftEvents_SubscribeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_RaftEvents_serviceDesc.Streams[0], "/atomix.raft.RaftEvents/Subscribe", opts...)
-	if err != nil {
-		return nil, err
+func (m *RaftEvent) GetMembershipChanged() *MembershipChangedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_MembershipChanged); ok {
+		return x.MembershipChanged
 	}
-	x := &raftEventsSubscribeClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *RaftEvent) GetSendSnapshotStarted() *SendSnapshotStartedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_SendSnapshotStarted); ok {
+		return x.SendSnapshotStarted
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *RaftEvent) GetSendSnapshotCompleted() *SendSnapshotCompletedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_SendSnapshotCompleted); ok {
+		return x.SendSnapshotCompleted
 	}
-	return x, nil
+	return nil
 }
 
-type RaftEvents_SubscribeClient interface {
-	Recv() (*RaftEvent, error)
-	grpc.ClientStream
+func (m *RaftEvent) GetSendSnapshotAborted() *SendSnapshotAbortedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_SendSnapshotAborted); ok {
+		return x.SendSnapshotAborted
+	}
+	return nil
 }
 
-type raftEventsSubscribeClient struct {
-	grpc.ClientStream
+func (m *RaftEvent) GetSnapshotReceived() *SnapshotReceivedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_SnapshotReceived); ok {
+		return x.SnapshotReceived
+	}
+	return nil
 }
 
-func (x *raftEventsSubscribeClient) Recv() (*RaftEvent, error) {
-	m := new(RaftEvent)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *RaftEvent) GetSnapshotRecovered() *SnapshotRecoveredEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_SnapshotRecovered); ok {
+		return x.SnapshotRecovered
 	}
-	return m, nil
+	return nil
 }
 
-// RaftEventsServer is the server API for RaftEvents service.
-type RaftEventsServer interface {
-	Subscribe(*SubscribeRequest, RaftEvents_SubscribeServer) error
+func (m *RaftEvent) GetSnapshotCreated() *SnapshotCreatedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_SnapshotCreated); ok {
+		return x.SnapshotCreated
+	}
+	return nil
 }
 
-// UnimplementedRaftEventsServer can be embedded to have forward compatible implementations.
-type UnimplementedRaftEventsServer struct {
+func (m *RaftEvent) GetSnapshotCompacted() *SnapshotCompactedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_SnapshotCompacted); ok {
+		return x.SnapshotCompacted
+	}
+	return nil
 }
 
-func (*UnimplementedRaftEventsServer) Subscribe(req *SubscribeRequest, srv RaftEvents_SubscribeServer) error {
-	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+func (m *RaftEvent) GetLogCompacted() *LogCompactedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_LogCompacted); ok {
+		return x.LogCompacted
+	}
+	return nil
 }
 
-func RegisterRaftEventsServer(s *grpc.Server, srv RaftEventsServer) {
-	s.RegisterService(&_RaftEvents_serviceDesc, srv)
+func (m *RaftEvent) GetLogdbCompacted() *LogDBCompactedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_LogdbCompacted); ok {
+		return x.LogdbCompacted
+	}
+	return nil
 }
 
-func _RaftEvents_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SubscribeRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *RaftEvent) GetConnectionEstablished() *ConnectionEstablishedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_ConnectionEstablished); ok {
+		return x.ConnectionEstablished
 	}
-	return srv.(RaftEventsServer).Subscribe(m, &raftEventsSubscribeServer{stream})
+	return nil
 }
 
-type RaftEvents_SubscribeServer interface {
-	Send(*RaftEvent) error
-	grpc.ServerStream
+func (m *RaftEvent) GetConnectionFailed() *ConnectionFailedEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_ConnectionFailed); ok {
+		return x.ConnectionFailed
+	}
+	return nil
 }
 
-type raftEventsSubscribeServer struct {
-	grpc.ServerStream
+func (m *RaftEvent) GetMemberStats() *MemberStatsEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_MemberStats); ok {
+		return x.MemberStats
+	}
+	return nil
 }
 
-func (x *raftEventsSubscribeServer) Send(m *RaftEvent) error {
-	return x.ServerStream.SendMsg(m)
+func (m *RaftEvent) GetDiskUsage() *DiskUsageEvent {
+	if x, ok := m.GetEvent().(*RaftEvent_DiskUsage); ok {
+		return x.DiskUsage
+	}
+	return nil
 }
 
-var _RaftEvents_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "atomix.raft.RaftEvents",
-	HandlerType: (*RaftEventsServer)(nil),
-	Methods:     []grpc.MethodDesc{},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "Subscribe",
-			Handler:       _RaftEvents_Subscribe_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "storage/protocol.proto",
-}
-
-func (m *Entry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RaftEvent) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RaftEvent_MemberReady)(nil),
+		(*RaftEvent_LeaderUpdated)(nil),
+		(*RaftEvent_MembershipChanged)(nil),
+		(*RaftEvent_SendSnapshotStarted)(nil),
+		(*RaftEvent_SendSnapshotCompleted)(nil),
+		(*RaftEvent_SendSnapshotAborted)(nil),
+		(*RaftEvent_SnapshotReceived)(nil),
+		(*RaftEvent_SnapshotRecovered)(nil),
+		(*RaftEvent_SnapshotCreated)(nil),
+		(*RaftEvent_SnapshotCompacted)(nil),
+		(*RaftEvent_LogCompacted)(nil),
+		(*RaftEvent_LogdbCompacted)(nil),
+		(*RaftEvent_ConnectionEstablished)(nil),
+		(*RaftEvent_ConnectionFailed)(nil),
+		(*RaftEvent_MemberStats)(nil),
+		(*RaftEvent_DiskUsage)(nil),
 	}
-	return dAtA[:n], nil
 }
 
-func (m *Entry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type PartitionEvent struct {
+	Partition uint64 `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
 }
 
-func (m *Entry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.StreamID != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.StreamID))
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *PartitionEvent) Reset()         { *m = PartitionEvent{} }
+func (m *PartitionEvent) String() string { return proto.CompactTextString(m) }
+func (*PartitionEvent) ProtoMessage()    {}
+func (*PartitionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{23}
 }
-
-func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PartitionEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PartitionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PartitionEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PartitionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionEvent.Merge(m, src)
 }
-
-func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *PartitionEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *PartitionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionEvent.DiscardUnknown(m)
 }
 
-func (m *RaftEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PartitionEvent proto.InternalMessageInfo
+
+func (m *PartitionEvent) GetPartition() uint64 {
+	if m != nil {
+		return m.Partition
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *RaftEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type MemberReadyEvent struct {
+	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
 }
 
-func (m *RaftEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Event != nil {
-		{
-			size := m.Event.Size()
-			i -= size
-			if _, err := m.Event.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+func (m *MemberReadyEvent) Reset()         { *m = MemberReadyEvent{} }
+func (m *MemberReadyEvent) String() string { return proto.CompactTextString(m) }
+func (*MemberReadyEvent) ProtoMessage()    {}
+func (*MemberReadyEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{24}
+}
+func (m *MemberReadyEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberReadyEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MemberReadyEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err1 != nil {
-		return 0, err1
-	}
-	i -= n1
-	i = encodeVarintProtocol(dAtA, i, uint64(n1))
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *MemberReadyEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberReadyEvent.Merge(m, src)
+}
+func (m *MemberReadyEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberReadyEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberReadyEvent.DiscardUnknown(m)
 }
 
-func (m *RaftEvent_MemberReady) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_MemberReadyEvent proto.InternalMessageInfo
+
+type MembershipChangedEvent struct {
+	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
 }
 
-func (m *RaftEvent_MemberReady) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.MemberReady != nil {
-		{
-			size, err := m.MemberReady.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	return len(dAtA) - i, nil
+func (m *MembershipChangedEvent) Reset()         { *m = MembershipChangedEvent{} }
+func (m *MembershipChangedEvent) String() string { return proto.CompactTextString(m) }
+func (*MembershipChangedEvent) ProtoMessage()    {}
+func (*MembershipChangedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{25}
 }
-func (m *RaftEvent_LeaderUpdated) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *MembershipChangedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *RaftEvent_LeaderUpdated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.LeaderUpdated != nil {
-		{
-			size, err := m.LeaderUpdated.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *MembershipChangedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MembershipChangedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x1a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *RaftEvent_MembershipChanged) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *MembershipChangedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembershipChangedEvent.Merge(m, src)
 }
-
-func (m *RaftEvent_MembershipChanged) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.MembershipChanged != nil {
-		{
-			size, err := m.MembershipChanged.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	return len(dAtA) - i, nil
+func (m *MembershipChangedEvent) XXX_Size() int {
+	return m.Size()
 }
-func (m *RaftEvent_SendSnapshotStarted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *MembershipChangedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembershipChangedEvent.DiscardUnknown(m)
 }
 
-func (m *RaftEvent_SendSnapshotStarted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SendSnapshotStarted != nil {
-		{
-			size, err := m.SendSnapshotStarted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
-	}
-	return len(dAtA) - i, nil
-}
-func (m *RaftEvent_SendSnapshotCompleted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_MembershipChangedEvent proto.InternalMessageInfo
+
+type LeaderEvent struct {
+	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
+	Term           uint64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Leader         string `protobuf:"bytes,3,opt,name=leader,proto3" json:"leader,omitempty"`
 }
 
-func (m *RaftEvent_SendSnapshotCompleted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SendSnapshotCompleted != nil {
-		{
-			size, err := m.SendSnapshotCompleted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
-	}
-	return len(dAtA) - i, nil
+func (m *LeaderEvent) Reset()         { *m = LeaderEvent{} }
+func (m *LeaderEvent) String() string { return proto.CompactTextString(m) }
+func (*LeaderEvent) ProtoMessage()    {}
+func (*LeaderEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{26}
 }
-func (m *RaftEvent_SendSnapshotAborted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LeaderEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *RaftEvent_SendSnapshotAborted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SendSnapshotAborted != nil {
-		{
-			size, err := m.SendSnapshotAborted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *LeaderEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeaderEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x3a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *RaftEvent_SnapshotReceived) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LeaderEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderEvent.Merge(m, src)
 }
-
-func (m *RaftEvent_SnapshotReceived) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SnapshotReceived != nil {
-		{
-			size, err := m.SnapshotReceived.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x42
-	}
-	return len(dAtA) - i, nil
+func (m *LeaderEvent) XXX_Size() int {
+	return m.Size()
 }
-func (m *RaftEvent_SnapshotRecovered) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LeaderEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderEvent.DiscardUnknown(m)
 }
 
-func (m *RaftEvent_SnapshotRecovered) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SnapshotRecovered != nil {
-		{
-			size, err := m.SnapshotRecovered.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x4a
+var xxx_messageInfo_LeaderEvent proto.InternalMessageInfo
+
+func (m *LeaderEvent) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
 	}
-	return len(dAtA) - i, nil
-}
-func (m *RaftEvent_SnapshotCreated) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return 0
 }
 
-func (m *RaftEvent_SnapshotCreated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SnapshotCreated != nil {
-		{
-			size, err := m.SnapshotCreated.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x52
+func (m *LeaderEvent) GetLeader() string {
+	if m != nil {
+		return m.Leader
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
-func (m *RaftEvent_SnapshotCompacted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+type LeaderUpdatedEvent struct {
+	LeaderEvent `protobuf:"bytes,1,opt,name=leader,proto3,embedded=leader" json:"leader"`
 }
 
-func (m *RaftEvent_SnapshotCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SnapshotCompacted != nil {
-		{
-			size, err := m.SnapshotCompacted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *LeaderUpdatedEvent) Reset()         { *m = LeaderUpdatedEvent{} }
+func (m *LeaderUpdatedEvent) String() string { return proto.CompactTextString(m) }
+func (*LeaderUpdatedEvent) ProtoMessage()    {}
+func (*LeaderUpdatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{27}
+}
+func (m *LeaderUpdatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeaderUpdatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeaderUpdatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x5a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *RaftEvent_LogCompacted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LeaderUpdatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderUpdatedEvent.Merge(m, src)
+}
+func (m *LeaderUpdatedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeaderUpdatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderUpdatedEvent.DiscardUnknown(m)
 }
 
-func (m *RaftEvent_LogCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.LogCompacted != nil {
-		{
-			size, err := m.LogCompacted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+var xxx_messageInfo_LeaderUpdatedEvent proto.InternalMessageInfo
+
+type SnapshotEvent struct {
+	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
+	Index          uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (m *SnapshotEvent) Reset()         { *m = SnapshotEvent{} }
+func (m *SnapshotEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotEvent) ProtoMessage()    {}
+func (*SnapshotEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{28}
+}
+func (m *SnapshotEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x62
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *RaftEvent_LogdbCompacted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SnapshotEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotEvent.Merge(m, src)
+}
+func (m *SnapshotEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotEvent.DiscardUnknown(m)
 }
 
-func (m *RaftEvent_LogdbCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.LogdbCompacted != nil {
-		{
-			size, err := m.LogdbCompacted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x6a
+var xxx_messageInfo_SnapshotEvent proto.InternalMessageInfo
+
+func (m *SnapshotEvent) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
 	}
-	return len(dAtA) - i, nil
-}
-func (m *RaftEvent_ConnectionEstablished) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return 0
 }
 
-func (m *RaftEvent_ConnectionEstablished) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.ConnectionEstablished != nil {
-		{
-			size, err := m.ConnectionEstablished.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x72
-	}
-	return len(dAtA) - i, nil
-}
-func (m *RaftEvent_ConnectionFailed) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SendSnapshotStartedEvent struct {
+	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
+	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 }
 
-func (m *RaftEvent_ConnectionFailed) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.ConnectionFailed != nil {
-		{
-			size, err := m.ConnectionFailed.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *SendSnapshotStartedEvent) Reset()         { *m = SendSnapshotStartedEvent{} }
+func (m *SendSnapshotStartedEvent) String() string { return proto.CompactTextString(m) }
+func (*SendSnapshotStartedEvent) ProtoMessage()    {}
+func (*SendSnapshotStartedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{29}
+}
+func (m *SendSnapshotStartedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SendSnapshotStartedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SendSnapshotStartedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x7a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *PartitionEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *SendSnapshotStartedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendSnapshotStartedEvent.Merge(m, src)
 }
-
-func (m *PartitionEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SendSnapshotStartedEvent) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *PartitionEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Partition != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+func (m *SendSnapshotStartedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendSnapshotStartedEvent.DiscardUnknown(m)
 }
 
-func (m *MemberReadyEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SendSnapshotStartedEvent proto.InternalMessageInfo
+
+func (m *SendSnapshotStartedEvent) GetTo() string {
+	if m != nil {
+		return m.To
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *MemberReadyEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SendSnapshotCompletedEvent struct {
+	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
+	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 }
 
-func (m *MemberReadyEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *SendSnapshotCompletedEvent) Reset()         { *m = SendSnapshotCompletedEvent{} }
+func (m *SendSnapshotCompletedEvent) String() string { return proto.CompactTextString(m) }
+func (*SendSnapshotCompletedEvent) ProtoMessage()    {}
+func (*SendSnapshotCompletedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{30}
+}
+func (m *SendSnapshotCompletedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SendSnapshotCompletedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SendSnapshotCompletedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *SendSnapshotCompletedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendSnapshotCompletedEvent.Merge(m, src)
+}
+func (m *SendSnapshotCompletedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SendSnapshotCompletedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendSnapshotCompletedEvent.DiscardUnknown(m)
 }
 
-func (m *MembershipChangedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SendSnapshotCompletedEvent proto.InternalMessageInfo
+
+func (m *SendSnapshotCompletedEvent) GetTo() string {
+	if m != nil {
+		return m.To
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *MembershipChangedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SendSnapshotAbortedEvent struct {
+	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
+	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 }
 
-func (m *MembershipChangedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *SendSnapshotAbortedEvent) Reset()         { *m = SendSnapshotAbortedEvent{} }
+func (m *SendSnapshotAbortedEvent) String() string { return proto.CompactTextString(m) }
+func (*SendSnapshotAbortedEvent) ProtoMessage()    {}
+func (*SendSnapshotAbortedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{31}
+}
+func (m *SendSnapshotAbortedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SendSnapshotAbortedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SendSnapshotAbortedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *SendSnapshotAbortedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendSnapshotAbortedEvent.Merge(m, src)
+}
+func (m *SendSnapshotAbortedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SendSnapshotAbortedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendSnapshotAbortedEvent.DiscardUnknown(m)
 }
 
-func (m *LeaderEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SendSnapshotAbortedEvent proto.InternalMessageInfo
+
+func (m *SendSnapshotAbortedEvent) GetTo() string {
+	if m != nil {
+		return m.To
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *LeaderEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SnapshotReceivedEvent struct {
+	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
+	From          string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
 }
 
-func (m *LeaderEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Leader) > 0 {
-		i -= len(m.Leader)
-		copy(dAtA[i:], m.Leader)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *SnapshotReceivedEvent) Reset()         { *m = SnapshotReceivedEvent{} }
+func (m *SnapshotReceivedEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReceivedEvent) ProtoMessage()    {}
+func (*SnapshotReceivedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{32}
+}
+func (m *SnapshotReceivedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotReceivedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotReceivedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *LeaderUpdatedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SnapshotReceivedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReceivedEvent.Merge(m, src)
+}
+func (m *SnapshotReceivedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotReceivedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReceivedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReceivedEvent proto.InternalMessageInfo
+
+func (m *SnapshotReceivedEvent) GetFrom() string {
+	if m != nil {
+		return m.From
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *LeaderUpdatedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SnapshotRecoveredEvent struct {
+	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
 }
 
-func (m *LeaderUpdatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.LeaderEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *SnapshotRecoveredEvent) Reset()         { *m = SnapshotRecoveredEvent{} }
+func (m *SnapshotRecoveredEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotRecoveredEvent) ProtoMessage()    {}
+func (*SnapshotRecoveredEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{33}
+}
+func (m *SnapshotRecoveredEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotRecoveredEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotRecoveredEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *SnapshotEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *SnapshotRecoveredEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotRecoveredEvent.Merge(m, src)
+}
+func (m *SnapshotRecoveredEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotRecoveredEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotRecoveredEvent.DiscardUnknown(m)
 }
 
-func (m *SnapshotEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_SnapshotRecoveredEvent proto.InternalMessageInfo
+
+type SnapshotCreatedEvent struct {
+	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
 }
 
-func (m *SnapshotEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *SnapshotCreatedEvent) Reset()         { *m = SnapshotCreatedEvent{} }
+func (m *SnapshotCreatedEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotCreatedEvent) ProtoMessage()    {}
+func (*SnapshotCreatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{34}
+}
+func (m *SnapshotCreatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotCreatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *SendSnapshotStartedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *SnapshotCreatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotCreatedEvent.Merge(m, src)
+}
+func (m *SnapshotCreatedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotCreatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotCreatedEvent.DiscardUnknown(m)
 }
 
-func (m *SendSnapshotStartedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_SnapshotCreatedEvent proto.InternalMessageInfo
+
+type SnapshotCompactedEvent struct {
+	SnapshotEvent `protobuf:"bytes,1,opt,name=snapshot,proto3,embedded=snapshot" json:"snapshot"`
+	// retained_snapshots is the number of snapshots retained for the partition
+	RetainedSnapshots uint32 `protobuf:"varint,2,opt,name=retained_snapshots,json=retainedSnapshots,proto3" json:"retained_snapshots,omitempty"`
 }
 
-func (m *SendSnapshotStartedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.To) > 0 {
-		i -= len(m.To)
-		copy(dAtA[i:], m.To)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.To)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *SnapshotCompactedEvent) Reset()         { *m = SnapshotCompactedEvent{} }
+func (m *SnapshotCompactedEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotCompactedEvent) ProtoMessage()    {}
+func (*SnapshotCompactedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{35}
+}
+func (m *SnapshotCompactedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotCompactedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *SnapshotCompactedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotCompactedEvent.Merge(m, src)
+}
+func (m *SnapshotCompactedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotCompactedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotCompactedEvent.DiscardUnknown(m)
 }
 
-func (m *SendSnapshotCompletedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SnapshotCompactedEvent proto.InternalMessageInfo
+
+func (m *SnapshotCompactedEvent) GetRetainedSnapshots() uint32 {
+	if m != nil {
+		return m.RetainedSnapshots
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SendSnapshotCompletedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type LogEvent struct {
+	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
+	Index          uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
 }
 
-func (m *SendSnapshotCompletedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.To) > 0 {
-		i -= len(m.To)
-		copy(dAtA[i:], m.To)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.To)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *LogEvent) Reset()         { *m = LogEvent{} }
+func (m *LogEvent) String() string { return proto.CompactTextString(m) }
+func (*LogEvent) ProtoMessage()    {}
+func (*LogEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{36}
+}
+func (m *LogEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *SendSnapshotAbortedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *LogEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEvent.Merge(m, src)
 }
-
-func (m *SendSnapshotAbortedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogEvent) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *SendSnapshotAbortedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.To) > 0 {
-		i -= len(m.To)
-		copy(dAtA[i:], m.To)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.To)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+func (m *LogEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEvent.DiscardUnknown(m)
 }
 
-func (m *SnapshotReceivedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_LogEvent proto.InternalMessageInfo
+
+func (m *LogEvent) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SnapshotReceivedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type LogCompactedEvent struct {
+	LogEvent `protobuf:"bytes,1,opt,name=log,proto3,embedded=log" json:"log"`
+	// compaction_overhead is the number of entries retained in the log
+	CompactionOverhead uint64 `protobuf:"varint,2,opt,name=compaction_overhead,json=compactionOverhead,proto3" json:"compaction_overhead,omitempty"`
+	// requested indicates the compaction was scheduled or requested rather than triggered by a snapshot
+	Requested bool `protobuf:"varint,3,opt,name=requested,proto3" json:"requested,omitempty"`
 }
 
-func (m *SnapshotReceivedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.From) > 0 {
-		i -= len(m.From)
-		copy(dAtA[i:], m.From)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.From)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *LogCompactedEvent) Reset()         { *m = LogCompactedEvent{} }
+func (m *LogCompactedEvent) String() string { return proto.CompactTextString(m) }
+func (*LogCompactedEvent) ProtoMessage()    {}
+func (*LogCompactedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{37}
+}
+func (m *LogCompactedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogCompactedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *SnapshotRecoveredEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *LogCompactedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogCompactedEvent.Merge(m, src)
 }
-
-func (m *SnapshotRecoveredEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogCompactedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogCompactedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogCompactedEvent.DiscardUnknown(m)
 }
 
-func (m *SnapshotRecoveredEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+var xxx_messageInfo_LogCompactedEvent proto.InternalMessageInfo
+
+func (m *LogCompactedEvent) GetCompactionOverhead() uint64 {
+	if m != nil {
+		return m.CompactionOverhead
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *SnapshotCreatedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LogCompactedEvent) GetRequested() bool {
+	if m != nil {
+		return m.Requested
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *SnapshotCreatedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type LogDBCompactedEvent struct {
+	LogEvent `protobuf:"bytes,1,opt,name=log,proto3,embedded=log" json:"log"`
 }
 
-func (m *SnapshotCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *LogDBCompactedEvent) Reset()         { *m = LogDBCompactedEvent{} }
+func (m *LogDBCompactedEvent) String() string { return proto.CompactTextString(m) }
+func (*LogDBCompactedEvent) ProtoMessage()    {}
+func (*LogDBCompactedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{38}
+}
+func (m *LogDBCompactedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogDBCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogDBCompactedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *SnapshotCompactedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *LogDBCompactedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogDBCompactedEvent.Merge(m, src)
 }
-
-func (m *SnapshotCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogDBCompactedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogDBCompactedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogDBCompactedEvent.DiscardUnknown(m)
 }
 
-func (m *SnapshotCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+var xxx_messageInfo_LogDBCompactedEvent proto.InternalMessageInfo
+
+// MemberStatsEvent is published periodically with the replication statistics of the local member of a partition
+type MemberStatsEvent struct {
+	PartitionEvent `protobuf:"bytes,1,opt,name=partition,proto3,embedded=partition" json:"partition"`
+	Role           MemberStatsEvent_Role `protobuf:"varint,2,opt,name=role,proto3,enum=atomix.raft.MemberStatsEvent_Role" json:"role,omitempty"`
+	Term           uint64                `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	Leader         string                `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	Learner        bool                  `protobuf:"varint,5,opt,name=learner,proto3" json:"learner,omitempty"`
+	// estimated_applied_index estimates the index of the last entry applied to the state machine. dragonboat
+	// does not expose Raft indexes, so entries are counted from the last recovered snapshot and entries not
+	// applied to the state machine, e.g. no-ops and configuration changes, are not counted.
+	EstimatedAppliedIndex uint64 `protobuf:"varint,7,opt,name=estimated_applied_index,json=estimatedAppliedIndex,proto3" json:"estimated_applied_index,omitempty"`
+	LastSnapshotIndex     uint64 `protobuf:"varint,8,opt,name=last_snapshot_index,json=lastSnapshotIndex,proto3" json:"last_snapshot_index,omitempty"`
+	// snapshot_size is the size of the last snapshot in bytes
+	SnapshotSize uint64 `protobuf:"varint,9,opt,name=snapshot_size,json=snapshotSize,proto3" json:"snapshot_size,omitempty"`
+	// log_size estimates the number of entries retained in the log since it was last compacted
+	LogSize uint64 `protobuf:"varint,10,opt,name=log_size,json=logSize,proto3" json:"log_size,omitempty"`
+}
+
+func (m *MemberStatsEvent) Reset()         { *m = MemberStatsEvent{} }
+func (m *MemberStatsEvent) String() string { return proto.CompactTextString(m) }
+func (*MemberStatsEvent) ProtoMessage()    {}
+func (*MemberStatsEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{39}
+}
+func (m *MemberStatsEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberStatsEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MemberStatsEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *MemberStatsEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberStatsEvent.Merge(m, src)
+}
+func (m *MemberStatsEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberStatsEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberStatsEvent.DiscardUnknown(m)
 }
 
-func (m *LogEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_MemberStatsEvent proto.InternalMessageInfo
+
+func (m *MemberStatsEvent) GetRole() MemberStatsEvent_Role {
+	if m != nil {
+		return m.Role
 	}
-	return dAtA[:n], nil
+	return MemberStatsEvent_FOLLOWER
 }
 
-func (m *LogEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *MemberStatsEvent) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
 }
 
-func (m *LogEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *MemberStatsEvent) GetLeader() string {
+	if m != nil {
+		return m.Leader
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *LogCompactedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *MemberStatsEvent) GetLearner() bool {
+	if m != nil {
+		return m.Learner
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *LogCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *MemberStatsEvent) GetEstimatedAppliedIndex() uint64 {
+	if m != nil {
+		return m.EstimatedAppliedIndex
+	}
+	return 0
 }
 
-func (m *LogCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.LogEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *MemberStatsEvent) GetLastSnapshotIndex() uint64 {
+	if m != nil {
+		return m.LastSnapshotIndex
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *LogDBCompactedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *MemberStatsEvent) GetSnapshotSize() uint64 {
+	if m != nil {
+		return m.SnapshotSize
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *LogDBCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *MemberStatsEvent) GetLogSize() uint64 {
+	if m != nil {
+		return m.LogSize
+	}
+	return 0
 }
 
-func (m *LogDBCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.LogEvent.MarshalToSizedBuffer(dAtA[:i])
+// DiskUsageEvent is published periodically with the usage of the node's data volume
+type DiskUsageEvent struct {
+	// wal_size is the size of the write-ahead log in bytes
+	WalSize uint64 `protobuf:"varint,1,opt,name=wal_size,json=walSize,proto3" json:"wal_size,omitempty"`
+	// snapshot_size is the size of all snapshots stored by the node in bytes
+	SnapshotSize uint64 `protobuf:"varint,2,opt,name=snapshot_size,json=snapshotSize,proto3" json:"snapshot_size,omitempty"`
+	// capacity is the capacity of the data volume in bytes
+	Capacity uint64 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// available is the number of bytes available on the data volume
+	Available uint64 `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`
+	// writes_rejected indicates the node is rejecting writes because the data volume's usage exceeds the limit
+	WritesRejected bool `protobuf:"varint,5,opt,name=writes_rejected,json=writesRejected,proto3" json:"writes_rejected,omitempty"`
+}
+
+func (m *DiskUsageEvent) Reset()         { *m = DiskUsageEvent{} }
+func (m *DiskUsageEvent) String() string { return proto.CompactTextString(m) }
+func (*DiskUsageEvent) ProtoMessage()    {}
+func (*DiskUsageEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{40}
+}
+func (m *DiskUsageEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiskUsageEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiskUsageEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *ConnectionEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *DiskUsageEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskUsageEvent.Merge(m, src)
 }
-
-func (m *ConnectionEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DiskUsageEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiskUsageEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskUsageEvent.DiscardUnknown(m)
 }
 
-func (m *ConnectionEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Snapshot {
-		i--
-		if m.Snapshot {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.Address) > 0 {
-		i -= len(m.Address)
-		copy(dAtA[i:], m.Address)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Address)))
-		i--
-		dAtA[i] = 0xa
+var xxx_messageInfo_DiskUsageEvent proto.InternalMessageInfo
+
+func (m *DiskUsageEvent) GetWalSize() uint64 {
+	if m != nil {
+		return m.WalSize
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *ConnectionEstablishedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *DiskUsageEvent) GetSnapshotSize() uint64 {
+	if m != nil {
+		return m.SnapshotSize
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ConnectionEstablishedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DiskUsageEvent) GetCapacity() uint64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
 }
 
-func (m *ConnectionEstablishedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.ConnectionEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *DiskUsageEvent) GetAvailable() uint64 {
+	if m != nil {
+		return m.Available
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *ConnectionFailedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *DiskUsageEvent) GetWritesRejected() bool {
+	if m != nil {
+		return m.WritesRejected
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *ConnectionFailedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ConnectionEvent struct {
+	Address  string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Snapshot bool   `protobuf:"varint,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 }
 
-func (m *ConnectionFailedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.ConnectionEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *ConnectionEvent) Reset()         { *m = ConnectionEvent{} }
+func (m *ConnectionEvent) String() string { return proto.CompactTextString(m) }
+func (*ConnectionEvent) ProtoMessage()    {}
+func (*ConnectionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{41}
+}
+func (m *ConnectionEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func encodeVarintProtocol(dAtA []byte, offset int, v uint64) int {
-	offset -= sovProtocol(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (m *ConnectionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionEvent.Merge(m, src)
 }
-func (m *Entry) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	if m.StreamID != 0 {
-		n += 1 + sovProtocol(uint64(m.StreamID))
-	}
-	return n
+func (m *ConnectionEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionEvent.DiscardUnknown(m)
 }
 
-func (m *SubscribeRequest) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ConnectionEvent proto.InternalMessageInfo
+
+func (m *ConnectionEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
 	}
-	var l int
-	_ = l
-	return n
+	return ""
 }
 
-func (m *RaftEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Event != nil {
-		n += m.Event.Size()
+func (m *ConnectionEvent) GetSnapshot() bool {
+	if m != nil {
+		return m.Snapshot
 	}
-	return n
+	return false
 }
 
-func (m *RaftEvent_MemberReady) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.MemberReady != nil {
-		l = m.MemberReady.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+type ConnectionEstablishedEvent struct {
+	ConnectionEvent `protobuf:"bytes,1,opt,name=connection,proto3,embedded=connection" json:"connection"`
 }
-func (m *RaftEvent_LeaderUpdated) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.LeaderUpdated != nil {
-		l = m.LeaderUpdated.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
-}
-func (m *RaftEvent_MembershipChanged) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.MembershipChanged != nil {
-		l = m.MembershipChanged.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+
+func (m *ConnectionEstablishedEvent) Reset()         { *m = ConnectionEstablishedEvent{} }
+func (m *ConnectionEstablishedEvent) String() string { return proto.CompactTextString(m) }
+func (*ConnectionEstablishedEvent) ProtoMessage()    {}
+func (*ConnectionEstablishedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{42}
 }
-func (m *RaftEvent_SendSnapshotStarted) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SendSnapshotStarted != nil {
-		l = m.SendSnapshotStarted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+func (m *ConnectionEstablishedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (m *RaftEvent_SendSnapshotCompleted) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SendSnapshotCompleted != nil {
-		l = m.SendSnapshotCompleted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+func (m *ConnectionEstablishedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionEstablishedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *RaftEvent_SendSnapshotAborted) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SendSnapshotAborted != nil {
-		l = m.SendSnapshotAborted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+func (m *ConnectionEstablishedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionEstablishedEvent.Merge(m, src)
 }
-func (m *RaftEvent_SnapshotReceived) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SnapshotReceived != nil {
-		l = m.SnapshotReceived.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+func (m *ConnectionEstablishedEvent) XXX_Size() int {
+	return m.Size()
 }
-func (m *RaftEvent_SnapshotRecovered) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SnapshotRecovered != nil {
-		l = m.SnapshotRecovered.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+func (m *ConnectionEstablishedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionEstablishedEvent.DiscardUnknown(m)
 }
-func (m *RaftEvent_SnapshotCreated) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SnapshotCreated != nil {
-		l = m.SnapshotCreated.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+
+var xxx_messageInfo_ConnectionEstablishedEvent proto.InternalMessageInfo
+
+type ConnectionFailedEvent struct {
+	ConnectionEvent `protobuf:"bytes,1,opt,name=connection,proto3,embedded=connection" json:"connection"`
 }
-func (m *RaftEvent_SnapshotCompacted) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SnapshotCompacted != nil {
-		l = m.SnapshotCompacted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+
+func (m *ConnectionFailedEvent) Reset()         { *m = ConnectionFailedEvent{} }
+func (m *ConnectionFailedEvent) String() string { return proto.CompactTextString(m) }
+func (*ConnectionFailedEvent) ProtoMessage()    {}
+func (*ConnectionFailedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ddcb70539c8e6f6, []int{43}
 }
-func (m *RaftEvent_LogCompacted) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.LogCompacted != nil {
-		l = m.LogCompacted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+func (m *ConnectionFailedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (m *RaftEvent_LogdbCompacted) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.LogdbCompacted != nil {
-		l = m.LogdbCompacted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+func (m *ConnectionFailedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionFailedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *RaftEvent_ConnectionEstablished) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ConnectionEstablished != nil {
-		l = m.ConnectionEstablished.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+func (m *ConnectionFailedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionFailedEvent.Merge(m, src)
 }
-func (m *RaftEvent_ConnectionFailed) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ConnectionFailed != nil {
-		l = m.ConnectionFailed.Size()
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+func (m *ConnectionFailedEvent) XXX_Size() int {
+	return m.Size()
 }
-func (m *PartitionEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Partition != 0 {
-		n += 1 + sovProtocol(uint64(m.Partition))
-	}
-	return n
+func (m *ConnectionFailedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionFailedEvent.DiscardUnknown(m)
 }
 
-func (m *MemberReadyEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.PartitionEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+var xxx_messageInfo_ConnectionFailedEvent proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("atomix.raft.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("atomix.raft.MemberStatsEvent_Role", MemberStatsEvent_Role_name, MemberStatsEvent_Role_value)
+	proto.RegisterType((*Entry)(nil), "atomix.raft.Entry")
+	proto.RegisterType((*AddMemberRequest)(nil), "atomix.raft.AddMemberRequest")
+	proto.RegisterType((*AddMemberResponse)(nil), "atomix.raft.AddMemberResponse")
+	proto.RegisterType((*RemoveMemberRequest)(nil), "atomix.raft.RemoveMemberRequest")
+	proto.RegisterType((*RemoveMemberResponse)(nil), "atomix.raft.RemoveMemberResponse")
+	proto.RegisterType((*SyncMemberRequest)(nil), "atomix.raft.SyncMemberRequest")
+	proto.RegisterType((*SyncMemberResponse)(nil), "atomix.raft.SyncMemberResponse")
+	proto.RegisterType((*TransferLeadershipRequest)(nil), "atomix.raft.TransferLeadershipRequest")
+	proto.RegisterType((*TransferLeadershipResponse)(nil), "atomix.raft.TransferLeadershipResponse")
+	proto.RegisterType((*AcquireLeadershipRequest)(nil), "atomix.raft.AcquireLeadershipRequest")
+	proto.RegisterType((*AcquireLeadershipResponse)(nil), "atomix.raft.AcquireLeadershipResponse")
+	proto.RegisterType((*SnapshotMemberRequest)(nil), "atomix.raft.SnapshotMemberRequest")
+	proto.RegisterType((*SnapshotMemberResponse)(nil), "atomix.raft.SnapshotMemberResponse")
+	proto.RegisterType((*CompactMemberRequest)(nil), "atomix.raft.CompactMemberRequest")
+	proto.RegisterType((*CompactMemberResponse)(nil), "atomix.raft.CompactMemberResponse")
+	proto.RegisterType((*RestartMemberRequest)(nil), "atomix.raft.RestartMemberRequest")
+	proto.RegisterType((*RestartMemberResponse)(nil), "atomix.raft.RestartMemberResponse")
+	proto.RegisterType((*StartMemberRequest)(nil), "atomix.raft.StartMemberRequest")
+	proto.RegisterType((*StartMemberResponse)(nil), "atomix.raft.StartMemberResponse")
+	proto.RegisterType((*StopMemberRequest)(nil), "atomix.raft.StopMemberRequest")
+	proto.RegisterType((*StopMemberResponse)(nil), "atomix.raft.StopMemberResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "atomix.raft.SubscribeRequest")
+	proto.RegisterType((*RaftEvent)(nil), "atomix.raft.RaftEvent")
+	proto.RegisterType((*PartitionEvent)(nil), "atomix.raft.PartitionEvent")
+	proto.RegisterType((*MemberReadyEvent)(nil), "atomix.raft.MemberReadyEvent")
+	proto.RegisterType((*MembershipChangedEvent)(nil), "atomix.raft.MembershipChangedEvent")
+	proto.RegisterType((*LeaderEvent)(nil), "atomix.raft.LeaderEvent")
+	proto.RegisterType((*LeaderUpdatedEvent)(nil), "atomix.raft.LeaderUpdatedEvent")
+	proto.RegisterType((*SnapshotEvent)(nil), "atomix.raft.SnapshotEvent")
+	proto.RegisterType((*SendSnapshotStartedEvent)(nil), "atomix.raft.SendSnapshotStartedEvent")
+	proto.RegisterType((*SendSnapshotCompletedEvent)(nil), "atomix.raft.SendSnapshotCompletedEvent")
+	proto.RegisterType((*SendSnapshotAbortedEvent)(nil), "atomix.raft.SendSnapshotAbortedEvent")
+	proto.RegisterType((*SnapshotReceivedEvent)(nil), "atomix.raft.SnapshotReceivedEvent")
+	proto.RegisterType((*SnapshotRecoveredEvent)(nil), "atomix.raft.SnapshotRecoveredEvent")
+	proto.RegisterType((*SnapshotCreatedEvent)(nil), "atomix.raft.SnapshotCreatedEvent")
+	proto.RegisterType((*SnapshotCompactedEvent)(nil), "atomix.raft.SnapshotCompactedEvent")
+	proto.RegisterType((*LogEvent)(nil), "atomix.raft.LogEvent")
+	proto.RegisterType((*LogCompactedEvent)(nil), "atomix.raft.LogCompactedEvent")
+	proto.RegisterType((*LogDBCompactedEvent)(nil), "atomix.raft.LogDBCompactedEvent")
+	proto.RegisterType((*MemberStatsEvent)(nil), "atomix.raft.MemberStatsEvent")
+	proto.RegisterType((*DiskUsageEvent)(nil), "atomix.raft.DiskUsageEvent")
+	proto.RegisterType((*ConnectionEvent)(nil), "atomix.raft.ConnectionEvent")
+	proto.RegisterType((*ConnectionEstablishedEvent)(nil), "atomix.raft.ConnectionEstablishedEvent")
+	proto.RegisterType((*ConnectionFailedEvent)(nil), "atomix.raft.ConnectionFailedEvent")
 }
 
-func (m *MembershipChangedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.PartitionEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+func init() { proto.RegisterFile("storage/protocol.proto", fileDescriptor_5ddcb70539c8e6f6) }
+
+var fileDescriptor_5ddcb70539c8e6f6 = []byte{
+	// 1877 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x72, 0xdb, 0xc8,
+	0x11, 0x16, 0x64, 0x8a, 0x3f, 0x4d, 0x91, 0x22, 0x47, 0x12, 0x0d, 0x63, 0x1d, 0x51, 0x86, 0x2b,
+	0x6b, 0x57, 0xaa, 0x42, 0x65, 0x95, 0xcd, 0x56, 0x2a, 0x95, 0x43, 0xf8, 0x67, 0x5b, 0x6b, 0x59,
+	0x54, 0x40, 0xd9, 0xde, 0x24, 0x95, 0x30, 0x43, 0x62, 0x44, 0xc1, 0x06, 0x01, 0x2e, 0x06, 0x92,
+	0x97, 0x7b, 0xc8, 0x2d, 0x87, 0xbd, 0xed, 0x25, 0xc7, 0x3c, 0x45, 0xf2, 0x10, 0x7b, 0xf4, 0x31,
+	0x27, 0x25, 0x25, 0xbf, 0x41, 0x72, 0xcb, 0x29, 0x85, 0x99, 0xc1, 0x2f, 0x41, 0x39, 0x4a, 0xe8,
+	0x1b, 0xd0, 0xfd, 0xcd, 0xd7, 0x0d, 0x4c, 0x4f, 0x77, 0x4f, 0x43, 0x8d, 0xba, 0xb6, 0x83, 0xc7,
+	0x64, 0x6f, 0xea, 0xd8, 0xae, 0x3d, 0xb2, 0xcd, 0x06, 0x7b, 0x40, 0x45, 0xec, 0xda, 0x13, 0xe3,
+	0xab, 0x86, 0x83, 0x4f, 0x5d, 0xa5, 0x3e, 0xb6, 0xed, 0xb1, 0x29, 0x30, 0xc3, 0xf3, 0xd3, 0x3d,
+	0xd7, 0x98, 0x10, 0xea, 0xe2, 0xc9, 0x94, 0xa3, 0x95, 0xad, 0xb1, 0x3d, 0xb6, 0xd9, 0xe3, 0x9e,
+	0xf7, 0xc4, 0xa5, 0xea, 0x05, 0xac, 0x75, 0x2d, 0xd7, 0x99, 0xa1, 0x2d, 0x58, 0xbb, 0xc0, 0xe6,
+	0x39, 0x91, 0xa5, 0x5d, 0xe9, 0xe1, 0xba, 0xc6, 0x5f, 0xd0, 0x4f, 0xa0, 0x40, 0x5d, 0x87, 0xe0,
+	0xc9, 0xc0, 0xd0, 0xe5, 0xd5, 0x5d, 0xe9, 0x61, 0xa6, 0x25, 0x5f, 0x5d, 0xd6, 0xf3, 0x7d, 0x26,
+	0x3c, 0xe8, 0xfc, 0xfb, 0xb2, 0x9e, 0xa7, 0xe2, 0x59, 0xf3, 0x9f, 0x74, 0xb4, 0x0b, 0xd9, 0xd7,
+	0x64, 0xe6, 0xad, 0xb9, 0xb5, 0x2b, 0x3d, 0x2c, 0xb4, 0x0a, 0x57, 0x97, 0xf5, 0xb5, 0xa7, 0x64,
+	0x76, 0xd0, 0xd1, 0xd6, 0x5e, 0x93, 0xd9, 0x81, 0xae, 0x7e, 0x23, 0x41, 0xa5, 0xa9, 0xeb, 0xcf,
+	0xc8, 0x64, 0x48, 0x1c, 0x8d, 0x7c, 0x79, 0x4e, 0xa8, 0x8b, 0xee, 0x42, 0x61, 0x8a, 0x1d, 0xd7,
+	0x70, 0x0d, 0xdb, 0x62, 0x7e, 0x64, 0xb4, 0x50, 0x80, 0xee, 0x43, 0xce, 0xb2, 0x75, 0x12, 0x7a,
+	0x02, 0x57, 0x97, 0xf5, 0xec, 0x91, 0xad, 0x93, 0x83, 0x8e, 0x96, 0xf5, 0x54, 0x07, 0x3a, 0x92,
+	0x21, 0x87, 0x75, 0xdd, 0x21, 0x94, 0x72, 0xd3, 0x9a, 0xff, 0xea, 0x69, 0x4c, 0x82, 0x1d, 0x8b,
+	0x38, 0x72, 0x66, 0x57, 0x7a, 0x98, 0xd7, 0xfc, 0x57, 0x75, 0x13, 0xaa, 0x11, 0x57, 0xe8, 0xd4,
+	0xb6, 0x28, 0x51, 0xbf, 0x80, 0x4d, 0x8d, 0x4c, 0xec, 0x0b, 0xb2, 0x6c, 0x17, 0xd5, 0x1a, 0x6c,
+	0xc5, 0x99, 0x85, 0xc5, 0x4f, 0xa0, 0xda, 0x9f, 0x59, 0xa3, 0x1b, 0xd8, 0x53, 0xb7, 0x00, 0x45,
+	0x97, 0x08, 0xa2, 0xdf, 0xc1, 0x9d, 0x13, 0x07, 0x5b, 0xf4, 0x94, 0x38, 0x87, 0x04, 0xeb, 0xc4,
+	0xa1, 0x67, 0xc6, 0x74, 0x89, 0x1f, 0x70, 0x17, 0x94, 0x34, 0x7e, 0x61, 0xfd, 0xa7, 0x20, 0x37,
+	0x47, 0x5f, 0x9e, 0x1b, 0x0e, 0xb9, 0xa1, 0x71, 0xf5, 0x23, 0xb8, 0x93, 0xb2, 0x52, 0xd0, 0x9e,
+	0xc2, 0x76, 0xdf, 0xc2, 0x53, 0x7a, 0x66, 0xbb, 0x37, 0xd9, 0x91, 0x3d, 0xd8, 0x1c, 0xd9, 0x93,
+	0x29, 0x1e, 0x79, 0x6f, 0x03, 0xfb, 0x82, 0x38, 0x67, 0x04, 0x8b, 0x8f, 0xd3, 0x50, 0xa8, 0xea,
+	0x09, 0x8d, 0xda, 0x80, 0x5a, 0xd2, 0x0e, 0xf7, 0xc0, 0x3b, 0x21, 0x86, 0xa5, 0x93, 0xaf, 0x84,
+	0x11, 0xfe, 0xa2, 0x7e, 0x0a, 0x5b, 0x6d, 0xce, 0x72, 0x93, 0x8d, 0xbb, 0x0d, 0xdb, 0x89, 0x55,
+	0xe2, 0x33, 0x3f, 0xf5, 0x82, 0x83, 0xba, 0xd8, 0xb9, 0x29, 0x5d, 0x62, 0x95, 0xa0, 0xfb, 0xa3,
+	0x04, 0xa8, 0x7f, 0x43, 0xb6, 0xff, 0xee, 0xa0, 0x21, 0xc8, 0xbc, 0xb2, 0x0d, 0x8b, 0x9d, 0xb2,
+	0xbc, 0xc6, 0x9e, 0xaf, 0x39, 0x62, 0xdb, 0xb0, 0xd9, 0x4f, 0x71, 0xcf, 0x0b, 0x79, 0xd7, 0x9e,
+	0xde, 0x34, 0xe4, 0x23, 0x4b, 0x04, 0x11, 0x82, 0x4a, 0xff, 0x7c, 0x48, 0x47, 0x8e, 0x31, 0x24,
+	0x82, 0x47, 0xfd, 0x17, 0x40, 0x41, 0xc3, 0xa7, 0x6e, 0xf7, 0x82, 0x58, 0x2e, 0x6a, 0x41, 0x21,
+	0xc8, 0x88, 0x8c, 0xb5, 0xb8, 0xaf, 0x34, 0x78, 0xce, 0x6c, 0xf8, 0x39, 0xb3, 0x71, 0xe2, 0x23,
+	0x5a, 0xf9, 0xef, 0x2e, 0xeb, 0x2b, 0xdf, 0xfe, 0xbd, 0x2e, 0x69, 0xe1, 0x32, 0xd4, 0x82, 0xf5,
+	0x09, 0xb3, 0x3b, 0x70, 0x08, 0xd6, 0x67, 0xec, 0xef, 0x14, 0xf7, 0xbf, 0xd7, 0x88, 0xe4, 0xe1,
+	0x86, 0xef, 0x18, 0xd6, 0x67, 0xcc, 0xf0, 0x93, 0x15, 0xad, 0x38, 0x09, 0x65, 0xe8, 0x09, 0x94,
+	0x4d, 0x16, 0xdd, 0x83, 0xf3, 0xa9, 0x8e, 0x5d, 0xc2, 0x53, 0x64, 0x71, 0xbf, 0x1e, 0x63, 0xe1,
+	0x07, 0xe0, 0x39, 0x47, 0xf8, 0x3c, 0x25, 0x33, 0x2a, 0x45, 0x27, 0x80, 0x38, 0xb1, 0x77, 0x4e,
+	0x06, 0xa3, 0x33, 0x6c, 0x8d, 0x89, 0xce, 0x7e, 0x7c, 0x71, 0xff, 0x7e, 0x8a, 0x4f, 0x1e, 0xac,
+	0xcd, 0x51, 0x3e, 0x63, 0x75, 0x92, 0xd4, 0xa0, 0xdf, 0xc0, 0x36, 0x25, 0x96, 0x3e, 0xa0, 0xe2,
+	0x10, 0x0c, 0x58, 0x58, 0x11, 0x5d, 0x5e, 0x63, 0xc4, 0xdf, 0x8f, 0x11, 0xf7, 0x89, 0xa5, 0xfb,
+	0xa7, 0xa5, 0xcf, 0x71, 0x3e, 0xf5, 0x26, 0x9d, 0xd7, 0x21, 0x0c, 0xb7, 0xe3, 0xe4, 0xde, 0x01,
+	0x34, 0x89, 0x47, 0x9f, 0x65, 0xf4, 0x0f, 0x16, 0xd2, 0xb7, 0x7d, 0xa4, 0x6f, 0x60, 0x9b, 0xa6,
+	0x69, 0xe7, 0xfd, 0xc7, 0x43, 0x9b, 0xf9, 0x9f, 0x7b, 0x8f, 0xff, 0x4d, 0x8e, 0x4b, 0xf5, 0x5f,
+	0xe8, 0xd0, 0x2f, 0xa1, 0x1a, 0xf0, 0x3a, 0x64, 0x44, 0x8c, 0x0b, 0xa2, 0xcb, 0x79, 0x46, 0xac,
+	0xc6, 0x89, 0x05, 0x4a, 0x13, 0x20, 0x9f, 0xb5, 0x42, 0x13, 0x0a, 0x6f, 0x17, 0xa3, 0x94, 0x5e,
+	0x86, 0x22, 0xba, 0x5c, 0x48, 0xd9, 0xc5, 0x08, 0x27, 0x47, 0x05, 0xbb, 0x48, 0x93, 0x1a, 0x74,
+	0x04, 0x95, 0xf0, 0x1f, 0x3b, 0x84, 0xc5, 0x19, 0x30, 0xce, 0x7b, 0xa9, 0x9c, 0x6d, 0x8e, 0xf1,
+	0x19, 0x37, 0x68, 0x5c, 0x1e, 0xf3, 0x52, 0x24, 0x4d, 0xa2, 0xcb, 0xc5, 0x6b, 0xbc, 0x6c, 0xfb,
+	0xa8, 0x39, 0x2f, 0x03, 0x0d, 0xea, 0x42, 0xc9, 0xb4, 0xc7, 0x11, 0xc2, 0x75, 0x46, 0xb8, 0x13,
+	0x3f, 0x0a, 0xf6, 0x78, 0x8e, 0x6b, 0xdd, 0x8c, 0x08, 0xd1, 0x53, 0xd8, 0x30, 0xed, 0xb1, 0x3e,
+	0x8c, 0x10, 0x95, 0x18, 0xd1, 0x6e, 0x92, 0xa8, 0xd3, 0x9a, 0xa3, 0x2a, 0xb3, 0xa5, 0x21, 0xd9,
+	0xef, 0xa1, 0x36, 0xb2, 0x2d, 0x8b, 0xf0, 0x82, 0xe1, 0x1d, 0xfc, 0xa1, 0x69, 0xd0, 0x33, 0xa2,
+	0xcb, 0xe5, 0x94, 0x08, 0x6d, 0x07, 0xd0, 0x6e, 0x88, 0x0c, 0x22, 0x74, 0x94, 0xa6, 0xf5, 0x82,
+	0x28, 0x62, 0xe1, 0x14, 0x1b, 0x26, 0xd1, 0xe5, 0x8d, 0x94, 0x20, 0x0a, 0xc9, 0x1f, 0x31, 0x50,
+	0x10, 0x44, 0xa3, 0x84, 0x22, 0x92, 0x98, 0xa8, 0x8b, 0x5d, 0x2a, 0x57, 0x16, 0x26, 0xa6, 0xbe,
+	0xa7, 0x4f, 0x24, 0x26, 0x26, 0x43, 0x3f, 0x07, 0xd0, 0x0d, 0xfa, 0x7a, 0x70, 0x4e, 0xf1, 0x98,
+	0xc8, 0x55, 0xc6, 0xf0, 0x51, 0x8c, 0xa1, 0x63, 0xd0, 0xd7, 0xcf, 0x3d, 0xad, 0xbf, 0xbe, 0xa0,
+	0xfb, 0x92, 0x56, 0x0e, 0xd6, 0x88, 0x27, 0x55, 0x1b, 0x50, 0x3e, 0xf6, 0x93, 0x35, 0xcf, 0xbc,
+	0xd7, 0xe7, 0xf3, 0x97, 0x50, 0x49, 0xa6, 0x4c, 0xd4, 0x4e, 0xae, 0x48, 0x7a, 0x12, 0xb7, 0xc0,
+	0x93, 0xf5, 0xdb, 0x4b, 0x2f, 0x59, 0x87, 0xc4, 0xbf, 0x85, 0x5a, 0x7a, 0xde, 0x5b, 0x0e, 0xfd,
+	0x1f, 0xa0, 0xc8, 0x93, 0xf4, 0xf2, 0x38, 0xbd, 0x9a, 0xea, 0x12, 0x67, 0x22, 0xba, 0x13, 0xf6,
+	0x8c, 0x6a, 0x90, 0xe5, 0x69, 0x5f, 0xf4, 0xb3, 0xe2, 0x4d, 0x3d, 0x06, 0x34, 0x5f, 0x24, 0xd0,
+	0xcf, 0x02, 0x34, 0xf7, 0x41, 0x4e, 0xa9, 0x2a, 0x49, 0x07, 0x7c, 0xc6, 0x57, 0x50, 0xf2, 0x0f,
+	0xef, 0x12, 0xbf, 0x29, 0xe8, 0x9a, 0x56, 0xa3, 0x5d, 0x93, 0x09, 0xf2, 0xa2, 0xda, 0x81, 0x7e,
+	0x01, 0x79, 0x3f, 0x55, 0x04, 0x85, 0x3a, 0x2d, 0xc3, 0x24, 0x8d, 0x06, 0xab, 0x50, 0x19, 0x56,
+	0x5d, 0x9b, 0x19, 0x2c, 0x68, 0xab, 0xae, 0xad, 0x5a, 0xa0, 0x2c, 0x2e, 0x25, 0x1f, 0xc0, 0x5e,
+	0xe2, 0xeb, 0xa2, 0x95, 0xe5, 0x03, 0x58, 0x9b, 0x84, 0x9d, 0x71, 0xac, 0xdc, 0x2c, 0xc1, 0x14,
+	0x82, 0xcc, 0xa9, 0x63, 0x4f, 0x84, 0x31, 0xf6, 0xac, 0xfe, 0x3a, 0x6c, 0x90, 0xe3, 0x95, 0xe8,
+	0xff, 0xb7, 0xa7, 0x7e, 0x01, 0x5b, 0x69, 0x15, 0x69, 0x09, 0xcc, 0xdf, 0x48, 0x50, 0x8b, 0xee,
+	0x3f, 0x1e, 0x2d, 0x8f, 0x1c, 0xfd, 0x10, 0x90, 0x43, 0x5c, 0x6c, 0x58, 0x24, 0xec, 0x3b, 0x28,
+	0xfb, 0x69, 0x25, 0xad, 0xea, 0x6b, 0x7c, 0x1a, 0xaa, 0x12, 0xc8, 0x1f, 0xda, 0xe3, 0x0f, 0x7e,
+	0xc6, 0xfe, 0x24, 0x41, 0x75, 0xae, 0x78, 0xa2, 0x4f, 0xe0, 0x96, 0x69, 0x8f, 0x85, 0xa9, 0xed,
+	0x64, 0x81, 0x4c, 0x1a, 0xf1, 0xb0, 0x37, 0xbe, 0x43, 0x79, 0x19, 0xdf, 0xe1, 0x4d, 0xb8, 0x68,
+	0x6f, 0xf3, 0x5a, 0x28, 0x50, 0x9f, 0xc0, 0x66, 0x4a, 0x29, 0xfe, 0x1f, 0x1c, 0x53, 0xff, 0x7a,
+	0xcb, 0x2f, 0x1e, 0x61, 0x59, 0x5b, 0xce, 0x1f, 0xfd, 0x0c, 0x32, 0x8e, 0x6d, 0x12, 0xf6, 0x8d,
+	0xe5, 0x44, 0x59, 0x4e, 0x5a, 0x6c, 0x68, 0xb6, 0x49, 0x34, 0x86, 0x0f, 0x32, 0xf8, 0xad, 0xd4,
+	0x0c, 0x9e, 0x89, 0x66, 0xf0, 0xe8, 0x6d, 0x69, 0x2d, 0x76, 0x5b, 0x42, 0x9f, 0xc1, 0x6d, 0x42,
+	0x5d, 0x63, 0xe2, 0x1d, 0x80, 0x01, 0x9e, 0x4e, 0x4d, 0x83, 0xe8, 0x03, 0xbe, 0xc3, 0x39, 0x46,
+	0xbc, 0x1d, 0xa8, 0x9b, 0x5c, 0x7b, 0xe0, 0x29, 0x51, 0x03, 0x36, 0x4d, 0x4c, 0xdd, 0xb0, 0xf7,
+	0xe5, 0x6b, 0xf2, 0x6c, 0x4d, 0xd5, 0x53, 0xf9, 0x41, 0xc8, 0xf1, 0xf7, 0xa1, 0x14, 0x40, 0xa9,
+	0xf1, 0x35, 0x61, 0x6d, 0x67, 0x46, 0x5b, 0xf7, 0x85, 0x7d, 0xe3, 0x6b, 0x82, 0xee, 0x40, 0xde,
+	0x6b, 0xd2, 0x98, 0x1e, 0x98, 0x3e, 0x67, 0xda, 0x63, 0x4f, 0xa5, 0xee, 0x41, 0xc6, 0xfb, 0x76,
+	0xb4, 0x0e, 0xf9, 0x47, 0xbd, 0xc3, 0xc3, 0xde, 0xcb, 0xae, 0x56, 0x59, 0x41, 0x25, 0x28, 0xb4,
+	0x9b, 0x47, 0x9d, 0x83, 0x4e, 0xf3, 0xa4, 0x5b, 0x91, 0x10, 0x40, 0xf6, 0xb0, 0xdb, 0xec, 0x74,
+	0xb5, 0xca, 0xea, 0xe7, 0x99, 0x7c, 0xb6, 0x92, 0x53, 0xff, 0x22, 0x41, 0x39, 0xde, 0x4b, 0x78,
+	0x46, 0xde, 0x60, 0x93, 0x1b, 0xe1, 0x2d, 0x42, 0xee, 0x0d, 0x36, 0x99, 0xfd, 0x39, 0x27, 0x57,
+	0x53, 0x9c, 0x54, 0x20, 0x3f, 0xc2, 0x53, 0x3c, 0x32, 0xdc, 0x99, 0xf8, 0xf7, 0xc1, 0xbb, 0x17,
+	0x8d, 0xf8, 0x02, 0x1b, 0x26, 0x1e, 0x9a, 0x84, 0x6d, 0x41, 0x46, 0x0b, 0x05, 0xe8, 0x01, 0x6c,
+	0xbc, 0x71, 0x0c, 0x97, 0xd0, 0x81, 0x43, 0x5e, 0x91, 0x91, 0x7f, 0xd3, 0xc9, 0x6b, 0x65, 0x2e,
+	0xd6, 0x84, 0x54, 0x7d, 0x0c, 0x1b, 0x91, 0x6e, 0x8f, 0x79, 0x1d, 0x19, 0x36, 0x49, 0xf1, 0x61,
+	0x93, 0x12, 0xc9, 0x29, 0xab, 0x8c, 0x2e, 0x4c, 0x45, 0x3a, 0x28, 0x8b, 0xdb, 0x46, 0xf4, 0x08,
+	0x20, 0x6c, 0xef, 0x44, 0xfc, 0xde, 0x5d, 0xd4, 0x73, 0x26, 0x02, 0x38, 0xb2, 0x52, 0x1d, 0x78,
+	0x13, 0x86, 0x94, 0xfe, 0x71, 0x59, 0x06, 0x7e, 0xf0, 0x67, 0x09, 0x0a, 0x4c, 0x7f, 0x32, 0x9b,
+	0x12, 0x54, 0x84, 0xdc, 0xf3, 0xa3, 0xa7, 0x47, 0xbd, 0x97, 0x47, 0x95, 0x15, 0xb4, 0x0d, 0xd5,
+	0xfe, 0x51, 0xf3, 0xb8, 0xff, 0xa4, 0x77, 0x32, 0xd0, 0xba, 0xed, 0xee, 0xc1, 0x8b, 0x6e, 0xa7,
+	0x22, 0xa1, 0x1a, 0xa0, 0xa8, 0xb8, 0xf7, 0xa2, 0xab, 0x75, 0x3b, 0x95, 0x55, 0xb4, 0x05, 0x95,
+	0x40, 0xde, 0xd6, 0xba, 0xcd, 0x93, 0x6e, 0xa7, 0x72, 0x2b, 0x86, 0x6e, 0xf7, 0x9e, 0x1d, 0x37,
+	0xdb, 0x9e, 0x3c, 0x83, 0xaa, 0x50, 0x3a, 0xec, 0x3d, 0x8e, 0x88, 0xd6, 0xd0, 0x26, 0x6c, 0x1c,
+	0xf6, 0x1e, 0x77, 0x5a, 0x11, 0x61, 0x76, 0x5f, 0x03, 0x08, 0x6e, 0xff, 0x14, 0x75, 0xa0, 0x10,
+	0x0c, 0x08, 0x50, 0xbc, 0x31, 0x4e, 0x0e, 0x0e, 0x94, 0x5a, 0x4c, 0x1d, 0x90, 0xfc, 0x48, 0xda,
+	0xff, 0x67, 0x96, 0x8f, 0x14, 0x9a, 0xfa, 0xc4, 0xb0, 0xd0, 0xe7, 0x50, 0x08, 0xe6, 0x86, 0x09,
+	0xce, 0xe4, 0x68, 0x53, 0xd9, 0x59, 0xa4, 0x16, 0xc3, 0xa5, 0x3e, 0xac, 0x47, 0x87, 0x82, 0x28,
+	0x7e, 0x75, 0x49, 0x99, 0x44, 0x2a, 0xf7, 0xae, 0x41, 0x08, 0xd2, 0x67, 0x00, 0xe1, 0x78, 0x10,
+	0xc5, 0x5d, 0x98, 0x1b, 0x35, 0x2a, 0xf5, 0x85, 0x7a, 0x41, 0x47, 0x00, 0xcd, 0xcf, 0xfd, 0xd0,
+	0xc7, 0xb1, 0x65, 0x0b, 0x07, 0x8f, 0xca, 0x83, 0xf7, 0xe2, 0x84, 0x99, 0x21, 0x54, 0xe7, 0xc6,
+	0x80, 0x28, 0x7e, 0x6f, 0x5f, 0x34, 0x60, 0x54, 0x3e, 0x7e, 0x1f, 0x4c, 0xd8, 0xf8, 0x15, 0x94,
+	0xe3, 0x53, 0x3e, 0x94, 0x7e, 0x7f, 0x8f, 0xff, 0xa1, 0xfb, 0xd7, 0x62, 0x04, 0xf5, 0x0b, 0x28,
+	0xc5, 0x46, 0x7b, 0xe8, 0x5e, 0xe2, 0x70, 0xcd, 0x0f, 0x0b, 0x15, 0xf5, 0x3a, 0x48, 0xc8, 0x1b,
+	0x9b, 0xf1, 0xa1, 0x64, 0x00, 0xcc, 0x4f, 0x0d, 0x15, 0xf5, 0x3a, 0x88, 0xe0, 0x3d, 0x86, 0x62,
+	0x64, 0x34, 0x87, 0x12, 0x51, 0x30, 0xcf, 0xb9, 0xbb, 0x18, 0x10, 0x09, 0xbb, 0x60, 0x44, 0x97,
+	0x0c, 0xbb, 0xe4, 0xb8, 0x4f, 0xa9, 0x2f, 0xd4, 0x73, 0xba, 0x96, 0xfc, 0xdd, 0xd5, 0x8e, 0xf4,
+	0xf6, 0x6a, 0x47, 0xfa, 0xc7, 0xd5, 0x8e, 0xf4, 0xed, 0xbb, 0x9d, 0x95, 0xb7, 0xef, 0x76, 0x56,
+	0xfe, 0xf6, 0x6e, 0x67, 0x65, 0x98, 0x65, 0x83, 0xbb, 0x1f, 0xff, 0x67, 0x00, 0xf0, 0xef, 0x22,
+	0x2d, 0x21, 0x19, 0x00, 0x00,
 }
 
-func (m *LeaderEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.PartitionEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
-	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RaftEventsClient is the client API for RaftEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RaftEventsClient interface {
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (RaftEvents_SubscribeClient, error)
 }
 
-func (m *LeaderUpdatedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.LeaderEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+type raftEventsClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *SnapshotEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.PartitionEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	return n
+func NewRaftEventsClient(cc *grpc.ClientConn) RaftEventsClient {
+	return &raftEventsClient{cc}
 }
 
-func (m *SendSnapshotStartedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *raftEventsClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (RaftEvents_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RaftEvents_serviceDesc.Streams[0], "/atomix.raft.RaftEvents/Subscribe", opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.SnapshotEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	l = len(m.To)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	x := &raftEventsSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return n
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *SendSnapshotCompletedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+type RaftEvents_SubscribeClient interface {
+	Recv() (*RaftEvent, error)
+	grpc.ClientStream
+}
+
+type raftEventsSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *raftEventsSubscribeClient) Recv() (*RaftEvent, error) {
+	m := new(RaftEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.SnapshotEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	l = len(m.To)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	return m, nil
+}
+
+// RaftEventsServer is the server API for RaftEvents service.
+type RaftEventsServer interface {
+	Subscribe(*SubscribeRequest, RaftEvents_SubscribeServer) error
+}
+
+// UnimplementedRaftEventsServer can be embedded to have forward compatible implementations.
+type UnimplementedRaftEventsServer struct {
+}
+
+func (*UnimplementedRaftEventsServer) Subscribe(req *SubscribeRequest, srv RaftEvents_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterRaftEventsServer(s *grpc.Server, srv RaftEventsServer) {
+	s.RegisterService(&_RaftEvents_serviceDesc, srv)
+}
+
+func _RaftEvents_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return n
+	return srv.(RaftEventsServer).Subscribe(m, &raftEventsSubscribeServer{stream})
 }
 
-func (m *SendSnapshotAbortedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+type RaftEvents_SubscribeServer interface {
+	Send(*RaftEvent) error
+	grpc.ServerStream
+}
+
+type raftEventsSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *raftEventsSubscribeServer) Send(m *RaftEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _RaftEvents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "atomix.raft.RaftEvents",
+	HandlerType: (*RaftEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _RaftEvents_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "storage/protocol.proto",
+}
+
+// RaftAdminClient is the client API for RaftAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RaftAdminClient interface {
+	// AddMember adds a member to a partition
+	AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error)
+	// RemoveMember removes a member from a partition
+	RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error)
+	// SyncMember waits for the local member of a partition to apply all committed entries
+	SyncMember(ctx context.Context, in *SyncMemberRequest, opts ...grpc.CallOption) (*SyncMemberResponse, error)
+	// TransferLeadership transfers leadership of a partition away from the local member
+	TransferLeadership(ctx context.Context, in *TransferLeadershipRequest, opts ...grpc.CallOption) (*TransferLeadershipResponse, error)
+	// AcquireLeadership transfers leadership of a partition to the local member
+	AcquireLeadership(ctx context.Context, in *AcquireLeadershipRequest, opts ...grpc.CallOption) (*AcquireLeadershipResponse, error)
+	// SnapshotMember takes a snapshot of the local member of a partition
+	SnapshotMember(ctx context.Context, in *SnapshotMemberRequest, opts ...grpc.CallOption) (*SnapshotMemberResponse, error)
+	// CompactMember compacts the log of the local member of a partition up to its last snapshot
+	CompactMember(ctx context.Context, in *CompactMemberRequest, opts ...grpc.CallOption) (*CompactMemberResponse, error)
+	// RestartMember restarts the local member of a partition from its persisted state
+	RestartMember(ctx context.Context, in *RestartMemberRequest, opts ...grpc.CallOption) (*RestartMemberResponse, error)
+	// StartMember starts a member of a partition on the local node
+	StartMember(ctx context.Context, in *StartMemberRequest, opts ...grpc.CallOption) (*StartMemberResponse, error)
+	// StopMember stops the local member of a partition and removes its data
+	StopMember(ctx context.Context, in *StopMemberRequest, opts ...grpc.CallOption) (*StopMemberResponse, error)
+}
+
+type raftAdminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRaftAdminClient(cc *grpc.ClientConn) RaftAdminClient {
+	return &raftAdminClient{cc}
+}
+
+func (c *raftAdminClient) AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error) {
+	out := new(AddMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/AddMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.SnapshotEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	l = len(m.To)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	return out, nil
+}
+
+func (c *raftAdminClient) RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error) {
+	out := new(RemoveMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/RemoveMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *SnapshotReceivedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *raftAdminClient) SyncMember(ctx context.Context, in *SyncMemberRequest, opts ...grpc.CallOption) (*SyncMemberResponse, error) {
+	out := new(SyncMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/SyncMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.SnapshotEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	l = len(m.From)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	return out, nil
+}
+
+func (c *raftAdminClient) TransferLeadership(ctx context.Context, in *TransferLeadershipRequest, opts ...grpc.CallOption) (*TransferLeadershipResponse, error) {
+	out := new(TransferLeadershipResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/TransferLeadership", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *SnapshotRecoveredEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *raftAdminClient) AcquireLeadership(ctx context.Context, in *AcquireLeadershipRequest, opts ...grpc.CallOption) (*AcquireLeadershipResponse, error) {
+	out := new(AcquireLeadershipResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/AcquireLeadership", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.SnapshotEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+	return out, nil
 }
 
-func (m *SnapshotCreatedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *raftAdminClient) SnapshotMember(ctx context.Context, in *SnapshotMemberRequest, opts ...grpc.CallOption) (*SnapshotMemberResponse, error) {
+	out := new(SnapshotMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/SnapshotMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.SnapshotEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+	return out, nil
 }
 
-func (m *SnapshotCompactedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *raftAdminClient) CompactMember(ctx context.Context, in *CompactMemberRequest, opts ...grpc.CallOption) (*CompactMemberResponse, error) {
+	out := new(CompactMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/CompactMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.SnapshotEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+	return out, nil
 }
 
-func (m *LogEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *raftAdminClient) RestartMember(ctx context.Context, in *RestartMemberRequest, opts ...grpc.CallOption) (*RestartMemberResponse, error) {
+	out := new(RestartMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/RestartMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.PartitionEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
+	return out, nil
+}
+
+func (c *raftAdminClient) StartMember(ctx context.Context, in *StartMemberRequest, opts ...grpc.CallOption) (*StartMemberResponse, error) {
+	out := new(StartMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/StartMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *LogCompactedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *raftAdminClient) StopMember(ctx context.Context, in *StopMemberRequest, opts ...grpc.CallOption) (*StopMemberResponse, error) {
+	out := new(StopMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.RaftAdmin/StopMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.LogEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+	return out, nil
 }
 
-func (m *LogDBCompactedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+// RaftAdminServer is the server API for RaftAdmin service.
+type RaftAdminServer interface {
+	// AddMember adds a member to a partition
+	AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error)
+	// RemoveMember removes a member from a partition
+	RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error)
+	// SyncMember waits for the local member of a partition to apply all committed entries
+	SyncMember(context.Context, *SyncMemberRequest) (*SyncMemberResponse, error)
+	// TransferLeadership transfers leadership of a partition away from the local member
+	TransferLeadership(context.Context, *TransferLeadershipRequest) (*TransferLeadershipResponse, error)
+	// AcquireLeadership transfers leadership of a partition to the local member
+	AcquireLeadership(context.Context, *AcquireLeadershipRequest) (*AcquireLeadershipResponse, error)
+	// SnapshotMember takes a snapshot of the local member of a partition
+	SnapshotMember(context.Context, *SnapshotMemberRequest) (*SnapshotMemberResponse, error)
+	// CompactMember compacts the log of the local member of a partition up to its last snapshot
+	CompactMember(context.Context, *CompactMemberRequest) (*CompactMemberResponse, error)
+	// RestartMember restarts the local member of a partition from its persisted state
+	RestartMember(context.Context, *RestartMemberRequest) (*RestartMemberResponse, error)
+	// StartMember starts a member of a partition on the local node
+	StartMember(context.Context, *StartMemberRequest) (*StartMemberResponse, error)
+	// StopMember stops the local member of a partition and removes its data
+	StopMember(context.Context, *StopMemberRequest) (*StopMemberResponse, error)
+}
+
+// UnimplementedRaftAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedRaftAdminServer struct {
+}
+
+func (*UnimplementedRaftAdminServer) AddMember(ctx context.Context, req *AddMemberRequest) (*AddMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (*UnimplementedRaftAdminServer) RemoveMember(ctx context.Context, req *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (*UnimplementedRaftAdminServer) SyncMember(ctx context.Context, req *SyncMemberRequest) (*SyncMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncMember not implemented")
+}
+func (*UnimplementedRaftAdminServer) TransferLeadership(ctx context.Context, req *TransferLeadershipRequest) (*TransferLeadershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferLeadership not implemented")
+}
+func (*UnimplementedRaftAdminServer) AcquireLeadership(ctx context.Context, req *AcquireLeadershipRequest) (*AcquireLeadershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireLeadership not implemented")
+}
+func (*UnimplementedRaftAdminServer) SnapshotMember(ctx context.Context, req *SnapshotMemberRequest) (*SnapshotMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotMember not implemented")
+}
+func (*UnimplementedRaftAdminServer) CompactMember(ctx context.Context, req *CompactMemberRequest) (*CompactMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompactMember not implemented")
+}
+func (*UnimplementedRaftAdminServer) RestartMember(ctx context.Context, req *RestartMemberRequest) (*RestartMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartMember not implemented")
+}
+func (*UnimplementedRaftAdminServer) StartMember(ctx context.Context, req *StartMemberRequest) (*StartMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMember not implemented")
+}
+func (*UnimplementedRaftAdminServer) StopMember(ctx context.Context, req *StopMemberRequest) (*StopMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopMember not implemented")
+}
+
+func RegisterRaftAdminServer(s *grpc.Server, srv RaftAdminServer) {
+	s.RegisterService(&_RaftAdmin_serviceDesc, srv)
+}
+
+func _RaftAdmin_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/AddMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).AddMember(ctx, req.(*AddMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/RemoveMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).RemoveMember(ctx, req.(*RemoveMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_SyncMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).SyncMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/SyncMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).SyncMember(ctx, req.(*SyncMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_TransferLeadership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferLeadershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).TransferLeadership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/TransferLeadership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).TransferLeadership(ctx, req.(*TransferLeadershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_AcquireLeadership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireLeadershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).AcquireLeadership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/AcquireLeadership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).AcquireLeadership(ctx, req.(*AcquireLeadershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_SnapshotMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).SnapshotMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/SnapshotMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).SnapshotMember(ctx, req.(*SnapshotMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_CompactMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).CompactMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/CompactMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).CompactMember(ctx, req.(*CompactMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_RestartMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).RestartMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/RestartMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).RestartMember(ctx, req.(*RestartMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_StartMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).StartMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/StartMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).StartMember(ctx, req.(*StartMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftAdmin_StopMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftAdminServer).StopMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.RaftAdmin/StopMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftAdminServer).StopMember(ctx, req.(*StopMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RaftAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "atomix.raft.RaftAdmin",
+	HandlerType: (*RaftAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddMember",
+			Handler:    _RaftAdmin_AddMember_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _RaftAdmin_RemoveMember_Handler,
+		},
+		{
+			MethodName: "SyncMember",
+			Handler:    _RaftAdmin_SyncMember_Handler,
+		},
+		{
+			MethodName: "TransferLeadership",
+			Handler:    _RaftAdmin_TransferLeadership_Handler,
+		},
+		{
+			MethodName: "AcquireLeadership",
+			Handler:    _RaftAdmin_AcquireLeadership_Handler,
+		},
+		{
+			MethodName: "SnapshotMember",
+			Handler:    _RaftAdmin_SnapshotMember_Handler,
+		},
+		{
+			MethodName: "CompactMember",
+			Handler:    _RaftAdmin_CompactMember_Handler,
+		},
+		{
+			MethodName: "RestartMember",
+			Handler:    _RaftAdmin_RestartMember_Handler,
+		},
+		{
+			MethodName: "StartMember",
+			Handler:    _RaftAdmin_StartMember_Handler,
+		},
+		{
+			MethodName: "StopMember",
+			Handler:    _RaftAdmin_StopMember_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage/protocol.proto",
+}
+
+func (m *Entry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Entry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Entry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KeyID) > 0 {
+		i -= len(m.KeyID)
+		copy(dAtA[i:], m.KeyID)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.KeyID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.StreamID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.StreamID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Learner {
+		i--
+		if m.Learner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.NodeID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.NodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoveMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NodeID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.NodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoveMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *SyncMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SyncMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SyncMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SyncMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferLeadershipRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeadershipRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferLeadershipRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NodeID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.NodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferLeadershipResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeadershipResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferLeadershipResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *AcquireLeadershipRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AcquireLeadershipRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AcquireLeadershipRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AcquireLeadershipResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AcquireLeadershipResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AcquireLeadershipResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CompactionOverhead != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.CompactionOverhead))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RestartMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RestartMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RestartMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RestartMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RestartMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RestartMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *StartMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StartMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StartMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Learner {
+		i--
+		if m.Learner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Join {
+		i--
+		if m.Join {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.NodeID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.NodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StartMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StartMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StartMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *StopMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StopMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StopMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StopMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StopMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StopMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RaftEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaftEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Event != nil {
+		{
+			size := m.Event.Size()
+			i -= size
+			if _, err := m.Event.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintProtocol(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *RaftEvent_MemberReady) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_MemberReady) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MemberReady != nil {
+		{
+			size, err := m.MemberReady.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_LeaderUpdated) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_LeaderUpdated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LeaderUpdated != nil {
+		{
+			size, err := m.LeaderUpdated.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_MembershipChanged) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_MembershipChanged) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MembershipChanged != nil {
+		{
+			size, err := m.MembershipChanged.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_SendSnapshotStarted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_SendSnapshotStarted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SendSnapshotStarted != nil {
+		{
+			size, err := m.SendSnapshotStarted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_SendSnapshotCompleted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_SendSnapshotCompleted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SendSnapshotCompleted != nil {
+		{
+			size, err := m.SendSnapshotCompleted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_SendSnapshotAborted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_SendSnapshotAborted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SendSnapshotAborted != nil {
+		{
+			size, err := m.SendSnapshotAborted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_SnapshotReceived) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_SnapshotReceived) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SnapshotReceived != nil {
+		{
+			size, err := m.SnapshotReceived.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_SnapshotRecovered) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_SnapshotRecovered) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SnapshotRecovered != nil {
+		{
+			size, err := m.SnapshotRecovered.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_SnapshotCreated) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_SnapshotCreated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SnapshotCreated != nil {
+		{
+			size, err := m.SnapshotCreated.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_SnapshotCompacted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_SnapshotCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SnapshotCompacted != nil {
+		{
+			size, err := m.SnapshotCompacted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_LogCompacted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_LogCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LogCompacted != nil {
+		{
+			size, err := m.LogCompacted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_LogdbCompacted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_LogdbCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LogdbCompacted != nil {
+		{
+			size, err := m.LogdbCompacted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_ConnectionEstablished) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_ConnectionEstablished) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ConnectionEstablished != nil {
+		{
+			size, err := m.ConnectionEstablished.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_ConnectionFailed) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_ConnectionFailed) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ConnectionFailed != nil {
+		{
+			size, err := m.ConnectionFailed.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_MemberStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_MemberStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MemberStats != nil {
+		{
+			size, err := m.MemberStats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RaftEvent_DiskUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftEvent_DiskUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DiskUsage != nil {
+		{
+			size, err := m.DiskUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PartitionEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PartitionEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PartitionEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberReadyEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberReadyEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberReadyEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *MembershipChangedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MembershipChangedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MembershipChangedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LeaderEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaderEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaderEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LeaderUpdatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaderUpdatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaderUpdatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.LeaderEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SendSnapshotStartedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SendSnapshotStartedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SendSnapshotStartedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.To) > 0 {
+		i -= len(m.To)
+		copy(dAtA[i:], m.To)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.To)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SendSnapshotCompletedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SendSnapshotCompletedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SendSnapshotCompletedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.To) > 0 {
+		i -= len(m.To)
+		copy(dAtA[i:], m.To)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.To)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SendSnapshotAbortedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SendSnapshotAbortedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SendSnapshotAbortedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.To) > 0 {
+		i -= len(m.To)
+		copy(dAtA[i:], m.To)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.To)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotReceivedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotReceivedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotReceivedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.From) > 0 {
+		i -= len(m.From)
+		copy(dAtA[i:], m.From)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.From)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotRecoveredEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotRecoveredEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotRecoveredEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotCreatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotCreatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotCompactedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RetainedSnapshots != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.RetainedSnapshots))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.SnapshotEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LogEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LogCompactedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Requested {
+		i--
+		if m.Requested {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.CompactionOverhead != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.CompactionOverhead))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.LogEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LogDBCompactedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogDBCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogDBCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.LogEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberStatsEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberStatsEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberStatsEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LogSize != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LogSize))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.SnapshotSize != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.SnapshotSize))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.LastSnapshotIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastSnapshotIndex))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.EstimatedAppliedIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.EstimatedAppliedIndex))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Learner {
+		i--
+		if m.Learner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Role != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.PartitionEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *DiskUsageEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiskUsageEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiskUsageEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.WritesRejected {
+		i--
+		if m.WritesRejected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Available != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Available))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Capacity != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Capacity))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.SnapshotSize != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.SnapshotSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.WalSize != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.WalSize))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectionEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectionEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Snapshot {
+		i--
+		if m.Snapshot {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectionEstablishedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionEstablishedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectionEstablishedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.ConnectionEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectionFailedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionFailedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectionFailedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.ConnectionEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintProtocol(dAtA []byte, offset int, v uint64) int {
+	offset -= sovProtocol(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Entry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.StreamID != 0 {
+		n += 1 + sovProtocol(uint64(m.StreamID))
+	}
+	l = len(m.KeyID)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *AddMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	if m.NodeID != 0 {
+		n += 1 + sovProtocol(uint64(m.NodeID))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Learner {
+		n += 2
+	}
+	return n
+}
+
+func (m *AddMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RemoveMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	if m.NodeID != 0 {
+		n += 1 + sovProtocol(uint64(m.NodeID))
+	}
+	return n
+}
+
+func (m *RemoveMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SyncMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	return n
+}
+
+func (m *SyncMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *TransferLeadershipRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	if m.NodeID != 0 {
+		n += 1 + sovProtocol(uint64(m.NodeID))
+	}
+	return n
+}
+
+func (m *TransferLeadershipResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *AcquireLeadershipRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	return n
+}
+
+func (m *AcquireLeadershipResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SnapshotMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	if m.CompactionOverhead != 0 {
+		n += 1 + sovProtocol(uint64(m.CompactionOverhead))
+	}
+	return n
+}
+
+func (m *SnapshotMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *CompactMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	return n
+}
+
+func (m *CompactMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RestartMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	return n
+}
+
+func (m *RestartMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *StartMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	if m.NodeID != 0 {
+		n += 1 + sovProtocol(uint64(m.NodeID))
+	}
+	if m.Join {
+		n += 2
+	}
+	if m.Learner {
+		n += 2
+	}
+	return n
+}
+
+func (m *StartMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *StopMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	return n
+}
+
+func (m *StopMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SubscribeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RaftEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Event != nil {
+		n += m.Event.Size()
+	}
+	return n
+}
+
+func (m *RaftEvent_MemberReady) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberReady != nil {
+		l = m.MemberReady.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_LeaderUpdated) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LeaderUpdated != nil {
+		l = m.LeaderUpdated.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_MembershipChanged) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MembershipChanged != nil {
+		l = m.MembershipChanged.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_SendSnapshotStarted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SendSnapshotStarted != nil {
+		l = m.SendSnapshotStarted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_SendSnapshotCompleted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SendSnapshotCompleted != nil {
+		l = m.SendSnapshotCompleted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_SendSnapshotAborted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SendSnapshotAborted != nil {
+		l = m.SendSnapshotAborted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_SnapshotReceived) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotReceived != nil {
+		l = m.SnapshotReceived.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_SnapshotRecovered) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotRecovered != nil {
+		l = m.SnapshotRecovered.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_SnapshotCreated) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotCreated != nil {
+		l = m.SnapshotCreated.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_SnapshotCompacted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotCompacted != nil {
+		l = m.SnapshotCompacted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_LogCompacted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogCompacted != nil {
+		l = m.LogCompacted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_LogdbCompacted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogdbCompacted != nil {
+		l = m.LogdbCompacted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_ConnectionEstablished) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ConnectionEstablished != nil {
+		l = m.ConnectionEstablished.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_ConnectionFailed) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ConnectionFailed != nil {
+		l = m.ConnectionFailed.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_MemberStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberStats != nil {
+		l = m.MemberStats.Size()
+		n += 2 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RaftEvent_DiskUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskUsage != nil {
+		l = m.DiskUsage.Size()
+		n += 2 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *PartitionEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		n += 1 + sovProtocol(uint64(m.Partition))
+	}
+	return n
+}
+
+func (m *MemberReadyEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PartitionEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *MembershipChangedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PartitionEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *LeaderEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PartitionEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *LeaderUpdatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.LeaderEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *SnapshotEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PartitionEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *SendSnapshotStartedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.SnapshotEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	l = len(m.To)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *SendSnapshotCompletedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.SnapshotEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	l = len(m.To)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *SendSnapshotAbortedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.SnapshotEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	l = len(m.To)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *SnapshotReceivedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.SnapshotEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	l = len(m.From)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *SnapshotRecoveredEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.SnapshotEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *SnapshotCreatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.SnapshotEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *SnapshotCompactedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.SnapshotEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.RetainedSnapshots != 0 {
+		n += 1 + sovProtocol(uint64(m.RetainedSnapshots))
+	}
+	return n
+}
+
+func (m *LogEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PartitionEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *LogCompactedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.LogEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.CompactionOverhead != 0 {
+		n += 1 + sovProtocol(uint64(m.CompactionOverhead))
+	}
+	if m.Requested {
+		n += 2
+	}
+	return n
+}
+
+func (m *LogDBCompactedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.LogEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *MemberStatsEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PartitionEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Role != 0 {
+		n += 1 + sovProtocol(uint64(m.Role))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Learner {
+		n += 2
+	}
+	if m.EstimatedAppliedIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.EstimatedAppliedIndex))
+	}
+	if m.LastSnapshotIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.LastSnapshotIndex))
+	}
+	if m.SnapshotSize != 0 {
+		n += 1 + sovProtocol(uint64(m.SnapshotSize))
+	}
+	if m.LogSize != 0 {
+		n += 1 + sovProtocol(uint64(m.LogSize))
+	}
+	return n
+}
+
+func (m *DiskUsageEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.WalSize != 0 {
+		n += 1 + sovProtocol(uint64(m.WalSize))
+	}
+	if m.SnapshotSize != 0 {
+		n += 1 + sovProtocol(uint64(m.SnapshotSize))
+	}
+	if m.Capacity != 0 {
+		n += 1 + sovProtocol(uint64(m.Capacity))
+	}
+	if m.Available != 0 {
+		n += 1 + sovProtocol(uint64(m.Available))
+	}
+	if m.WritesRejected {
+		n += 2
+	}
+	return n
+}
+
+func (m *ConnectionEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Snapshot {
+		n += 2
+	}
+	return n
+}
+
+func (m *ConnectionEstablishedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ConnectionEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *ConnectionFailedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ConnectionEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func sovProtocol(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozProtocol(x uint64) (n int) {
+	return sovProtocol(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Entry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Entry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Entry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+			}
+			m.StreamID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StreamID |= streamID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			m.NodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Learner", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Learner = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			m.NodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SyncMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SyncMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SyncMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SyncMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SyncMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SyncMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeadershipRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeadershipRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeadershipRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			m.NodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeadershipResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeadershipResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeadershipResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AcquireLeadershipRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AcquireLeadershipRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AcquireLeadershipRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AcquireLeadershipResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AcquireLeadershipResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AcquireLeadershipResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactionOverhead", wireType)
+			}
+			m.CompactionOverhead = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompactionOverhead |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompactMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompactMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RestartMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RestartMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RestartMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RestartMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RestartMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RestartMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StartMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StartMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StartMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
+			}
+			m.Partition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Partition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			m.NodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Join", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Join = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Learner", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Learner = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	var l int
-	_ = l
-	l = m.LogEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
-}
 
-func (m *ConnectionEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	if m.Snapshot {
-		n += 2
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *ConnectionEstablishedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *StartMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StartMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StartMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	var l int
-	_ = l
-	l = m.ConnectionEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
-}
 
-func (m *ConnectionFailedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	var l int
-	_ = l
-	l = m.ConnectionEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
-}
-
-func sovProtocol(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozProtocol(x uint64) (n int) {
-	return sovProtocol(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Entry) Unmarshal(dAtA []byte) error {
+func (m *StopMemberRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2969,17 +7209,17 @@ func (m *Entry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Entry: wiretype end group for non-group")
+			return fmt.Errorf("proto: StopMemberRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Entry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StopMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partition", wireType)
 			}
-			var byteLen int
+			m.Partition = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -2989,45 +7229,64 @@ func (m *Entry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Partition |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StopMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
 			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.StreamID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StreamID |= streamID(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StopMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StopMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -3511,15 +7770,85 @@ func (m *RaftEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &SnapshotCompactedEvent{}
+			v := &SnapshotCompactedEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &RaftEvent_SnapshotCompacted{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogCompacted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LogCompactedEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &RaftEvent_LogCompacted{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogdbCompacted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LogDBCompactedEvent{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &RaftEvent_SnapshotCompacted{v}
+			m.Event = &RaftEvent_LogdbCompacted{v}
 			iNdEx = postIndex
-		case 12:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogCompacted", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionEstablished", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3546,15 +7875,15 @@ func (m *RaftEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &LogCompactedEvent{}
+			v := &ConnectionEstablishedEvent{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &RaftEvent_LogCompacted{v}
+			m.Event = &RaftEvent_ConnectionEstablished{v}
 			iNdEx = postIndex
-		case 13:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogdbCompacted", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionFailed", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3581,15 +7910,15 @@ func (m *RaftEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &LogDBCompactedEvent{}
+			v := &ConnectionFailedEvent{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &RaftEvent_LogdbCompacted{v}
+			m.Event = &RaftEvent_ConnectionFailed{v}
 			iNdEx = postIndex
-		case 14:
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionEstablished", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3616,15 +7945,15 @@ func (m *RaftEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ConnectionEstablishedEvent{}
+			v := &MemberStatsEvent{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &RaftEvent_ConnectionEstablished{v}
+			m.Event = &RaftEvent_MemberStats{v}
 			iNdEx = postIndex
-		case 15:
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionFailed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskUsage", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3651,11 +7980,11 @@ func (m *RaftEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ConnectionFailedEvent{}
+			v := &DiskUsageEvent{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &RaftEvent_ConnectionFailed{v}
+			m.Event = &RaftEvent_DiskUsage{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4081,21 +8410,245 @@ func (m *LeaderUpdatedEvent) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LeaderUpdatedEvent: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaderUpdatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaderUpdatedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaderUpdatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.LeaderEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PartitionEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SendSnapshotStartedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SendSnapshotStartedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SendSnapshotStartedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SnapshotEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4105,24 +8658,23 @@ func (m *LeaderUpdatedEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.LeaderEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.To = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4148,7 +8700,7 @@ func (m *LeaderUpdatedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SnapshotEvent) Unmarshal(dAtA []byte) error {
+func (m *SendSnapshotCompletedEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4171,15 +8723,15 @@ func (m *SnapshotEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: SendSnapshotCompletedEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SendSnapshotCompletedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PartitionEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotEvent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4206,15 +8758,15 @@ func (m *SnapshotEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PartitionEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.SnapshotEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
 			}
-			m.Index = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4224,11 +8776,24 @@ func (m *SnapshotEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.To = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4253,7 +8818,7 @@ func (m *SnapshotEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SendSnapshotStartedEvent) Unmarshal(dAtA []byte) error {
+func (m *SendSnapshotAbortedEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4276,10 +8841,10 @@ func (m *SendSnapshotStartedEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SendSnapshotStartedEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: SendSnapshotAbortedEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SendSnapshotStartedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SendSnapshotAbortedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4371,7 +8936,7 @@ func (m *SendSnapshotStartedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SendSnapshotCompletedEvent) Unmarshal(dAtA []byte) error {
+func (m *SnapshotReceivedEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4394,10 +8959,10 @@ func (m *SendSnapshotCompletedEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SendSnapshotCompletedEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotReceivedEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SendSnapshotCompletedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotReceivedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4435,7 +9000,7 @@ func (m *SendSnapshotCompletedEvent) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4463,7 +9028,7 @@ func (m *SendSnapshotCompletedEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.To = string(dAtA[iNdEx:postIndex])
+			m.From = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4489,7 +9054,7 @@ func (m *SendSnapshotCompletedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SendSnapshotAbortedEvent) Unmarshal(dAtA []byte) error {
+func (m *SnapshotRecoveredEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4512,10 +9077,10 @@ func (m *SendSnapshotAbortedEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SendSnapshotAbortedEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotRecoveredEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SendSnapshotAbortedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotRecoveredEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4551,11 +9116,64 @@ func (m *SendSnapshotAbortedEvent) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotCreatedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotCreatedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotCreatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotEvent", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4565,23 +9183,24 @@ func (m *SendSnapshotAbortedEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.To = string(dAtA[iNdEx:postIndex])
+			if err := m.SnapshotEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4607,7 +9226,7 @@ func (m *SendSnapshotAbortedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SnapshotReceivedEvent) Unmarshal(dAtA []byte) error {
+func (m *SnapshotCompactedEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4630,10 +9249,10 @@ func (m *SnapshotReceivedEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotReceivedEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotCompactedEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotReceivedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotCompactedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4670,10 +9289,10 @@ func (m *SnapshotReceivedEvent) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetainedSnapshots", wireType)
 			}
-			var stringLen uint64
+			m.RetainedSnapshots = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4683,24 +9302,11 @@ func (m *SnapshotReceivedEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.RetainedSnapshots |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.From = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4725,7 +9331,7 @@ func (m *SnapshotReceivedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SnapshotRecoveredEvent) Unmarshal(dAtA []byte) error {
+func (m *LogEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4748,15 +9354,15 @@ func (m *SnapshotRecoveredEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotRecoveredEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotRecoveredEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionEvent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4783,10 +9389,29 @@ func (m *SnapshotRecoveredEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SnapshotEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.PartitionEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4811,7 +9436,7 @@ func (m *SnapshotRecoveredEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SnapshotCreatedEvent) Unmarshal(dAtA []byte) error {
+func (m *LogCompactedEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4834,15 +9459,15 @@ func (m *SnapshotCreatedEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotCreatedEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogCompactedEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotCreatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogCompactedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogEvent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4869,10 +9494,49 @@ func (m *SnapshotCreatedEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SnapshotEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LogEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactionOverhead", wireType)
+			}
+			m.CompactionOverhead = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompactionOverhead |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requested", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Requested = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4897,7 +9561,7 @@ func (m *SnapshotCreatedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SnapshotCompactedEvent) Unmarshal(dAtA []byte) error {
+func (m *LogDBCompactedEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4920,15 +9584,15 @@ func (m *SnapshotCompactedEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotCompactedEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogDBCompactedEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotCompactedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogDBCompactedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogEvent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4955,7 +9619,7 @@ func (m *SnapshotCompactedEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SnapshotEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LogEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4983,7 +9647,7 @@ func (m *SnapshotCompactedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LogEvent) Unmarshal(dAtA []byte) error {
+func (m *MemberStatsEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5006,10 +9670,10 @@ func (m *LogEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemberStatsEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemberStatsEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5047,9 +9711,9 @@ func (m *LogEvent) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
 			}
-			m.Index = 0
+			m.Role = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5059,69 +9723,106 @@ func (m *LogEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint64(b&0x7F) << shift
+				m.Role |= MemberStatsEvent_Role(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			if skippy < 0 {
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LogCompactedEvent) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Learner", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Learner = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EstimatedAppliedIndex", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LogCompactedEvent: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogCompactedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogEvent", wireType)
+			m.EstimatedAppliedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EstimatedAppliedIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			var msglen int
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSnapshotIndex", wireType)
+			}
+			m.LastSnapshotIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5131,25 +9832,49 @@ func (m *LogCompactedEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.LastSnapshotIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtocol
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotSize", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
+			m.SnapshotSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogSize", wireType)
 			}
-			if err := m.LogEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.LogSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LogSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5174,7 +9899,7 @@ func (m *LogCompactedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LogDBCompactedEvent) Unmarshal(dAtA []byte) error {
+func (m *DiskUsageEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5197,17 +9922,17 @@ func (m *LogDBCompactedEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogDBCompactedEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiskUsageEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogDBCompactedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiskUsageEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogEvent", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WalSize", wireType)
 			}
-			var msglen int
+			m.WalSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5217,25 +9942,88 @@ func (m *LogDBCompactedEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.WalSize |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtocol
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotSize", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
+			m.SnapshotSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
 			}
-			if err := m.LogEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Capacity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capacity |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Available", wireType)
+			}
+			m.Available = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Available |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WritesRejected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WritesRejected = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -0,0 +1,203 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	protocol "github.com/atomix/atomix-go-framework/pkg/atomix/storage/protocol/rsm"
+	"github.com/atomix/atomix-raft-storage/pkg/storage/config"
+	"github.com/lni/dragonboat/v3"
+	raftconfig "github.com/lni/dragonboat/v3/config"
+	"github.com/lni/dragonboat/v3/statemachine"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+// testStateMachine is a state machine that ignores all commands
+type testStateMachine struct{}
+
+func (s *testStateMachine) Update(bytes []byte) (statemachine.Result, error) {
+	return statemachine.Result{}, nil
+}
+
+func (s *testStateMachine) Lookup(query interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (s *testStateMachine) SaveSnapshot(writer io.Writer, files statemachine.ISnapshotFileCollection, done <-chan struct{}) error {
+	return nil
+}
+
+func (s *testStateMachine) RecoverFromSnapshot(reader io.Reader, files []statemachine.SnapshotFile, done <-chan struct{}) error {
+	return nil
+}
+
+func (s *testStateMachine) Close() error {
+	return nil
+}
+
+// newTestProtocol returns a protocol running a single member of the given partition on a NodeHost in dir
+func newTestProtocol(t *testing.T, dir string, clusterID uint64) *Protocol {
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+
+	p := NewProtocol(config.ProtocolConfig{}, nil)
+	node, err := dragonboat.NewNodeHost(raftconfig.NodeHostConfig{
+		WALDir:              dir,
+		NodeHostDir:         dir,
+		RTTMillisecond:      10,
+		RaftAddress:         address,
+		RaftEventListener:   p.listener,
+		SystemEventListener: p.listener,
+	})
+	assert.NoError(t, err)
+	p.node = node
+	p.fsmFactory = func(clusterID, nodeID uint64) statemachine.IStateMachine {
+		return &testStateMachine{}
+	}
+	assert.NoError(t, p.startPartition(clusterID, 1, map[uint64]string{1: address}, false, false))
+	return p
+}
+
+// waitForLeader waits for the local member of the given partition to be elected leader
+func waitForLeader(t *testing.T, p *Protocol, clusterID uint64) {
+	for i := 0; i < 500; i++ {
+		if _, ok, err := p.node.GetLeaderID(clusterID); err == nil && ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Fail(t, fmt.Sprintf("no leader elected for partition %d", clusterID))
+}
+
+func TestStopPartition(t *testing.T) {
+	tests := []struct {
+		name    string
+		stopped bool
+	}{
+		{
+			name: "running member",
+		},
+		{
+			name:    "member stopped by failed attempt",
+			stopped: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "atomix-raft-data")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			p := newTestProtocol(t, dir, 1)
+			defer p.node.Stop()
+			waitForLeader(t, p, 1)
+
+			// A previous attempt that failed to remove the member's data left the server registered
+			if test.stopped {
+				assert.NoError(t, p.servers[protocol.PartitionID(1)].Stop())
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			assert.NoError(t, p.stopPartition(ctx, 1))
+			_, ok := p.servers[protocol.PartitionID(1)]
+			assert.False(t, ok)
+			assert.False(t, p.node.HasNodeInfo(1, 1))
+
+			// Stopping a member that's not running is a no-op
+			assert.NoError(t, p.stopPartition(ctx, 1))
+		})
+	}
+}
+
+func TestRestartPartition(t *testing.T) {
+	dir, err := ioutil.TempDir("", "atomix-raft-data")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := newTestProtocol(t, dir, 1)
+	defer p.node.Stop()
+	waitForLeader(t, p, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// A member that fails to start on a closed node leaves the stopped server registered so the restart can be retried
+	closedDir, err := ioutil.TempDir("", "atomix-raft-data")
+	assert.NoError(t, err)
+	defer os.RemoveAll(closedDir)
+	closed := newTestProtocol(t, closedDir, 1)
+	closed.node.Stop()
+
+	server := p.servers[protocol.PartitionID(1)]
+	node := p.node
+	p.node = closed.node
+	assert.Error(t, p.restartPartition(ctx, 1))
+	assert.Equal(t, server, p.servers[protocol.PartitionID(1)])
+
+	p.node = node
+	assert.NoError(t, p.restartPartition(ctx, 1))
+	restarted, ok := p.servers[protocol.PartitionID(1)]
+	assert.True(t, ok)
+	assert.NotEqual(t, server, restarted)
+	waitForLeader(t, p, 1)
+}
+
+func TestGetElectionRTT(t *testing.T) {
+	second := time.Second
+	millisecond := time.Millisecond
+	tests := []struct {
+		name              string
+		electionTimeout   *time.Duration
+		heartbeatInterval *time.Duration
+		electionRTT       uint64
+	}{
+		{
+			name:        "defaults",
+			electionRTT: 10,
+		},
+		{
+			name:              "configured timeout",
+			electionTimeout:   &second,
+			heartbeatInterval: &millisecond,
+			electionRTT:       1000,
+		},
+		{
+			name:              "timeout shorter than minimum",
+			electionTimeout:   &millisecond,
+			heartbeatInterval: &second,
+			electionRTT:       minElectionRTT,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := NewProtocol(config.ProtocolConfig{
+				ElectionTimeout:   test.electionTimeout,
+				HeartbeatInterval: test.heartbeatInterval,
+			}, nil)
+			assert.Equal(t, test.electionRTT, p.getElectionRTT())
+		})
+	}
+}
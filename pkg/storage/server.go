@@ -15,45 +15,138 @@
 package storage
 
 import (
+	"context"
 	"github.com/lni/dragonboat/v3"
 	"github.com/lni/dragonboat/v3/config"
 	"github.com/lni/dragonboat/v3/statemachine"
+	"time"
 )
 
 // newServer returns a new protocol server
-func newServer(clusterID uint64, members map[uint64]string, node *dragonboat.NodeHost, config config.Config, fsm func(uint64, uint64) statemachine.IStateMachine) *Server {
+func newServer(clusterID uint64, members map[uint64]string, join bool, node *dragonboat.NodeHost, config config.Config, fsm func(uint64, uint64) statemachine.IStateMachine, stats *memberStats, options serverOptions) *Server {
 	return &Server{
 		clusterID: clusterID,
 		members:   members,
+		join:      join,
 		node:      node,
 		config:    config,
 		fsm:       fsm,
+		stats:     stats,
+		options:   options,
 	}
 }
 
+// serverOptions are the snapshot and compaction options of a protocol server
+type serverOptions struct {
+	// snapshotInterval is the interval at which snapshots are taken, or zero if snapshots are not taken periodically
+	snapshotInterval time.Duration
+	// snapshotThresholdBytes is the size of the entries applied since the last snapshot after which a snapshot is taken
+	snapshotThresholdBytes uint64
+	// compactionInterval is the interval at which the log is compacted when automatic compaction is disabled
+	compactionInterval time.Duration
+}
+
 // Server is a Raft server
 type Server struct {
 	clusterID uint64
 	members   map[uint64]string
+	join      bool
 	node      *dragonboat.NodeHost
 	config    config.Config
 	fsm       func(uint64, uint64) statemachine.IStateMachine
+	stats     *memberStats
+	options   serverOptions
+	cancel    context.CancelFunc
 }
 
 // Start starts the server
 func (s *Server) Start() error {
 	log.Infof("Starting server for partition %d", s.clusterID)
-	err := s.node.StartCluster(s.members, false, s.fsm, s.config)
+	err := s.node.StartCluster(s.members, s.join, s.fsm, s.config)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	if s.options.snapshotInterval > 0 || s.options.snapshotThresholdBytes > 0 {
+		go s.snapshot(ctx)
+	}
+	if s.options.compactionInterval > 0 {
+		go s.compact(ctx)
+	}
 	return nil
 }
 
+// snapshot takes snapshots of the partition in addition to snapshots triggered by the log size. If an interval
+// is configured, a snapshot is taken periodically. If a byte threshold is configured, a snapshot is also taken
+// once the entries applied since the last snapshot exceed the threshold.
+func (s *Server) snapshot(ctx context.Context) {
+	var tickC <-chan time.Time
+	if s.options.snapshotInterval > 0 {
+		ticker := time.NewTicker(s.options.snapshotInterval)
+		defer ticker.Stop()
+		tickC = ticker.C
+	}
+
+	var checkC <-chan time.Time
+	if s.options.snapshotThresholdBytes > 0 {
+		checkTicker := time.NewTicker(snapshotCheckInterval)
+		defer checkTicker.Stop()
+		checkC = checkTicker.C
+	}
+
+	for {
+		select {
+		case <-tickC:
+			s.requestSnapshot(ctx)
+		case <-checkC:
+			if s.stats.getAppliedBytes() >= s.options.snapshotThresholdBytes {
+				s.requestSnapshot(ctx)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// requestSnapshot takes a snapshot of the partition
+func (s *Server) requestSnapshot(ctx context.Context) {
+	snapshotCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	index, err := s.node.SyncRequestSnapshot(snapshotCtx, s.clusterID, dragonboat.DefaultSnapshotOption)
+	cancel()
+	if err != nil {
+		log.Debugf("Snapshot of partition %d skipped: %s", s.clusterID, err)
+	} else {
+		log.Debugf("Snapshot of partition %d taken at index %d", s.clusterID, index)
+	}
+}
+
+// compact periodically compacts the log of the partition up to its last snapshot when automatic compaction is disabled
+func (s *Server) compact(ctx context.Context) {
+	ticker := time.NewTicker(s.options.compactionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			compactCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			err := compactLog(compactCtx, s.node, s.clusterID, s.config.NodeID, s.stats)
+			cancel()
+			if err != nil {
+				log.Warnf("Failed to compact log of partition %d: %s", s.clusterID, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // Stop stops the server
 func (s *Server) Stop() error {
 	log.Infof("Stopping server for partition %d", s.clusterID)
+	if s.cancel != nil {
+		s.cancel()
+	}
 	err := s.node.StopCluster(s.clusterID)
 	if err != nil {
 		log.Error(err)
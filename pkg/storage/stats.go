@@ -0,0 +1,264 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/lni/dragonboat/v3"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// statsInterval is the interval at which member statistics are published
+const statsInterval = 10 * time.Second
+
+// snapshotDirPrefix is the prefix of the directories in which dragonboat stores snapshots
+const snapshotDirPrefix = "snapshot-"
+
+// memberStats tracks the replication statistics of the local member of a partition.
+// dragonboat does not expose the applied or commit index of regular state machines, so the applied
+// index is estimated by counting the entries applied to the state machine since the last snapshot
+// from which the member recovered. Entries not applied to the state machine are not counted.
+type memberStats struct {
+	term                uint64
+	recoveredIndex      uint64
+	applied             uint64
+	appliedBytes        uint64
+	compactedIndex      uint64
+	compactionRequested bool
+	mu                  sync.RWMutex
+}
+
+// entryApplied records an entry of the given size applied to the state machine
+func (s *memberStats) entryApplied(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applied++
+	s.appliedBytes += uint64(size)
+}
+
+// snapshotRecovered resets the count of applied entries when the state machine is recovered from a snapshot
+func (s *memberStats) snapshotRecovered() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applied = 0
+	s.appliedBytes = 0
+}
+
+// snapshotCreated resets the size of the entries applied since the last snapshot
+func (s *memberStats) snapshotCreated() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appliedBytes = 0
+}
+
+// setCompactionRequested sets whether a compaction of the log has been requested
+func (s *memberStats) setCompactionRequested(requested bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compactionRequested = requested
+}
+
+// logCompacted sets the index up to which the log was compacted, returning whether the compaction was requested
+func (s *memberStats) logCompacted(index uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requested := s.compactionRequested
+	s.compactedIndex = index
+	s.compactionRequested = false
+	return requested
+}
+
+// setRecoveredIndex sets the index of the snapshot from which the state machine was last recovered
+func (s *memberStats) setRecoveredIndex(index uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recoveredIndex = index
+}
+
+// setTerm sets the last known term
+func (s *memberStats) setTerm(term uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if term > s.term {
+		s.term = term
+	}
+}
+
+// getTerm returns the last known term
+func (s *memberStats) getTerm() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.term
+}
+
+// getAppliedIndex returns the estimated index of the last entry applied to the state machine
+func (s *memberStats) getAppliedIndex() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.recoveredIndex + s.applied
+}
+
+// getAppliedBytes returns the size in bytes of the entries applied since the last snapshot
+func (s *memberStats) getAppliedBytes() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.appliedBytes
+}
+
+// getLogSize returns the estimated number of entries retained in the log since it was last compacted
+func (s *memberStats) getLogSize() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	applied := s.recoveredIndex + s.applied
+	if applied < s.compactedIndex {
+		return 0
+	}
+	return applied - s.compactedIndex
+}
+
+// getStats returns the statistics of the local member of the given partition
+func (p *Protocol) getStats(partitionID uint64) *memberStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats, ok := p.stats[partitionID]
+	if !ok {
+		stats = &memberStats{}
+		p.stats[partitionID] = stats
+	}
+	return stats
+}
+
+// publishStats periodically publishes the replication statistics of the local members of all partitions
+// and the usage of the node's data volume
+func (p *Protocol) publishStats(ctx context.Context) {
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, event := range p.getStatsEvents() {
+				p.listener.publish(RaftEvent{
+					Timestamp: time.Now(),
+					Event: &RaftEvent_MemberStats{
+						MemberStats: event,
+					},
+				})
+			}
+			event, err := p.getDiskUsageEvent()
+			if err != nil {
+				log.Warnf("Failed to read data volume usage: %s", err)
+				continue
+			}
+			p.listener.publish(RaftEvent{
+				Timestamp: time.Now(),
+				Event: &RaftEvent_DiskUsage{
+					DiskUsage: event,
+				},
+			})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// getStatsEvents returns the statistics of the local members of all partitions
+func (p *Protocol) getStatsEvents() []*MemberStatsEvent {
+	node, err := p.getNodeHost()
+	if err != nil {
+		return nil
+	}
+
+	info := node.GetNodeHostInfo(dragonboat.NodeHostInfoOption{SkipLogInfo: true})
+	events := make([]*MemberStatsEvent, 0, len(info.ClusterInfoList))
+	for _, cluster := range info.ClusterInfoList {
+		if cluster.Pending {
+			continue
+		}
+
+		stats := p.getStats(cluster.ClusterID)
+		event := &MemberStatsEvent{
+			PartitionEvent: PartitionEvent{
+				Partition: cluster.ClusterID,
+			},
+			Term:                  stats.getTerm(),
+			Learner:               cluster.IsObserver,
+			EstimatedAppliedIndex: stats.getAppliedIndex(),
+			LogSize:               stats.getLogSize(),
+		}
+
+		// Members that don't know of a leader are assumed to be campaigning for leadership
+		leaderID, ok, err := node.GetLeaderID(cluster.ClusterID)
+		switch {
+		case cluster.IsLeader:
+			event.Role = MemberStatsEvent_LEADER
+			event.Leader = p.getMemberID(cluster.ClusterID, cluster.NodeID)
+		case err != nil || !ok:
+			event.Role = MemberStatsEvent_CANDIDATE
+		default:
+			event.Role = MemberStatsEvent_FOLLOWER
+			event.Leader = p.getMemberID(cluster.ClusterID, leaderID)
+		}
+
+		snapshotIndex, snapshotSize, err := getSnapshotStats(cluster.ClusterID, cluster.NodeID)
+		if err != nil {
+			log.Warnf("Failed to read snapshot for partition %d: %s", cluster.ClusterID, err)
+		} else {
+			event.LastSnapshotIndex = snapshotIndex
+			event.SnapshotSize = snapshotSize
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// getSnapshotStats returns the index and size in bytes of the last snapshot of the given member
+func getSnapshotStats(clusterID uint64, nodeID uint64) (uint64, uint64, error) {
+	// Snapshots are stored in <dir>/<hostname>/<deployment>/snapshot-part-<n>/snapshot-<cluster>-<node>/snapshot-<index>
+	// where the index is a fixed width hex string, so the last snapshot directory sorts last
+	pattern := filepath.Join(dataDir, "*", "*", "snapshot-part-*", fmt.Sprintf("snapshot-%d-%d", clusterID, nodeID), snapshotDirPrefix+strings.Repeat("?", 16))
+	dirs, err := filepath.Glob(pattern)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(dirs) == 0 {
+		return 0, 0, nil
+	}
+	sort.Strings(dirs)
+	dir := dirs[len(dirs)-1]
+
+	index, err := strconv.ParseUint(strings.TrimPrefix(filepath.Base(dir), snapshotDirPrefix), 16, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	var size uint64
+	for _, file := range files {
+		if !file.IsDir() {
+			size += uint64(file.Size())
+		}
+	}
+	return index, size, nil
+}